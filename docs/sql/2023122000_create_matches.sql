@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS `matches` (
+    `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `user_id` INT NOT NULL,
+    `matched_user_id` INT NOT NULL,
+    `status` INT NOT NULL DEFAULT '1',
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `created_by` INT,
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `updated_by` INT,
+    `deleted_at`TIMESTAMP,
+    `deleted_by` INT,
+    INDEX (`user_id`, `matched_user_id`),
+    INDEX (`matched_user_id`),
+    FOREIGN KEY (`user_id`) REFERENCES users(`id`),
+    FOREIGN KEY (`matched_user_id`) REFERENCES users(`id`)
+) ENGINE = INNODB;
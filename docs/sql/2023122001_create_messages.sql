@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS `messages` (
+    `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `match_id` INT NOT NULL,
+    `sender_id` INT NOT NULL,
+    `receiver_id` INT NOT NULL,
+    `content` TEXT NOT NULL,
+    `read_at` TIMESTAMP NULL,
+    `status` INT NOT NULL DEFAULT '1',
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `created_by` INT,
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `updated_by` INT,
+    `deleted_at`TIMESTAMP,
+    `deleted_by` INT,
+    INDEX (`match_id`, `id`),
+    INDEX (`receiver_id`, `read_at`),
+    FOREIGN KEY (`match_id`) REFERENCES matches(`id`),
+    FOREIGN KEY (`sender_id`) REFERENCES users(`id`),
+    FOREIGN KEY (`receiver_id`) REFERENCES users(`id`)
+) ENGINE = INNODB;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS `sessions` (
+    `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `user_id` INT NOT NULL,
+    `refresh_token_hash` VARCHAR(64) NOT NULL,
+    `expires_at` TIMESTAMP NOT NULL,
+    `status` INT NOT NULL DEFAULT '1',
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `created_by` INT,
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `updated_by` INT,
+    `deleted_at`TIMESTAMP,
+    `deleted_by` INT,
+    UNIQUE (`refresh_token_hash`),
+    INDEX (`user_id`, `status`),
+    FOREIGN KEY (`user_id`) REFERENCES users(`id`)
+) ENGINE = INNODB;
@@ -0,0 +1,21 @@
+ALTER TABLE `premium_features` ADD COLUMN `duration_days` INT NOT NULL DEFAULT '30' AFTER `flag`;
+
+CREATE TABLE IF NOT EXISTS `subscriptions` (
+    `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `user_id` INT NOT NULL,
+    `premium_feature_id` INT NOT NULL,
+    `started_at` TIMESTAMP NOT NULL,
+    `expires_at` TIMESTAMP NOT NULL,
+    `source` VARCHAR(32) NOT NULL,
+    `status` INT NOT NULL DEFAULT '1',
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `created_by` INT,
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `updated_by` INT,
+    `deleted_at`TIMESTAMP,
+    `deleted_by` INT,
+    INDEX (`user_id`, `status`),
+    INDEX (`status`, `expires_at`),
+    FOREIGN KEY (`user_id`) REFERENCES users(`id`),
+    FOREIGN KEY (`premium_feature_id`) REFERENCES premium_features(`id`)
+) ENGINE = INNODB;
@@ -0,0 +1,17 @@
+INSERT INTO subscriptions (user_id, premium_feature_id, started_at, expires_at, source, created_by)
+SELECT 
+    u.id, u.premium_feature_id, CURRENT_TIMESTAMP, DATE_ADD(CURRENT_TIMESTAMP, INTERVAL pf.duration_days DAY), 'legacy', u.id
+FROM 
+    users u
+    JOIN premium_features pf ON pf.id = u.premium_feature_id
+WHERE 
+    NOT EXISTS (
+        SELECT 1 FROM subscriptions s 
+        WHERE s.user_id = u.id AND s.premium_feature_id = u.premium_feature_id AND s.status = 1
+    );
+
+ALTER TABLE users 
+DROP FOREIGN KEY users_ibfk_1;
+
+ALTER TABLE users 
+DROP COLUMN premium_feature_id;
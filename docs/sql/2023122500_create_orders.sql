@@ -0,0 +1,22 @@
+ALTER TABLE `premium_features` ADD COLUMN `price` INT NOT NULL DEFAULT '0' AFTER `duration_days`;
+
+CREATE TABLE IF NOT EXISTS `orders` (
+    `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `user_id` INT NOT NULL,
+    `premium_feature_id` INT NOT NULL,
+    `amount` INT NOT NULL,
+    `provider` VARCHAR(32) NOT NULL,
+    `reference` VARCHAR(128) NOT NULL,
+    `paid_at` TIMESTAMP NULL,
+    `status` INT NOT NULL DEFAULT '1',
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `created_by` INT,
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `updated_by` INT,
+    `deleted_at`TIMESTAMP,
+    `deleted_by` INT,
+    UNIQUE (`provider`, `reference`),
+    INDEX (`user_id`, `status`),
+    FOREIGN KEY (`user_id`) REFERENCES users(`id`),
+    FOREIGN KEY (`premium_feature_id`) REFERENCES premium_features(`id`)
+) ENGINE = INNODB;
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS `quota_policies` (
+    `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `activity` VARCHAR(16) NOT NULL,
+    `premium_feature_id` INT,
+    `period` VARCHAR(16) NOT NULL,
+    `max_activity` INT NOT NULL,
+    `status` INT NOT NULL DEFAULT '1',
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `created_by` INT,
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `updated_by` INT,
+    `deleted_at`TIMESTAMP,
+    `deleted_by` INT,
+    INDEX (`activity`, `status`),
+    FOREIGN KEY (`premium_feature_id`) REFERENCES premium_features(`id`)
+) ENGINE = INNODB;
+
+-- same limits as the previously hard-coded ones: 10 swipes of any kind a day, unlimited with the premium feature
+INSERT INTO quota_policies (activity, premium_feature_id, period, max_activity)
+VALUES 
+    ('any', NULL, 'daily', 10);
+
+INSERT INTO quota_policies (activity, premium_feature_id, period, max_activity)
+SELECT 
+    'any', id, 'daily', -1
+FROM 
+    premium_features
+WHERE 
+    flag = 'no-swipe-quota-limit';
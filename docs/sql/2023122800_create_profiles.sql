@@ -0,0 +1,56 @@
+CREATE TABLE IF NOT EXISTS `profiles` (
+    `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `user_id` INT NOT NULL,
+    `display_name` VARCHAR(64) NOT NULL,
+    `birthdate` DATE NOT NULL,
+    `gender` VARCHAR(16) NOT NULL,
+    `bio` VARCHAR(500) NOT NULL DEFAULT '',
+    `height_cm` INT NOT NULL DEFAULT '0',
+    `job` VARCHAR(128) NOT NULL DEFAULT '',
+    `status` INT NOT NULL DEFAULT '1',
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `created_by` INT,
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `updated_by` INT,
+    `deleted_at`TIMESTAMP,
+    `deleted_by` INT,
+    UNIQUE (`user_id`),
+    INDEX (`gender`, `birthdate`),
+    FOREIGN KEY (`user_id`) REFERENCES users(`id`)
+) ENGINE = INNODB;
+
+CREATE TABLE IF NOT EXISTS `profile_interested_genders` (
+    `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `user_id` INT NOT NULL,
+    `gender` VARCHAR(16) NOT NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (`user_id`, `gender`),
+    INDEX (`gender`),
+    FOREIGN KEY (`user_id`) REFERENCES users(`id`)
+) ENGINE = INNODB;
+
+CREATE TABLE IF NOT EXISTS `profile_interests` (
+    `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `user_id` INT NOT NULL,
+    `tag` VARCHAR(32) NOT NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (`user_id`, `tag`),
+    INDEX (`tag`),
+    FOREIGN KEY (`user_id`) REFERENCES users(`id`)
+) ENGINE = INNODB;
+
+CREATE TABLE IF NOT EXISTS `profile_photos` (
+    `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `user_id` INT NOT NULL,
+    `url` VARCHAR(512) NOT NULL,
+    `position` INT NOT NULL,
+    `status` INT NOT NULL DEFAULT '1',
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `created_by` INT,
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `updated_by` INT,
+    `deleted_at`TIMESTAMP,
+    `deleted_by` INT,
+    INDEX (`user_id`, `position`),
+    FOREIGN KEY (`user_id`) REFERENCES users(`id`)
+) ENGINE = INNODB;
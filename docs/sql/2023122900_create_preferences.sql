@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS `preferences` (
+    `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `user_id` INT NOT NULL,
+    `min_age` INT NOT NULL DEFAULT '18',
+    `max_age` INT NOT NULL DEFAULT '99',
+    `max_distance_km` INT NOT NULL DEFAULT '0',
+    `status` INT NOT NULL DEFAULT '1',
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `created_by` INT,
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `updated_by` INT,
+    `deleted_at`TIMESTAMP,
+    `deleted_by` INT,
+    UNIQUE (`user_id`),
+    FOREIGN KEY (`user_id`) REFERENCES users(`id`)
+) ENGINE = INNODB;
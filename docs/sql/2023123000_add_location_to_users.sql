@@ -0,0 +1,4 @@
+ALTER TABLE `users`
+    ADD COLUMN `latitude` DOUBLE AFTER `timezone`,
+    ADD COLUMN `longitude` DOUBLE AFTER `latitude`,
+    ADD INDEX (`latitude`, `longitude`);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS `recomendation_deck_cards` (
+    `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `user_id` INT NOT NULL,
+    `candidate_id` INT NOT NULL,
+    `expires_at` TIMESTAMP NOT NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (`user_id`, `candidate_id`),
+    INDEX (`user_id`, `expires_at`),
+    FOREIGN KEY (`user_id`) REFERENCES users(`id`),
+    FOREIGN KEY (`candidate_id`) REFERENCES users(`id`)
+) ENGINE = INNODB;
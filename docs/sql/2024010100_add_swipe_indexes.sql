@@ -0,0 +1,4 @@
+ALTER TABLE `user_activities`
+    ADD INDEX (`user_id`, `liked_user_id`),
+    ADD INDEX (`user_id`, `passed_user_id`, `created_at`),
+    ADD INDEX (`liked_user_id`);
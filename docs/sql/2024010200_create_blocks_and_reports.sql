@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS `blocks` (
+    `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `user_id` INT NOT NULL,
+    `blocked_user_id` INT NOT NULL,
+    `status` INT NOT NULL DEFAULT '1',
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `created_by` INT,
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `updated_by` INT,
+    `deleted_at`TIMESTAMP,
+    `deleted_by` INT,
+    UNIQUE (`user_id`, `blocked_user_id`),
+    INDEX (`blocked_user_id`),
+    FOREIGN KEY (`user_id`) REFERENCES users(`id`),
+    FOREIGN KEY (`blocked_user_id`) REFERENCES users(`id`)
+) ENGINE = INNODB;
+
+-- status of a report is 1 open, 2 resolved and 3 dismissed
+CREATE TABLE IF NOT EXISTS `reports` (
+    `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `reporter_id` INT NOT NULL,
+    `reported_user_id` INT NOT NULL,
+    `reason` VARCHAR(32) NOT NULL,
+    `description` TEXT,
+    `status` INT NOT NULL DEFAULT '1',
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `created_by` INT,
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `updated_by` INT,
+    `deleted_at`TIMESTAMP,
+    `deleted_by` INT,
+    INDEX (`status`, `created_at`),
+    INDEX (`reported_user_id`),
+    FOREIGN KEY (`reporter_id`) REFERENCES users(`id`),
+    FOREIGN KEY (`reported_user_id`) REFERENCES users(`id`)
+) ENGINE = INNODB;
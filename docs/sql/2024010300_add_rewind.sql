@@ -0,0 +1,20 @@
+ALTER TABLE `user_activities` 
+    ADD COLUMN `rewound_at` TIMESTAMP NULL AFTER `liked_user_id`,
+    ADD INDEX (`user_id`, `rewound_at`);
+
+INSERT INTO premium_features (name, flag) 
+VALUES 
+    ('Rewind', 'rewind');
+
+-- rewinding needs the premium feature, which comes with 5 rewinds a day
+INSERT INTO quota_policies (activity, premium_feature_id, period, max_activity)
+VALUES 
+    ('rewind', NULL, 'daily', 0);
+
+INSERT INTO quota_policies (activity, premium_feature_id, period, max_activity)
+SELECT 
+    'rewind', id, 'daily', 5
+FROM 
+    premium_features
+WHERE 
+    flag = 'rewind';
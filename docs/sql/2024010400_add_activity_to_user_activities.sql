@@ -0,0 +1,18 @@
+ALTER TABLE `user_activities` 
+    ADD COLUMN `activity` VARCHAR(16) NOT NULL DEFAULT 'like' AFTER `liked_user_id`,
+    ADD INDEX (`liked_user_id`, `activity`);
+
+UPDATE user_activities SET activity = IF(passed_user_id IS NULL, 'like', 'pass');
+
+-- one super like a day, five with the premium feature
+INSERT INTO quota_policies (activity, premium_feature_id, period, max_activity)
+VALUES 
+    ('superlike', NULL, 'daily', 1);
+
+INSERT INTO quota_policies (activity, premium_feature_id, period, max_activity)
+SELECT 
+    'superlike', id, 'daily', 5
+FROM 
+    premium_features
+WHERE 
+    flag = 'no-swipe-quota-limit';
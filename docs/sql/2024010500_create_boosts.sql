@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS `boosts` (
+    `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `user_id` INT NOT NULL,
+    `started_at` TIMESTAMP NOT NULL,
+    `ends_at` TIMESTAMP NOT NULL,
+    `impressions` INT NOT NULL DEFAULT '0',
+    `status` INT NOT NULL DEFAULT '1',
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `created_by` INT,
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `updated_by` INT,
+    `deleted_at`TIMESTAMP,
+    `deleted_by` INT,
+    INDEX (`user_id`, `ends_at`),
+    FOREIGN KEY (`user_id`) REFERENCES users(`id`)
+) ENGINE = INNODB;
+
+INSERT INTO premium_features (name, flag) 
+VALUES 
+    ('Boost', 'boost');
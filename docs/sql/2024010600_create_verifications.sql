@@ -0,0 +1,20 @@
+-- status of a verification is 1 pending, 2 approved and 3 rejected
+CREATE TABLE IF NOT EXISTS `verifications` (
+    `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `user_id` INT NOT NULL,
+    `selfie` VARCHAR(255) NOT NULL,
+    `reason` VARCHAR(255),
+    `status` INT NOT NULL DEFAULT '1',
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `created_by` INT,
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `updated_by` INT,
+    `deleted_at`TIMESTAMP,
+    `deleted_by` INT,
+    INDEX (`status`, `created_at`),
+    INDEX (`user_id`),
+    FOREIGN KEY (`user_id`) REFERENCES users(`id`)
+) ENGINE = INNODB;
+
+-- an approved verification holds for a year before the user has to verify again
+UPDATE premium_features SET duration_days = 365 WHERE flag = 'verified';
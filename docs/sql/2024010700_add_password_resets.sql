@@ -0,0 +1,22 @@
+ALTER TABLE `users` 
+    ADD COLUMN `email` VARCHAR(255) AFTER `user_name`,
+    ADD UNIQUE (`email`);
+
+-- status of a password reset is 1 unused, 2 used and -1 revoked by a newer one
+CREATE TABLE IF NOT EXISTS `password_resets` (
+    `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    `user_id` INT NOT NULL,
+    `token_hash` VARCHAR(64) NOT NULL,
+    `expires_at` TIMESTAMP NOT NULL,
+    `used_at` TIMESTAMP NULL,
+    `status` INT NOT NULL DEFAULT '1',
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `created_by` INT,
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `updated_by` INT,
+    `deleted_at`TIMESTAMP,
+    `deleted_by` INT,
+    UNIQUE (`token_hash`),
+    INDEX (`user_id`, `status`),
+    FOREIGN KEY (`user_id`) REFERENCES users(`id`)
+) ENGINE = INNODB;
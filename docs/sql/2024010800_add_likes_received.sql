@@ -0,0 +1,4 @@
+-- seeing who liked you unblurred and getting notified of new likes comes with its own feature
+INSERT INTO premium_features (name, flag) 
+VALUES 
+    ('Likes Received', 'likes-received');
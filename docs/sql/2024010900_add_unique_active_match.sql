@@ -0,0 +1,16 @@
+-- keep the oldest when mutual likes at the same time already created a pair twice
+UPDATE 
+    matches m
+    JOIN matches older ON older.user_id = m.user_id 
+        AND older.matched_user_id = m.matched_user_id 
+        AND older.status = 1 
+        AND older.id < m.id
+SET 
+    m.status = -1, m.deleted_at = CURRENT_TIMESTAMP
+WHERE 
+    m.status = 1;
+
+-- a pair has at most one active match, unmatched rows are NULL here so the pair can match again
+ALTER TABLE `matches` 
+    ADD COLUMN `active_pair` TINYINT AS (IF(`status` = 1, 1, NULL)) STORED,
+    ADD UNIQUE INDEX (`user_id`, `matched_user_id`, `active_pair`);
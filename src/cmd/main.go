@@ -6,9 +6,11 @@ import (
 	"DatingApp/src/models"
 	"DatingApp/src/repositories"
 	"DatingApp/src/services"
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/joho/godotenv"
@@ -35,10 +37,15 @@ func main() {
 		panic(err)
 	}
 
-	repo := repositories.Init(repositories.Param{Db: db})
+	repo, err := repositories.Init(repositories.Param{Db: db})
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 
 	srv := services.Init(services.Param{Repositories: repo})
 
+	go srv.Subscription.RunExpirer(context.Background(), time.Minute)
+
 	midlwre := middleware.Init(middleware.InitParam{Service: srv})
 
 	hndlr := handler.Init(handler.InitParam{Service: srv, Middleware: midlwre})
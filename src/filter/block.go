@@ -0,0 +1,7 @@
+package filter
+
+type BlockFilter struct {
+	Id            int `db:"id" json:"id" form:"id"`
+	UserId        int `db:"user_id" json:"userId" form:"userId"`
+	BlockedUserId int `db:"blocked_user_id" json:"blockedUserId" form:"blockedUserId"`
+}
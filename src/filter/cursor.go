@@ -0,0 +1,10 @@
+package filter
+
+type Cursor struct {
+	Cursor int64 `json:"cursor" form:"cursor"`
+	Take   int   `json:"take" form:"take"`
+}
+
+func (c *Cursor) SetDefault() {
+	c.Take = 20
+}
@@ -0,0 +1,7 @@
+package filter
+
+type DeckCardFilter struct {
+	Id          int `db:"id" json:"id" form:"id"`
+	UserId      int `db:"user_id" json:"userId" form:"userId"`
+	CandidateId int `db:"candidate_id" json:"candidateId" form:"candidateId"`
+}
@@ -3,6 +3,7 @@ package filter
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 )
 
@@ -19,8 +20,9 @@ func (f *Paging[T]) SetDefault() {
 	f.Take = -1
 }
 
-func (f *Paging[T]) QueryBuilder() string {
+func (f *Paging[T]) QueryBuilder() (string, []interface{}) {
 	query := " WHERE 1=1"
+	args := []interface{}{}
 	if f.IsActive {
 		query += " AND status=1"
 	}
@@ -30,17 +32,18 @@ func (f *Paging[T]) QueryBuilder() string {
 	// Adding where statement
 	for i := 0; i < tpe.NumField(); i++ {
 		if !isEmpty(fmt.Sprint(ref.Field(i).Interface())) {
-			query += " AND " + tpe.Field(i).Tag.Get("db") + "='" + fmt.Sprint(ref.Field(i).Interface()) + "'"
+			query += " AND " + tpe.Field(i).Tag.Get("db") + "=?"
+			args = append(args, ref.Field(i).Interface())
 		}
 	}
 
-	return query
+	return query, args
 }
 
 func (f *Paging[T]) PaginationQuery() string {
 	query := ""
-	// Adding OrderBy Statement
-	if f.OrderBy != "" {
+	// Adding OrderBy Statement, only on known columns since it can't be a placeholder
+	if f.OrderBy != "" && f.isValidOrderBy() {
 		query += " ORDER BY " + f.OrderBy
 	}
 
@@ -54,6 +57,24 @@ func (f *Paging[T]) PaginationQuery() string {
 	return query
 }
 
+func (f *Paging[T]) isValidOrderBy() bool {
+	orders := strings.Fields(f.OrderBy)
+	if len(orders) == 0 || len(orders) > 2 {
+		return false
+	}
+	if len(orders) == 2 && !strings.EqualFold(orders[1], "asc") && !strings.EqualFold(orders[1], "desc") {
+		return false
+	}
+
+	tpe := reflect.TypeOf(f.Filter)
+	for i := 0; i < tpe.NumField(); i++ {
+		if tpe.Field(i).Tag.Get("db") == orders[0] {
+			return true
+		}
+	}
+	return false
+}
+
 func isEmpty(check string) bool {
 	return check == "0" || check == "" || check == fmt.Sprint(time.Time{})
 }
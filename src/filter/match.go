@@ -0,0 +1,7 @@
+package filter
+
+type MatchFilter struct {
+	Id            int `db:"id" json:"id" form:"id"`
+	UserId        int `db:"user_id" json:"userId" form:"userId"`
+	MatchedUserId int `db:"matched_user_id" json:"matchedUserId" form:"matchedUserId"`
+}
@@ -0,0 +1,8 @@
+package filter
+
+type MessageFilter struct {
+	Id         int `db:"id" json:"id" form:"id"`
+	MatchId    int `db:"match_id" json:"matchId" form:"matchId"`
+	SenderId   int `db:"sender_id" json:"senderId" form:"senderId"`
+	ReceiverId int `db:"receiver_id" json:"receiverId" form:"receiverId"`
+}
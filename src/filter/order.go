@@ -0,0 +1,8 @@
+package filter
+
+type OrderFilter struct {
+	Id        int    `db:"id" json:"id" form:"id"`
+	UserId    int    `db:"user_id" json:"userId" form:"userId"`
+	Provider  string `db:"provider" json:"provider" form:"provider"`
+	Reference string `db:"reference" json:"reference" form:"reference"`
+}
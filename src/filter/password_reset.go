@@ -0,0 +1,7 @@
+package filter
+
+type PasswordResetFilter struct {
+	Id        int    `db:"id" json:"id" form:"id"`
+	UserId    int    `db:"user_id" json:"userId" form:"userId"`
+	TokenHash string `db:"token_hash" json:"-" form:"-"`
+}
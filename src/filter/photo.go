@@ -0,0 +1,7 @@
+package filter
+
+type PhotoFilter struct {
+	Id       int `db:"id" json:"id" form:"id"`
+	UserId   int `db:"user_id" json:"userId" form:"userId"`
+	Position int `db:"position" json:"position" form:"position"`
+}
@@ -0,0 +1,6 @@
+package filter
+
+type PreferenceFilter struct {
+	Id     int `db:"id" json:"id" form:"id"`
+	UserId int `db:"user_id" json:"userId" form:"userId"`
+}
@@ -0,0 +1,7 @@
+package filter
+
+type ProfileFilter struct {
+	Id     int    `db:"id" json:"id" form:"id"`
+	UserId int    `db:"user_id" json:"userId" form:"userId"`
+	Gender string `db:"gender" json:"gender" form:"gender"`
+}
@@ -0,0 +1,8 @@
+package filter
+
+type QuotaPolicyFilter struct {
+	Id               int    `db:"id" json:"id" form:"id"`
+	Activity         string `db:"activity" json:"activity" form:"activity"`
+	PremiumFeatureId int    `db:"premium_feature_id" json:"premiumFeatureId" form:"premiumFeatureId"`
+	Period           string `db:"period" json:"period" form:"period"`
+}
@@ -0,0 +1,9 @@
+package filter
+
+type ReportFilter struct {
+	Id             int    `db:"id" json:"id" form:"id"`
+	ReporterId     int    `db:"reporter_id" json:"reporterId" form:"reporterId"`
+	ReportedUserId int    `db:"reported_user_id" json:"reportedUserId" form:"reportedUserId"`
+	Reason         string `db:"reason" json:"reason" form:"reason"`
+	Status         int    `db:"status" json:"status" form:"status"`
+}
@@ -0,0 +1,7 @@
+package filter
+
+type SessionFilter struct {
+	Id               int    `db:"id" json:"id" form:"id"`
+	UserId           int    `db:"user_id" json:"userId" form:"userId"`
+	RefreshTokenHash string `db:"refresh_token_hash" json:"-" form:"-"`
+}
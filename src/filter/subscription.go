@@ -0,0 +1,7 @@
+package filter
+
+type SubscriptionFilter struct {
+	Id               int `db:"id" json:"id" form:"id"`
+	UserId           int `db:"user_id" json:"userId" form:"userId"`
+	PremiumFeatureId int `db:"premium_feature_id" json:"premiumFeatureId" form:"premiumFeatureId"`
+}
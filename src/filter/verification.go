@@ -0,0 +1,7 @@
+package filter
+
+type VerificationFilter struct {
+	Id     int `db:"id" json:"id" form:"id"`
+	UserId int `db:"user_id" json:"userId" form:"userId"`
+	Status int `db:"status" json:"status" form:"status"`
+}
@@ -1,11 +1,13 @@
 package formatter
 
 type Auth struct {
-	Data  interface{} `json:"profile"`
-	Token string      `json:"token"`
+	Data         interface{} `json:"profile,omitempty"`
+	Token        string      `json:"token"`
+	RefreshToken string      `json:"refreshToken"`
 }
 
-func (f *Auth) AuthFormat(data interface{}, token string) {
+func (f *Auth) AuthFormat(data interface{}, token, refreshToken string) {
 	f.Data = data
 	f.Token = "Bearer " + token
+	f.RefreshToken = refreshToken
 }
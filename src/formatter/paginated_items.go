@@ -6,6 +6,8 @@ type PaginatedItems struct {
 	PageSize  int         `json:"pageSize"`
 	DataCount int         `json:"dataCount"`
 	PageCount int         `json:"pageCount"`
+	// NextCursor is only filled for cursor paginated items, 0 means no more data
+	NextCursor int64 `json:"nextCursor,omitempty"`
 }
 
 func (f *PaginatedItems) Format(pageIndex int, pageSize, count float64, take float64, data interface{}) {
@@ -24,3 +26,12 @@ func (f *PaginatedItems) Format(pageIndex int, pageSize, count float64, take flo
 		f.PageCount = 1
 	}
 }
+
+func (f *PaginatedItems) FormatCursor(take int, nextCursor int64, count int, data interface{}) {
+	f.Data = data
+	f.PageIndex = 1
+	f.PageSize = take
+	f.DataCount = count
+	f.PageCount = 1
+	f.NextCursor = nextCursor
+}
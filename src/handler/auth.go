@@ -77,8 +77,198 @@ func (h *handler) Login(ctx *gin.Context) {
 	}
 
 	auth := formatter.Auth{}
-	auth.AuthFormat(loggedinUser, token)
+	auth.AuthFormat(loggedinUser, token.AccessToken, token.RefreshToken)
 	response := models.APIResponse("Loged In", http.StatusOK, "success", auth, nil)
 
 	ctx.JSON(http.StatusOK, response)
 }
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Auth
+//	@Param		refreshInput	body	models.RefreshToken	true	"refreshInput"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/auth/refresh [post]
+func (h *handler) RefreshToken(ctx *gin.Context) {
+	var input models.RefreshToken
+
+	err := ctx.ShouldBindJSON(&input)
+	if err != nil {
+		response := models.APIResponse("Refresh Token Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	token, err := h.service.Auth.Refresh(ctx, input)
+	if err != nil {
+		response := models.APIResponse("Refresh Token Failed", http.StatusUnauthorized, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnauthorized, response)
+		return
+	}
+
+	auth := formatter.Auth{}
+	auth.AuthFormat(nil, token.AccessToken, token.RefreshToken)
+	response := models.APIResponse("Token Refreshed", http.StatusOK, "success", auth, nil)
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Auth
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/auth/logout [post]
+//	@Security	ApiKeyAuth
+func (h *handler) Logout(ctx *gin.Context) {
+	err := h.service.Auth.Logout(ctx)
+	if err != nil {
+		response := models.APIResponse("Logout Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusInternalServerError, response)
+		return
+	}
+	response := models.APIResponse("Logged Out", http.StatusOK, "Success", nil, nil)
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Auth
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/auth/logout-all [post]
+//	@Security	ApiKeyAuth
+func (h *handler) LogoutAll(ctx *gin.Context) {
+	err := h.service.Auth.LogoutAll(ctx)
+	if err != nil {
+		response := models.APIResponse("Logout Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusInternalServerError, response)
+		return
+	}
+	response := models.APIResponse("Logged Out From All Devices", http.StatusOK, "Success", nil, nil)
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Auth
+//	@Param		forgotPasswordInput	body	models.ForgotPasswordRequest	true	"forgotPasswordInput"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/auth/password/forgot [post]
+func (h *handler) ForgotPassword(ctx *gin.Context) {
+	var input models.ForgotPasswordRequest
+
+	err := ctx.ShouldBindJSON(&input)
+	if err != nil {
+		response := models.APIResponse("Forgot Password Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	err = h.service.Auth.ForgotPassword(ctx, input)
+	if err != nil {
+		response := models.APIResponse("Forgot Password Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusInternalServerError, response)
+		return
+	}
+	response := models.APIResponse("Password Reset Mail Sent If The Email Is Registered", http.StatusOK, "Success", nil, nil)
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Auth
+//	@Param		resetPasswordInput	body	models.ResetPasswordRequest	true	"resetPasswordInput"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/auth/password/reset [post]
+func (h *handler) ResetPassword(ctx *gin.Context) {
+	var input models.ResetPasswordRequest
+
+	err := ctx.ShouldBindJSON(&input)
+	if err != nil {
+		response := models.APIResponse("Reset Password Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	err = h.service.Auth.ResetPassword(ctx, input)
+	if err != nil {
+		response := models.APIResponse("Reset Password Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
+		return
+	}
+	response := models.APIResponse("Password Reset", http.StatusOK, "Success", nil, nil)
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Auth
+//	@Param		changePasswordInput	body	models.ChangePasswordRequest	true	"changePasswordInput"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/user/me/password [put]
+//	@Security	ApiKeyAuth
+func (h *handler) ChangePassword(ctx *gin.Context) {
+	var input models.ChangePasswordRequest
+
+	err := ctx.ShouldBindJSON(&input)
+	if err != nil {
+		response := models.APIResponse("Change Password Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	err = h.service.Auth.ChangePassword(ctx, input)
+	if err != nil {
+		response := models.APIResponse("Change Password Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
+		return
+	}
+	response := models.APIResponse("Password Changed", http.StatusOK, "Success", nil, nil)
+
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"DatingApp/src/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Boost
+//	@Security	ApiKeyAuth
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/user/boost [POST]
+func (h *handler) Boost(ctx *gin.Context) {
+	boost, err := h.service.Boost.Boost(ctx)
+	if err != nil {
+		response := models.APIResponse("Boost Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
+		return
+	}
+
+	response := models.APIResponse("Boost Success", http.StatusOK, "Success", boost, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Boost
+//	@Security	ApiKeyAuth
+//	@Param		id	path	integer	true	"id"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/user/boost/{id}/stats [GET]
+func (h *handler) GetBoostStats(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response := models.APIResponse("Get Boost Stats Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	stats, err := h.service.Boost.GetStats(ctx, id)
+	if err != nil {
+		response := models.APIResponse("Get Boost Stats Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
+		return
+	}
+
+	response := models.APIResponse("Get Boost Stats Success", http.StatusOK, "Success", stats, nil)
+	ctx.JSON(http.StatusOK, response)
+}
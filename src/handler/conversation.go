@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/formatter"
+	"DatingApp/src/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Conversation
+//	@Security	ApiKeyAuth
+//	@Param		paging	query	filter.Paging[filter.MatchFilter]	false	"paging"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/conversation/ [GET]
+func (h *handler) GetConversation(ctx *gin.Context) {
+	var filter filter.Paging[filter.MatchFilter]
+	filter.SetDefault()
+
+	if err := h.BindParams(ctx, &filter); err != nil {
+		response := models.APIResponse("Get Conversation Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	conversations, count, err := h.service.Message.GetConversations(ctx, filter)
+	if err != nil {
+		response := models.APIResponse("Get Conversation Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusInternalServerError, response)
+		return
+	}
+	paginatedItems := formatter.PaginatedItems{}
+	paginatedItems.Format(filter.Page, float64(len(conversations)), float64(count), float64(filter.Take), conversations)
+
+	response := models.APIResponse("Get Conversation Success", http.StatusOK, "Success", paginatedItems, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Conversation
+//	@Security	ApiKeyAuth
+//	@Param		id		path	integer			true	"match id"
+//	@Param		cursor	query	filter.Cursor	false	"cursor"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/conversation/{id}/message [GET]
+func (h *handler) GetMessage(ctx *gin.Context) {
+	var cursor filter.Cursor
+	cursor.SetDefault()
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response := models.APIResponse("Get Message Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	if err := h.BindParams(ctx, &cursor); err != nil {
+		response := models.APIResponse("Get Message Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	messages, nextCursor, err := h.service.Message.GetMessages(ctx, id, cursor)
+	if err != nil {
+		response := models.APIResponse("Get Message Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusInternalServerError, response)
+		return
+	}
+	paginatedItems := formatter.PaginatedItems{}
+	paginatedItems.FormatCursor(cursor.Take, nextCursor, len(messages), messages)
+
+	response := models.APIResponse("Get Message Success", http.StatusOK, "Success", paginatedItems, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Conversation
+//	@Security	ApiKeyAuth
+//	@Param		id		path	integer				true	"match id"
+//	@Param		models	body	models.MessageInput	true	"models"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/conversation/{id}/message [POST]
+func (h *handler) SendMessage(ctx *gin.Context) {
+	var input models.Query[models.MessageInput]
+
+	if err := ctx.ShouldBindJSON(&input.Model); err != nil {
+		response := models.APIResponse("Send Message Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response := models.APIResponse("Send Message Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	if err := h.service.Message.Send(ctx, id, input); err != nil {
+		response := models.APIResponse("Send Message Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := models.APIResponse("Send Message Success", http.StatusOK, "Success", nil, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Conversation
+//	@Security	ApiKeyAuth
+//	@Param		id	path	integer	true	"match id"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/conversation/{id}/read [PUT]
+func (h *handler) ReadMessage(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response := models.APIResponse("Read Message Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	if err := h.service.Message.Read(ctx, id); err != nil {
+		response := models.APIResponse("Read Message Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := models.APIResponse("Read Message Success", http.StatusOK, "Success", nil, nil)
+	ctx.JSON(http.StatusOK, response)
+}
@@ -3,7 +3,10 @@ package handler
 import (
 	"DatingApp/docs/swagger"
 	"DatingApp/src/middleware"
+	"DatingApp/src/models"
 	"DatingApp/src/services"
+	"errors"
+	"io/fs"
 	"net/http"
 
 	"github.com/gin-contrib/cors"
@@ -63,16 +66,49 @@ func (h *handler) register() *gin.Engine {
 	// API Route
 	api.POST("/login", h.Login)
 	api.POST("/register", h.Register)
+	api.POST("/auth/refresh", h.RefreshToken)
+	api.POST("/auth/password/forgot", h.ForgotPassword)
+	api.POST("/auth/password/reset", h.ResetPassword)
+	api.POST("/payment/webhook", h.PaymentWebhook)
+	authApi := api.Group("/auth").Use(h.middleware.AuthMiddleware)
+	{
+		authApi.POST("/logout", h.Logout)
+		authApi.POST("/logout-all", h.LogoutAll)
+	}
+	api.GET("/ws", h.middleware.WebSocketAuthMiddleware, h.WebSocket)
+	// photo urls are signed, they are opened by clients that dont send the token like img tags
+	api.GET("/photos/*key", h.GetPhoto)
 	userApi := api.Group("/user").Use(h.middleware.AuthMiddleware)
 	{
 		userApi.GET("/", h.GetUser)
 		userApi.DELETE("/:id", h.DeleteUser)
-		userApi.PATCH("/subscribe", h.Subscribe)
+		// features are paid for through /payment/checkout, granting one for free is kept to admins
+		userApi.PATCH("/subscribe", h.middleware.RequireRole(models.RoleAdmin), h.Subscribe)
+		userApi.GET("/subscription", h.GetSubscription)
 		userApi.GET("/recomendation", h.UserRecomendation)
+		userApi.GET("/likes-received", h.GetLikesReceived)
+		userApi.POST("/boost", h.Boost)
+		userApi.GET("/boost/:id/stats", h.GetBoostStats)
+		userApi.GET("/me/profile", h.GetProfile)
+		userApi.PUT("/me/profile", h.UpdateProfile)
+		userApi.GET("/me/preferences", h.GetPreference)
+		userApi.PUT("/me/preferences", h.UpdatePreference)
+		userApi.PUT("/me/location", h.UpdateLocation)
+		userApi.PUT("/me/password", h.ChangePassword)
+		userApi.GET("/me/photos", h.GetPhotos)
+		userApi.POST("/me/photos", h.UploadPhoto)
+		userApi.PUT("/me/photos/order", h.ReorderPhotos)
+		userApi.DELETE("/me/photos/:id", h.DeletePhoto)
+		userApi.POST("/me/verification", h.RequestVerification)
+		userApi.GET("/me/verification", h.GetVerification)
+		userApi.POST("/:id/block", h.BlockUser)
+		userApi.POST("/:id/report", h.ReportUser)
 	}
 	useractivityApi := api.Group("/user-activity").Use(h.middleware.AuthMiddleware)
 	{
 		useractivityApi.GET("/", h.GetUserActivity)
+		useractivityApi.GET("/quota", h.GetQuota)
+		useractivityApi.POST("/rewind", h.RewindUserActivity)
 		useractivityApi.POST("/:activity", h.CreateUserActivity)
 		useractivityApi.PUT("/:id", h.UpdateUserActivity)
 		useractivityApi.DELETE("/:id", h.DeleteUserActivity)
@@ -80,9 +116,32 @@ func (h *handler) register() *gin.Engine {
 	premiumfeatureApi := api.Group("/premium-feature").Use(h.middleware.AuthMiddleware)
 	{
 		premiumfeatureApi.GET("/", h.GetPremiumFeature)
-		premiumfeatureApi.POST("/", h.CreatePremiumFeature)
-		premiumfeatureApi.PUT("/:id", h.UpdatePremiumFeature)
-		premiumfeatureApi.DELETE("/:id", h.DeletePremiumFeature)
+		premiumfeatureApi.POST("/", h.middleware.RequireRole(models.RoleAdmin), h.CreatePremiumFeature)
+		premiumfeatureApi.PUT("/:id", h.middleware.RequireRole(models.RoleAdmin), h.UpdatePremiumFeature)
+		premiumfeatureApi.DELETE("/:id", h.middleware.RequireRole(models.RoleAdmin), h.DeletePremiumFeature)
+	}
+	matchApi := api.Group("/match").Use(h.middleware.AuthMiddleware)
+	{
+		matchApi.GET("/", h.GetMatch)
+		matchApi.DELETE("/:id", h.DeleteMatch)
+	}
+	paymentApi := api.Group("/payment").Use(h.middleware.AuthMiddleware)
+	{
+		paymentApi.POST("/checkout", h.Checkout)
+	}
+	conversationApi := api.Group("/conversation").Use(h.middleware.AuthMiddleware)
+	{
+		conversationApi.GET("/", h.GetConversation)
+		conversationApi.GET("/:id/message", h.GetMessage)
+		conversationApi.POST("/:id/message", h.SendMessage)
+		conversationApi.PUT("/:id/read", h.ReadMessage)
+	}
+	adminApi := api.Group("/admin").Use(h.middleware.AuthMiddleware, h.middleware.RequireRole(models.RoleAdmin))
+	{
+		adminApi.GET("/reports", h.GetReports)
+		adminApi.PUT("/reports/:id", h.ResolveReport)
+		adminApi.GET("/verifications", h.GetVerifications)
+		adminApi.PUT("/verifications/:id", h.ReviewVerification)
 	}
 
 	return router
@@ -101,3 +160,35 @@ func (h *handler) BindParams(ctx *gin.Context, obj interface{}) error {
 
 	return nil
 }
+
+// errorStatus maps service errors to a status code, anything unknown is a 500.
+func errorStatus(err error) int {
+	if errors.Is(err, models.ErrForbidden) || errors.Is(err, models.ErrBlocked) || errors.Is(err, models.ErrPremiumRequired) ||
+		errors.Is(err, models.ErrWrongPassword) {
+		return http.StatusForbidden
+	}
+	if errors.Is(err, models.ErrInvalidSignature) {
+		return http.StatusUnauthorized
+	}
+	if errors.Is(err, models.ErrQuotaExceeded) {
+		return http.StatusTooManyRequests
+	}
+	if errors.Is(err, models.ErrBoostActive) || errors.Is(err, models.ErrVerificationPending) || errors.Is(err, models.ErrAlreadyVerified) ||
+		errors.Is(err, models.ErrReportClosed) {
+		return http.StatusConflict
+	}
+	if errors.Is(err, models.ErrPhotoTooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	if errors.Is(err, fs.ErrNotExist) || errors.Is(err, models.ErrVerificationNotFound) ||
+		errors.Is(err, models.ErrBoostNotFound) || errors.Is(err, models.ErrUserNotFound) {
+		return http.StatusNotFound
+	}
+	if errors.Is(err, models.ErrUnderage) || errors.Is(err, models.ErrSelfTarget) ||
+		errors.Is(err, models.ErrUnsupportedPhoto) || errors.Is(err, models.ErrTooManyPhotos) || errors.Is(err, models.ErrInvalidPhotoOrder) ||
+		errors.Is(err, models.ErrUnknownPhotoUrl) ||
+		errors.Is(err, models.ErrInvalidResetToken) || errors.Is(err, models.ErrNotPurchasable) {
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
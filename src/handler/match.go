@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/formatter"
+	"DatingApp/src/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Match
+//	@Security	ApiKeyAuth
+//	@Param		paging	query	filter.Paging[filter.MatchFilter]	false	"paging"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/match/ [GET]
+func (h *handler) GetMatch(ctx *gin.Context) {
+	var filter filter.Paging[filter.MatchFilter]
+	filter.SetDefault()
+
+	if err := h.BindParams(ctx, &filter); err != nil {
+		response := models.APIResponse("Get Match Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	matches, count, err := h.service.Match.Get(ctx, filter)
+	if err != nil {
+		response := models.APIResponse("Get Match Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusInternalServerError, response)
+		return
+	}
+	paginatedItems := formatter.PaginatedItems{}
+	paginatedItems.Format(filter.Page, float64(len(matches)), float64(count), float64(filter.Take), matches)
+
+	response := models.APIResponse("Get Match Success", http.StatusOK, "Success", paginatedItems, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Match
+//	@Security	ApiKeyAuth
+//	@Param		id	path	integer	true	"id"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/match/{id} [DELETE]
+func (h *handler) DeleteMatch(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response := models.APIResponse("Unmatch Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	if err := h.service.Match.Delete(ctx, id); err != nil {
+		response := models.APIResponse("Unmatch Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := models.APIResponse("Unmatch Success", http.StatusOK, "Success", nil, nil)
+	ctx.JSON(http.StatusOK, response)
+}
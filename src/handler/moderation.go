@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/formatter"
+	"DatingApp/src/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Moderation
+//	@Security	ApiKeyAuth
+//	@Param		id	path	integer	true	"id"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/user/{id}/block [POST]
+func (h *handler) BlockUser(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response := models.APIResponse("Block User Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	if err := h.service.Moderation.Block(ctx, id); err != nil {
+		response := models.APIResponse("Block User Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
+		return
+	}
+
+	response := models.APIResponse("Block User Success", http.StatusOK, "Success", nil, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Moderation
+//	@Security	ApiKeyAuth
+//	@Param		id		path	integer					true	"id"
+//	@Param		models	body	models.ReportRequest	true	"models"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/user/{id}/report [POST]
+func (h *handler) ReportUser(ctx *gin.Context) {
+	var input models.ReportRequest
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response := models.APIResponse("Report User Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		response := models.APIResponse("Report User Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	if err := h.service.Moderation.Report(ctx, id, input); err != nil {
+		response := models.APIResponse("Report User Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
+		return
+	}
+
+	response := models.APIResponse("Report User Success", http.StatusOK, "Success", nil, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Moderation
+//	@Security	ApiKeyAuth
+//	@Param		paging	query	filter.Paging[filter.ReportFilter]	false	"paging"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/admin/reports [GET]
+func (h *handler) GetReports(ctx *gin.Context) {
+	var filter filter.Paging[filter.ReportFilter]
+	filter.SetDefault()
+
+	if err := h.BindParams(ctx, &filter); err != nil {
+		response := models.APIResponse("Get Reports Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	reports, count, err := h.service.Moderation.GetReports(ctx, filter)
+	if err != nil {
+		response := models.APIResponse("Get Reports Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusInternalServerError, response)
+		return
+	}
+	paginatedItems := formatter.PaginatedItems{}
+	paginatedItems.Format(filter.Page, float64(len(reports)), float64(count), float64(filter.Take), reports)
+
+	response := models.APIResponse("Get Reports Success", http.StatusOK, "Success", paginatedItems, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Moderation
+//	@Security	ApiKeyAuth
+//	@Param		id		path	integer					true	"id"
+//	@Param		models	body	models.ReportResolution	true	"models"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/admin/reports/{id} [PUT]
+func (h *handler) ResolveReport(ctx *gin.Context) {
+	var input models.ReportResolution
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response := models.APIResponse("Resolve Report Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		response := models.APIResponse("Resolve Report Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	if err := h.service.Moderation.ResolveReport(ctx, id, input); err != nil {
+		response := models.APIResponse("Resolve Report Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
+		return
+	}
+
+	response := models.APIResponse("Resolve Report Success", http.StatusOK, "Success", nil, nil)
+	ctx.JSON(http.StatusOK, response)
+}
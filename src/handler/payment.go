@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"DatingApp/src/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const signatureHeader = "X-Signature"
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Payment
+//	@Security	ApiKeyAuth
+//	@Param		models	body	models.Checkout	true	"models"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/payment/checkout [POST]
+func (h *handler) Checkout(ctx *gin.Context) {
+	var input models.Checkout
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		response := models.APIResponse("Checkout Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	checkout, err := h.service.Payment.Checkout(ctx, input)
+	if err != nil {
+		response := models.APIResponse("Checkout Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
+		return
+	}
+
+	response := models.APIResponse("Checkout Success", http.StatusOK, "Success", checkout, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Payment
+//	@Param		X-Signature	header	string				true	"hex HMAC-SHA256 of the body"
+//	@Param		models		body	models.PaymentEvent	true	"models"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/payment/webhook [POST]
+func (h *handler) PaymentWebhook(ctx *gin.Context) {
+	// the signature is computed over the raw body, so it must not go through a binder first
+	payload, err := ctx.GetRawData()
+	if err != nil {
+		response := models.APIResponse("Payment Webhook Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	if err := h.service.Payment.HandleWebhook(ctx, payload, ctx.GetHeader(signatureHeader)); err != nil {
+		status := errorStatus(err)
+		response := models.APIResponse("Payment Webhook Failed", status, "Failed", nil, err.Error())
+		ctx.JSON(status, response)
+		return
+	}
+
+	response := models.APIResponse("Payment Webhook Success", http.StatusOK, "Success", nil, nil)
+	ctx.JSON(http.StatusOK, response)
+}
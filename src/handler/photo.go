@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"DatingApp/src/models"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is what the rest of a photo upload request may take besides the photo itself.
+const multipartOverhead = 1 << 20
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Photo
+//	@Security	ApiKeyAuth
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/user/me/photos [GET]
+func (h *handler) GetPhotos(ctx *gin.Context) {
+	photos, err := h.service.Photo.Get(ctx)
+	if err != nil {
+		response := models.APIResponse("Get Photos Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := models.APIResponse("Get Photos Success", http.StatusOK, "Success", photos, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Photo
+//	@Security	ApiKeyAuth
+//	@Param		photo	formData	file	true	"jpeg, png or gif image"
+//	@Accept		multipart/form-data
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/user/me/photos [POST]
+func (h *handler) UploadPhoto(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, models.MaxPhotoBytes+multipartOverhead)
+
+	data, err := readImage(ctx, "photo")
+	if err != nil {
+		status := http.StatusUnprocessableEntity
+		if errors.Is(err, models.ErrPhotoTooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		response := models.APIResponse("Upload Photo Failed", status, "Failed", nil, err.Error())
+		ctx.JSON(status, response)
+		return
+	}
+
+	photo, err := h.service.Photo.Upload(ctx, data)
+	if err != nil {
+		response := models.APIResponse("Upload Photo Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
+		return
+	}
+
+	response := models.APIResponse("Upload Photo Success", http.StatusOK, "Success", photo, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Photo
+//	@Security	ApiKeyAuth
+//	@Param		models	body	models.PhotoOrderRequest	true	"models"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/user/me/photos/order [PUT]
+func (h *handler) ReorderPhotos(ctx *gin.Context) {
+	var input models.PhotoOrderRequest
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		response := models.APIResponse("Reorder Photos Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	photos, err := h.service.Photo.Reorder(ctx, input)
+	if err != nil {
+		response := models.APIResponse("Reorder Photos Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
+		return
+	}
+
+	response := models.APIResponse("Reorder Photos Success", http.StatusOK, "Success", photos, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Photo
+//	@Security	ApiKeyAuth
+//	@Param		id	path	integer	true	"id"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/user/me/photos/{id} [DELETE]
+func (h *handler) DeletePhoto(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response := models.APIResponse("Delete Photo Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	if err := h.service.Photo.Delete(ctx, id); err != nil {
+		response := models.APIResponse("Delete Photo Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := models.APIResponse("Delete Photo Success", http.StatusOK, "Success", nil, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Photo
+//	@Param		key			path	string	true	"key"
+//	@Param		expires		query	integer	true	"expires"
+//	@Param		signature	query	string	true	"signature"
+//	@Produce	jpeg
+//	@Success	200
+//	@Router		/photos/{key} [GET]
+func (h *handler) GetPhoto(ctx *gin.Context) {
+	var query models.PhotoQuery
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		response := models.APIResponse("Get Photo Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	data, err := h.service.Photo.Open(ctx, strings.TrimPrefix(ctx.Param("key"), "/"), query)
+	if err != nil {
+		response := models.APIResponse("Get Photo Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
+		return
+	}
+
+	// the url of a photo doesnt change until it expires
+	ctx.Header("Cache-Control", "private, max-age="+strconv.Itoa(int(models.PhotoUrlTTL.Seconds())))
+	ctx.Data(http.StatusOK, "image/jpeg", data)
+}
+
+// readImage reads the file of a multipart upload in field, it is never read past models.MaxPhotoBytes.
+func readImage(ctx *gin.Context, field string) ([]byte, error) {
+	header, err := ctx.FormFile(field)
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			return nil, models.ErrPhotoTooLarge
+		}
+		return nil, err
+	}
+	if header.Size > models.MaxPhotoBytes {
+		return nil, models.ErrPhotoTooLarge
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, models.MaxPhotoBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > models.MaxPhotoBytes {
+		return nil, models.ErrPhotoTooLarge
+	}
+	return data, nil
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"DatingApp/src/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Preference
+//	@Security	ApiKeyAuth
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/user/me/preferences [GET]
+func (h *handler) GetPreference(ctx *gin.Context) {
+	preference, err := h.service.Preference.Get(ctx)
+	if err != nil {
+		response := models.APIResponse("Get Preference Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := models.APIResponse("Get Preference Success", http.StatusOK, "Success", preference, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Preference
+//	@Security	ApiKeyAuth
+//	@Param		models	body	models.PreferenceRequest	true	"models"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/user/me/preferences [PUT]
+func (h *handler) UpdatePreference(ctx *gin.Context) {
+	var input models.PreferenceRequest
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		response := models.APIResponse("Update Preference Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	if err := h.service.Preference.Update(ctx, input); err != nil {
+		response := models.APIResponse("Update Preference Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
+		return
+	}
+
+	response := models.APIResponse("Update Preference Success", http.StatusOK, "Success", nil, nil)
+	ctx.JSON(http.StatusOK, response)
+}
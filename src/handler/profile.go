@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"DatingApp/src/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Profile
+//	@Security	ApiKeyAuth
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/user/me/profile [GET]
+func (h *handler) GetProfile(ctx *gin.Context) {
+	profile, err := h.service.Profile.Get(ctx)
+	if err != nil {
+		response := models.APIResponse("Get Profile Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := models.APIResponse("Get Profile Success", http.StatusOK, "Success", profile, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Profile
+//	@Security	ApiKeyAuth
+//	@Param		models	body	models.ProfileRequest	true	"models"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/user/me/profile [PUT]
+func (h *handler) UpdateProfile(ctx *gin.Context) {
+	var input models.ProfileRequest
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		response := models.APIResponse("Update Profile Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	if err := h.service.Profile.Update(ctx, input); err != nil {
+		response := models.APIResponse("Update Profile Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
+		return
+	}
+
+	response := models.APIResponse("Update Profile Success", http.StatusOK, "Success", nil, nil)
+	ctx.JSON(http.StatusOK, response)
+}
@@ -35,7 +35,7 @@ func (h *handler) GetUser(ctx *gin.Context) {
 		return
 	}
 
-	users, count, err := h.service.User.Get(ctx, filter)
+	users, count, err := h.service.User.GetWithFeatures(ctx, filter)
 	if err != nil {
 		response := models.APIResponse("Get User Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
 		ctx.JSON(http.StatusInternalServerError, response)
@@ -71,8 +71,8 @@ func (h *handler) DeleteUser(ctx *gin.Context) {
 	}
 
 	if err := h.service.User.Delete(ctx, id); err != nil {
-		response := models.APIResponse("Delete User Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
-		ctx.JSON(http.StatusInternalServerError, response)
+		response := models.APIResponse("Delete User Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
 		return
 	}
 
@@ -103,9 +103,9 @@ func (h *handler) Subscribe(ctx *gin.Context) {
 		return
 	}
 
-	if err := h.service.User.UpdatePremiumFeatureId(ctx, input); err != nil {
-		response := models.APIResponse("Subscribe Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
-		ctx.JSON(http.StatusInternalServerError, response)
+	if err := h.service.Subscription.Subscribe(ctx, input); err != nil {
+		response := models.APIResponse("Subscribe Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
 		return
 	}
 
@@ -122,19 +122,85 @@ func (h *handler) Subscribe(ctx *gin.Context) {
 //	@Description
 //	@Tags		User
 //	@Security	ApiKeyAuth
+//	@Param		query	query	models.RecomendationQuery	false	"query"
 //	@Accept		json
 //	@Produce	json
 //	@Success	200	{object}	models.Response
 //	@Router		/user/recomendation/ [GET]
 func (h *handler) UserRecomendation(ctx *gin.Context) {
-	user, err := h.service.User.GetRecomendedUser(ctx)
+	var query models.RecomendationQuery
 
+	if err := h.BindParams(ctx, &query); err != nil {
+		response := models.APIResponse("Get User Recomendation Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	deck, err := h.service.Recomendation.GetDeck(ctx, query.Size)
 	if err != nil {
 		response := models.APIResponse("Get User Recomendation Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
 		ctx.JSON(http.StatusInternalServerError, response)
 		return
 	}
 
-	response := models.APIResponse("Get User Recomendation Success", http.StatusOK, "Success", user, nil)
+	response := models.APIResponse("Get User Recomendation Success", http.StatusOK, "Success", deck, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		User
+//	@Security	ApiKeyAuth
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/user/subscription/ [GET]
+func (h *handler) GetSubscription(ctx *gin.Context) {
+	subscription, err := h.service.Subscription.Get(ctx)
+	if err != nil {
+		response := models.APIResponse("Get Subscription Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := models.APIResponse("Get Subscription Success", http.StatusOK, "Success", subscription, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		User
+//	@Security	ApiKeyAuth
+//	@Param		models	body	models.LocationRequest	true	"models"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/user/me/location [PUT]
+func (h *handler) UpdateLocation(ctx *gin.Context) {
+	var input models.LocationRequest
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		response := models.APIResponse("Update Location Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	if err := h.service.User.UpdateLocation(ctx, input); err != nil {
+		response := models.APIResponse("Update Location Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := models.APIResponse("Update Location Success", http.StatusOK, "Success", nil, nil)
 	ctx.JSON(http.StatusOK, response)
 }
@@ -57,34 +57,126 @@ func (h *handler) GetUserActivity(ctx *gin.Context) {
 //	@Description
 //	@Tags		UserActivity
 //	@Security	ApiKeyAuth
-//	@Param		activity		path	string							true	"activity"
+//	@Param		paging	query	filter.Paging[filter.UserActivityFilter]	false	"paging"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/user/likes-received [GET]
+func (h *handler) GetLikesReceived(ctx *gin.Context) {
+	var filter filter.Paging[filter.UserActivityFilter]
+	filter.SetDefault()
+
+	if err := h.BindParams(ctx, &filter); err != nil {
+		response := models.APIResponse("Get Likes Received Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	likes, count, err := h.service.UserActivity.GetLikesReceived(ctx, filter)
+	if err != nil {
+		response := models.APIResponse("Get Likes Received Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusInternalServerError, response)
+		return
+	}
+	paginatedItems := formatter.PaginatedItems{}
+	paginatedItems.Format(filter.Page, float64(len(likes)), float64(count), float64(filter.Take), likes)
+
+	response := models.APIResponse("Get Likes Received Success", http.StatusOK, "Success", paginatedItems, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		UserActivity
+//	@Security	ApiKeyAuth
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/user-activity/quota [GET]
+func (h *handler) GetQuota(ctx *gin.Context) {
+	quota, err := h.service.Quota.Get(ctx)
+	if err != nil {
+		response := models.APIResponse("Get Quota Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := models.APIResponse("Get Quota Success", http.StatusOK, "Success", quota, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		UserActivity
+//	@Security	ApiKeyAuth
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/user-activity/rewind [POST]
+func (h *handler) RewindUserActivity(ctx *gin.Context) {
+	activity, err := h.service.UserActivity.Rewind(ctx)
+	if err != nil {
+		response := models.APIResponse("Rewind UserActivity Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
+		return
+	}
+
+	response := models.APIResponse("Rewind UserActivity Success", http.StatusOK, "Success", activity, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		UserActivity
+//	@Security	ApiKeyAuth
+//	@Param		activity		path	string							true	"like, pass or superlike"
 //	@Param		targetUserId	body	models.UserActivityInputJson	true	"passed or liked userId"
 //	@Accept		json
 //	@Produce	json
 //	@Success	200	{object}	models.Response
 //	@Router		/user-activity/{activity} [POST]
 func (h *handler) CreateUserActivity(ctx *gin.Context) {
-	activity := ctx.Param("activity")
+	var path models.UserActivityPath
 	var targetUserId models.UserActivityInputJson
 	var input models.Query[models.UserActivityInput]
 
+	if err := ctx.ShouldBindUri(&path); err != nil {
+		response := models.APIResponse("Create UserActivity Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
 	if err := ctx.ShouldBindJSON(&targetUserId); err != nil {
 		response := models.APIResponse("Create UserActivity Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
 		ctx.JSON(http.StatusUnprocessableEntity, response)
 		return
 	}
 
-	if activity == "pass" {
+	input.Model.UserId = int(ctx.Value(models.UserKey).(models.User).Id)
+	input.Model.Activity = path.Activity
+	if path.Activity == models.ActivityPass {
 		input.Model.PassedUserId = targetUserId.TargetUserId
-		input.Model.UserId = int(ctx.Value(models.UserKey).(models.User).Id)
-	} else if activity == "like" {
+	} else {
 		input.Model.LikedUserId = targetUserId.TargetUserId
-		input.Model.UserId = int(ctx.Value(models.UserKey).(models.User).Id)
 	}
 
 	if err := h.service.UserActivity.Create(ctx, input); err != nil {
-		response := models.APIResponse("Create UserActivity Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
-		ctx.JSON(http.StatusInternalServerError, response)
+		response := models.APIResponse("Create UserActivity Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
 		return
 	}
 
@@ -124,8 +216,8 @@ func (h *handler) UpdateUserActivity(ctx *gin.Context) {
 	}
 
 	if err := h.service.UserActivity.Update(ctx, input, id); err != nil {
-		response := models.APIResponse("Update UserActivity Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
-		ctx.JSON(http.StatusInternalServerError, response)
+		response := models.APIResponse("Update UserActivity Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
 		return
 	}
 
@@ -156,8 +248,8 @@ func (h *handler) DeleteUserActivity(ctx *gin.Context) {
 	}
 
 	if err := h.service.UserActivity.Delete(ctx, id); err != nil {
-		response := models.APIResponse("Delete UserActivity Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
-		ctx.JSON(http.StatusInternalServerError, response)
+		response := models.APIResponse("Delete UserActivity Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
 		return
 	}
 
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/formatter"
+	"DatingApp/src/models"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Verification
+//	@Security	ApiKeyAuth
+//	@Param		selfie	formData	file	true	"jpeg, png or gif image"
+//	@Accept		multipart/form-data
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/user/me/verification [POST]
+func (h *handler) RequestVerification(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, models.MaxPhotoBytes+multipartOverhead)
+
+	data, err := readImage(ctx, "selfie")
+	if err != nil {
+		status := http.StatusUnprocessableEntity
+		if errors.Is(err, models.ErrPhotoTooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		response := models.APIResponse("Request Verification Failed", status, "Failed", nil, err.Error())
+		ctx.JSON(status, response)
+		return
+	}
+
+	if err := h.service.Verification.Request(ctx, data); err != nil {
+		response := models.APIResponse("Request Verification Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
+		return
+	}
+
+	response := models.APIResponse("Request Verification Success", http.StatusOK, "Success", nil, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Verification
+//	@Security	ApiKeyAuth
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/user/me/verification [GET]
+func (h *handler) GetVerification(ctx *gin.Context) {
+	verification, err := h.service.Verification.Get(ctx)
+	if err != nil {
+		response := models.APIResponse("Get Verification Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
+		return
+	}
+
+	response := models.APIResponse("Get Verification Success", http.StatusOK, "Success", verification, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Verification
+//	@Security	ApiKeyAuth
+//	@Param		paging	query	filter.Paging[filter.VerificationFilter]	false	"paging"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/admin/verifications [GET]
+func (h *handler) GetVerifications(ctx *gin.Context) {
+	var filter filter.Paging[filter.VerificationFilter]
+	filter.SetDefault()
+
+	if err := h.BindParams(ctx, &filter); err != nil {
+		response := models.APIResponse("Get Verifications Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	verifications, count, err := h.service.Verification.GetVerifications(ctx, filter)
+	if err != nil {
+		response := models.APIResponse("Get Verifications Failed", http.StatusInternalServerError, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusInternalServerError, response)
+		return
+	}
+	paginatedItems := formatter.PaginatedItems{}
+	paginatedItems.Format(filter.Page, float64(len(verifications)), float64(count), float64(filter.Take), verifications)
+
+	response := models.APIResponse("Get Verifications Success", http.StatusOK, "Success", paginatedItems, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description
+//	@Tags		Verification
+//	@Security	ApiKeyAuth
+//	@Param		id		path	integer						true	"id"
+//	@Param		models	body	models.VerificationReview	true	"models"
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	models.Response
+//	@Router		/admin/verifications/{id} [PUT]
+func (h *handler) ReviewVerification(ctx *gin.Context) {
+	var input models.VerificationReview
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response := models.APIResponse("Review Verification Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		response := models.APIResponse("Review Verification Failed", http.StatusUnprocessableEntity, "Failed", nil, err.Error())
+		ctx.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	if err := h.service.Verification.Review(ctx, id, input); err != nil {
+		response := models.APIResponse("Review Verification Failed", errorStatus(err), "Failed", nil, err.Error())
+		ctx.JSON(errorStatus(err), response)
+		return
+	}
+
+	response := models.APIResponse("Review Verification Success", http.StatusOK, "Success", nil, nil)
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"DatingApp/src/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+//	@BasePath	/api/v1
+//
+// PingExample godoc
+//
+//	@Summary
+//	@Schemes
+//	@Description	Upgrade to websocket and receive new-match, new-message and new-like events
+//	@Tags			WebSocket
+//	@Security		ApiKeyAuth
+//	@Param			Sec-WebSocket-Protocol	header	string	false	"bearer, {token} when Authorization header can't be set"
+//	@Success		101
+//	@Router			/ws [GET]
+func (h *handler) WebSocket(ctx *gin.Context) {
+	events, unsubscribe := h.service.Notification.Subscribe(ctx)
+	defer unsubscribe()
+
+	server := websocket.Server{
+		// origin is already allowed by cors config, the token offered as subprotocol
+		// must not be echoed back so only the protocol itself is accepted
+		Handshake: func(config *websocket.Config, req *http.Request) error {
+			protocols := config.Protocol
+			config.Protocol = nil
+			for _, protocol := range protocols {
+				if protocol == models.WebSocketProtocol {
+					config.Protocol = []string{models.WebSocketProtocol}
+				}
+			}
+			return nil
+		},
+		Handler: func(conn *websocket.Conn) {
+			defer conn.Close()
+
+			closed := make(chan struct{})
+			go func() {
+				defer close(closed)
+				var message string
+				for {
+					if err := websocket.Message.Receive(conn, &message); err != nil {
+						return
+					}
+				}
+			}()
+
+			for {
+				select {
+				case <-closed:
+					return
+				case event, ok := <-events:
+					if !ok {
+						return
+					}
+					if err := websocket.JSON.Send(conn, event); err != nil {
+						return
+					}
+				}
+			}
+		},
+	}
+	server.ServeHTTP(ctx.Writer, ctx.Request)
+}
@@ -15,6 +15,8 @@ import (
 
 type Interface interface {
 	AuthMiddleware(c *gin.Context)
+	WebSocketAuthMiddleware(c *gin.Context)
+	RequireRole(roles ...string) gin.HandlerFunc
 }
 
 type authMiddleware struct {
@@ -62,6 +64,21 @@ func (a *authMiddleware) AuthMiddleware(ctx *gin.Context) {
 
 	userId := int(claim["user_id"].(float64))
 
+	sessionClaim, ok := claim["session_id"].(float64)
+	if !ok {
+		response := models.APIResponse("Unauthorized", http.StatusUnauthorized, "error", nil, errors.New("invalid token").Error())
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, response)
+		return
+	}
+	sessionId := int(sessionClaim)
+
+	role, ok := claim["role"].(string)
+	if !ok {
+		response := models.APIResponse("Unauthorized", http.StatusUnauthorized, "error", nil, errors.New("invalid token").Error())
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, response)
+		return
+	}
+
 	dateTime, err := time.Parse(time.RFC3339Nano, claim["time"].(string))
 
 	if err != nil {
@@ -76,6 +93,13 @@ func (a *authMiddleware) AuthMiddleware(ctx *gin.Context) {
 		return
 	}
 
+	err = a.service.Auth.ValidateSession(ctx, userId, sessionId)
+	if err != nil {
+		response := models.APIResponse("Unauthorized", http.StatusUnauthorized, "error", nil, err.Error())
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, response)
+		return
+	}
+
 	paging := filter.Paging[filter.UserFilter]{}
 	paging.SetDefault()
 	paging.Filter.Id = userId
@@ -94,6 +118,35 @@ func (a *authMiddleware) AuthMiddleware(ctx *gin.Context) {
 	user := users[0]
 
 	ctx.Set(models.UserKey, user)
+	ctx.Set(models.SessionKey, sessionId)
+	ctx.Set(models.RoleKey, role)
+}
+
+// RequireRole must run after AuthMiddleware, it only lets through tokens carrying one of the given roles.
+func (a *authMiddleware) RequireRole(roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		role := ctx.GetString(models.RoleKey)
+		for _, r := range roles {
+			if r == role {
+				return
+			}
+		}
+		response := models.APIResponse("Forbidden", http.StatusForbidden, "error", nil, models.ErrForbidden.Error())
+		ctx.AbortWithStatusJSON(http.StatusForbidden, response)
+	}
+}
+
+// WebSocketAuthMiddleware also accepts the token offered as the subprotocol after models.WebSocketProtocol,
+// since browsers can't set the Authorization header on a websocket handshake. The token stays out of the url
+// so it doesn't end up in access logs.
+func (a *authMiddleware) WebSocketAuthMiddleware(ctx *gin.Context) {
+	if ctx.GetHeader("Authorization") == "" {
+		protocols := strings.Split(ctx.GetHeader("Sec-WebSocket-Protocol"), ",")
+		if len(protocols) == 2 && strings.TrimSpace(protocols[0]) == models.WebSocketProtocol {
+			ctx.Request.Header.Set("Authorization", "Bearer "+strings.TrimSpace(protocols[1]))
+		}
+	}
+	a.AuthMiddleware(ctx)
 }
 
 func (s *authMiddleware) validateToken(token string) (*jwt.Token, error) {
@@ -1,9 +1,24 @@
 package models
 
+import "errors"
+
+const (
+	UserKey    = "currentUser"
+	SessionKey = "currentSession"
+	RoleKey    = "currentRole"
+)
+
 const (
-	UserKey = "currentUser"
+	RoleUser  = "user"
+	RoleAdmin = "admin"
 )
 
+// WebSocketProtocol is the subprotocol a browser offers together with its token,
+// new WebSocket(url, ["bearer", token]), as it can't set the Authorization header on a handshake.
+const WebSocketProtocol = "bearer"
+
+var ErrForbidden = errors.New("forbidden")
+
 type Login struct {
 	UserName string `json:"userName"`
 	Password string `json:"password"`
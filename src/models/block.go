@@ -0,0 +1,37 @@
+package models
+
+import (
+	"DatingApp/src/formatter"
+	"errors"
+	"time"
+)
+
+// ErrBlocked is returned when either user blocked the other one.
+var ErrBlocked = errors.New("user is blocked")
+
+var ErrSelfTarget = errors.New("cannot block or report yourself")
+
+type Block struct {
+	Id            int64                                 `db:"id" json:"id"`
+	UserId        int                                   `db:"user_id" json:"userId"`
+	BlockedUserId int                                   `db:"blocked_user_id" json:"blockedUserId"`
+	Status        int64                                 `db:"status" json:"status"`
+	CreatedAt     formatter.NullableDataType[time.Time] `db:"created_at" json:"createdAt"`
+	CreatedBy     formatter.NullableDataType[int64]     `db:"created_by" json:"createdBy"`
+	UpdatedAt     formatter.NullableDataType[time.Time] `db:"updated_at" json:"updatedAt"`
+	UpdatedBy     formatter.NullableDataType[int64]     `db:"updated_by" json:"updatedBy"`
+	DeletedAt     formatter.NullableDataType[time.Time] `db:"deleted_at" json:"deletedAt"`
+	DeletedBy     formatter.NullableDataType[int64]     `db:"deleted_by" json:"deletedBy"`
+}
+
+type BlockInput struct {
+	UserId        int       `db:"user_id" json:"-"`
+	BlockedUserId int       `db:"blocked_user_id" json:"-"`
+	Status        int64     `db:"status" json:"-"`
+	CreatedAt     time.Time `db:"created_at" json:"-"`
+	CreatedBy     int64     `db:"created_by" json:"-"`
+	UpdatedAt     time.Time `db:"updated_at" json:"-"`
+	UpdatedBy     int64     `db:"updated_by" json:"-"`
+	DeletedAt     time.Time `db:"deleted_at" json:"-"`
+	DeletedBy     int64     `db:"deleted_by" json:"-"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"DatingApp/src/formatter"
+	"errors"
+	"time"
+)
+
+// BoostDuration is how long a boost keeps the user ahead in the decks of others.
+const BoostDuration = 30 * time.Minute
+
+// MaxBoostsPerDay is how many boosts a user can start in a day of their timezone.
+const MaxBoostsPerDay = 1
+
+var (
+	ErrBoostActive   = errors.New("a boost is already active")
+	ErrBoostNotFound = errors.New("boost doesnt exists")
+)
+
+type Boost struct {
+	Id          int64                                 `db:"id" json:"id"`
+	UserId      int                                   `db:"user_id" json:"userId"`
+	StartedAt   time.Time                             `db:"started_at" json:"startedAt"`
+	EndsAt      time.Time                             `db:"ends_at" json:"endsAt"`
+	Impressions int                                   `db:"impressions" json:"impressions"`
+	Status      int64                                 `db:"status" json:"status"`
+	CreatedAt   formatter.NullableDataType[time.Time] `db:"created_at" json:"createdAt"`
+	CreatedBy   formatter.NullableDataType[int64]     `db:"created_by" json:"createdBy"`
+	UpdatedAt   formatter.NullableDataType[time.Time] `db:"updated_at" json:"updatedAt"`
+	UpdatedBy   formatter.NullableDataType[int64]     `db:"updated_by" json:"updatedBy"`
+	DeletedAt   formatter.NullableDataType[time.Time] `db:"deleted_at" json:"deletedAt"`
+	DeletedBy   formatter.NullableDataType[int64]     `db:"deleted_by" json:"deletedBy"`
+}
+
+type BoostInput struct {
+	UserId    int       `db:"user_id" json:"-"`
+	StartedAt time.Time `db:"started_at" json:"-"`
+	EndsAt    time.Time `db:"ends_at" json:"-"`
+	Status    int64     `db:"status" json:"-"`
+	CreatedAt time.Time `db:"created_at" json:"-"`
+	CreatedBy int64     `db:"created_by" json:"-"`
+	UpdatedAt time.Time `db:"updated_at" json:"-"`
+	UpdatedBy int64     `db:"updated_by" json:"-"`
+	DeletedAt time.Time `db:"deleted_at" json:"-"`
+	DeletedBy int64     `db:"deleted_by" json:"-"`
+}
+
+// LikesGained counts the likes a user got in a time window.
+type LikesGained struct {
+	Likes      int `db:"likes"`
+	SuperLikes int `db:"super_likes"`
+}
+
+// BoostStats is how a boost did, impressions are the decks the user was handed out in while boosted.
+type BoostStats struct {
+	BoostId     int64     `json:"boostId"`
+	StartedAt   time.Time `json:"startedAt"`
+	EndsAt      time.Time `json:"endsAt"`
+	Active      bool      `json:"active"`
+	Impressions int       `json:"impressions"`
+	Likes       int       `json:"likes"`
+	SuperLikes  int       `json:"superLikes"`
+}
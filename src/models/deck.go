@@ -0,0 +1,34 @@
+package models
+
+import (
+	"DatingApp/src/formatter"
+	"time"
+)
+
+const (
+	// DeckTTL is how long a card handed out in a deck is kept out of the next decks when it isn't swiped.
+	DeckTTL = 30 * time.Minute
+	// DefaultPassCooldown is how long a passed user is kept out of the decks.
+	DefaultPassCooldown = 30 * 24 * time.Hour
+)
+
+type DeckCard struct {
+	Id          int64                                 `db:"id" json:"id"`
+	UserId      int                                   `db:"user_id" json:"userId"`
+	CandidateId int                                   `db:"candidate_id" json:"candidateId"`
+	ExpiresAt   time.Time                             `db:"expires_at" json:"expiresAt"`
+	CreatedAt   formatter.NullableDataType[time.Time] `db:"created_at" json:"createdAt"`
+}
+
+type DeckCardInput struct {
+	UserId      int       `db:"user_id" json:"-"`
+	CandidateId int       `db:"candidate_id" json:"-"`
+	ExpiresAt   time.Time `db:"expires_at" json:"-"`
+	CreatedAt   time.Time `db:"created_at" json:"-"`
+}
+
+// RecomendationDeck is a batch of cards, none of them comes back in another deck before ExpiresAt unless swiped.
+type RecomendationDeck struct {
+	Cards     []RecomendationUser `json:"cards"`
+	ExpiresAt time.Time           `json:"expiresAt"`
+}
@@ -1,26 +1,48 @@
 package models
 
-import "os"
+import (
+	"os"
+	"strconv"
+	"time"
+)
 
 type Env struct {
-	DB_USER          string
-	DB_PASS          string
-	DB_PORT          string
-	DB_HOST          string
-	DB_NAME          string
-	JWT_SECRET_TOKEN string
-	DB_TYPE          string
+	DB_USER                string
+	DB_PASS                string
+	DB_PORT                string
+	DB_HOST                string
+	DB_NAME                string
+	JWT_SECRET_TOKEN       string
+	DB_TYPE                string
+	PAYMENT_PROVIDER       string
+	PAYMENT_WEBHOOK_SECRET string
+	PASS_COOLDOWN_DAYS     string
+	STORAGE_DIR            string
+	STORAGE_URL            string
+	STORAGE_SECRET         string
+	MAILER                 string
+	MAIL_FILE              string
+	PASSWORD_RESET_URL     string
 }
 
 func SetEnv() Env {
 	env := Env{
-		DB_USER:          os.Getenv("DB_USER"),
-		DB_PASS:          os.Getenv("DB_PASS"),
-		DB_PORT:          os.Getenv("DB_PORT"),
-		DB_HOST:          os.Getenv("DB_HOST"),
-		DB_NAME:          os.Getenv("DB_NAME"),
-		JWT_SECRET_TOKEN: os.Getenv("JWT_SECRET_TOKEN"),
-		DB_TYPE:          os.Getenv("DB_TYPE"),
+		DB_USER:                os.Getenv("DB_USER"),
+		DB_PASS:                os.Getenv("DB_PASS"),
+		DB_PORT:                os.Getenv("DB_PORT"),
+		DB_HOST:                os.Getenv("DB_HOST"),
+		DB_NAME:                os.Getenv("DB_NAME"),
+		JWT_SECRET_TOKEN:       os.Getenv("JWT_SECRET_TOKEN"),
+		DB_TYPE:                os.Getenv("DB_TYPE"),
+		PAYMENT_PROVIDER:       os.Getenv("PAYMENT_PROVIDER"),
+		PAYMENT_WEBHOOK_SECRET: os.Getenv("PAYMENT_WEBHOOK_SECRET"),
+		PASS_COOLDOWN_DAYS:     os.Getenv("PASS_COOLDOWN_DAYS"),
+		STORAGE_DIR:            os.Getenv("STORAGE_DIR"),
+		STORAGE_URL:            os.Getenv("STORAGE_URL"),
+		STORAGE_SECRET:         os.Getenv("STORAGE_SECRET"),
+		MAILER:                 os.Getenv("MAILER"),
+		MAIL_FILE:              os.Getenv("MAIL_FILE"),
+		PASSWORD_RESET_URL:     os.Getenv("PASSWORD_RESET_URL"),
 	}
 	return env
 }
@@ -28,3 +50,59 @@ func SetEnv() Env {
 func GetSecret() []byte {
 	return []byte(SetEnv().JWT_SECRET_TOKEN)
 }
+
+// GetPaymentProvider names the payment provider, the server refuses to start when it's unset.
+func GetPaymentProvider() string {
+	return SetEnv().PAYMENT_PROVIDER
+}
+
+func GetPaymentSecret() []byte {
+	return []byte(SetEnv().PAYMENT_WEBHOOK_SECRET)
+}
+
+// GetPassCooldown is how long a passed user stays out of the recomendations, DefaultPassCooldown when unset or invalid.
+func GetPassCooldown() time.Duration {
+	days, err := strconv.Atoi(SetEnv().PASS_COOLDOWN_DAYS)
+	if err != nil || days <= 0 {
+		return DefaultPassCooldown
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// GetStorageDir is where the local storage keeps its files, DefaultStorageDir when unset.
+func GetStorageDir() string {
+	if dir := SetEnv().STORAGE_DIR; dir != "" {
+		return dir
+	}
+	return DefaultStorageDir
+}
+
+// GetStorageUrl is the url the files of the local storage are served from, DefaultStorageUrl when unset.
+func GetStorageUrl() string {
+	if url := SetEnv().STORAGE_URL; url != "" {
+		return url
+	}
+	return DefaultStorageUrl
+}
+
+func GetStorageSecret() []byte {
+	return []byte(SetEnv().STORAGE_SECRET)
+}
+
+// GetMailer is the driver of the mailer, the server refuses to start when it's unset.
+func GetMailer() string {
+	return SetEnv().MAILER
+}
+
+// GetMailFile is the file the file mailer writes the mails to.
+func GetMailFile() string {
+	return SetEnv().MAIL_FILE
+}
+
+// GetPasswordResetUrl is the page a password reset mail links to, DefaultPasswordResetUrl when unset.
+func GetPasswordResetUrl() string {
+	if url := SetEnv().PASSWORD_RESET_URL; url != "" {
+		return url
+	}
+	return DefaultPasswordResetUrl
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+const (
+	EventNewMatch   = "new-match"
+	EventNewMessage = "new-message"
+	EventNewLike    = "new-like"
+	EventSuperLike  = "super-like"
+)
+
+type Event struct {
+	UserId    int         `json:"-"`
+	Type      string      `json:"type"`
+	Data      interface{} `json:"data"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+type EventUser struct {
+	Id       int64   `json:"id"`
+	UserName string  `json:"userName"`
+	Image    *string `json:"image"`
+}
+
+type EventMessage struct {
+	MatchId  int    `json:"matchId"`
+	SenderId int    `json:"senderId"`
+	Content  string `json:"content"`
+}
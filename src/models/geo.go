@@ -0,0 +1,47 @@
+package models
+
+import "math"
+
+const EarthRadiusKm = 6371.0
+
+type Coordinate struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// LocationRequest is the body of PUT /user/me/location, pointers so 0 stays a valid coordinate.
+type LocationRequest struct {
+	Latitude  *float64 `json:"latitude" binding:"required,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"required,min=-180,max=180"`
+}
+
+// BoundingBox returns the latitude and longitude range holding every point within km of c,
+// it is wider than the circle and only meant to let the index prefilter before haversine.
+func (c Coordinate) BoundingBox(km float64) (minLat, maxLat, minLng, maxLng float64) {
+	deltaLat := km / EarthRadiusKm * 180 / math.Pi
+	minLat, maxLat = math.Max(c.Latitude-deltaLat, -90), math.Min(c.Latitude+deltaLat, 90)
+	if minLat == -90 || maxLat == 90 {
+		return minLat, maxLat, -180, 180
+	}
+
+	deltaLng := deltaLat / math.Cos(c.Latitude*math.Pi/180)
+	minLng, maxLng = c.Longitude-deltaLng, c.Longitude+deltaLng
+	if minLng < -180 || maxLng > 180 {
+		// crossing the antimeridian, the longitude can't be narrowed with a single range
+		return minLat, maxLat, -180, 180
+	}
+	return minLat, maxLat, minLng, maxLng
+}
+
+// ApproximateDistance rounds km to a whole kilometre and never goes below 1 so the exact spot of a user can't be guessed.
+func ApproximateDistance(km float64) float64 {
+	return math.Max(1, math.Round(km))
+}
+
+// Coordinate returns where the user is or nil when the user never shared a location.
+func (u User) Coordinate() *Coordinate {
+	if !u.Latitude.Valid || !u.Longitude.Valid {
+		return nil
+	}
+	return &Coordinate{Latitude: u.Latitude.Data, Longitude: u.Longitude.Data}
+}
@@ -3,7 +3,6 @@ package models
 import (
 	"fmt"
 	"reflect"
-	"strings"
 	"time"
 )
 
@@ -28,8 +27,9 @@ func (q *Query[T]) BuildTableMember() string {
 	return member
 }
 
-func (q *Query[T]) BuildCreateQuery() string {
+func (q *Query[T]) BuildCreateQuery() (string, []interface{}) {
 	query := " "
+	args := []interface{}{}
 
 	ref := reflect.ValueOf(q.Model)
 	tpe := ref.Type()
@@ -39,37 +39,28 @@ func (q *Query[T]) BuildCreateQuery() string {
 
 	isQueryNeedComa := false
 	for i := 0; i < tpe.NumField(); i++ {
+		if tpe.Field(i).Tag.Get("db") == "-" {
+			continue
+		}
 		if !isEmpty(fmt.Sprint(ref.Field(i).Interface())) {
-			if tpe.Field(i).Tag.Get("db") == "-" {
-				continue
-			}
 			if isQueryNeedComa {
 				table += ", "
 				values += ", "
 			}
 			table += tpe.Field(i).Tag.Get("db")
-
-			tempValue := fmt.Sprint(ref.Field(i).Interface())
-			if fmt.Sprint(ref.Field(i).Type()) == "time.Time" {
-				val := fmt.Sprint(ref.Field(i).Interface())
-				vals := strings.Split(val, " ")
-				tempValue = vals[0] + " " + vals[1]
-			}
+			values += "?"
+			args = append(args, ref.Field(i).Interface())
 			isQueryNeedComa = true
-			values += "'" + tempValue + "'"
-		}
-		if i == tpe.NumField()-1 {
-			table += ")"
-			values += ")"
 		}
 	}
-	query += table + " VALUES " + values
+	query += table + ")" + " VALUES " + values + ")"
 
-	return query
+	return query, args
 }
 
-func (q *Query[T]) BuildUpdateQuery(id int) string {
+func (q *Query[T]) BuildUpdateQuery(id int) (string, []interface{}) {
 	query := " SET "
+	args := []interface{}{}
 
 	ref := reflect.ValueOf(q.Model)
 	tpe := ref.Type()
@@ -83,20 +74,15 @@ func (q *Query[T]) BuildUpdateQuery(id int) string {
 			if isQueryNeedComa {
 				query += ", "
 			}
-
-			tempValue := fmt.Sprint(ref.Field(i).Interface())
-			if fmt.Sprint(ref.Field(i).Type()) == "time.Time" {
-				val := fmt.Sprint(ref.Field(i).Interface())
-				vals := strings.Split(val, " ")
-				tempValue = vals[0] + " " + vals[1]
-			}
 			isQueryNeedComa = true
 
-			query += tpe.Field(i).Tag.Get("db") + "='" + tempValue + "'"
+			query += tpe.Field(i).Tag.Get("db") + "=?"
+			args = append(args, ref.Field(i).Interface())
 		}
 	}
-	query += " WHERE id=" + fmt.Sprint(id)
-	return query
+	query += " WHERE id=?"
+	args = append(args, id)
+	return query, args
 }
 
 func isEmpty(check string) bool {
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"DatingApp/src/formatter"
+	"time"
+)
+
+type Match struct {
+	Id            int64                                 `db:"id" json:"id"`
+	UserId        int                                   `db:"user_id" json:"userId"`
+	MatchedUserId int                                   `db:"matched_user_id" json:"matchedUserId"`
+	Status        int64                                 `db:"status" json:"status"`
+	CreatedAt     formatter.NullableDataType[time.Time] `db:"created_at" json:"createdAt"`
+	CreatedBy     formatter.NullableDataType[int64]     `db:"created_by" json:"createdBy"`
+	UpdatedAt     formatter.NullableDataType[time.Time] `db:"updated_at" json:"updatedAt"`
+	UpdatedBy     formatter.NullableDataType[int64]     `db:"updated_by" json:"updatedBy"`
+	DeletedAt     formatter.NullableDataType[time.Time] `db:"deleted_at" json:"deletedAt"`
+	DeletedBy     formatter.NullableDataType[int64]     `db:"deleted_by" json:"deletedBy"`
+}
+
+type MatchInput struct {
+	UserId        int       `db:"user_id" json:"userId"`
+	MatchedUserId int       `db:"matched_user_id" json:"matchedUserId"`
+	Status        int64     `db:"status" json:"-"`
+	CreatedAt     time.Time `db:"created_at" json:"-"`
+	CreatedBy     int64     `db:"created_by" json:"-"`
+	UpdatedAt     time.Time `db:"updated_at" json:"-"`
+	UpdatedBy     int64     `db:"updated_by" json:"-"`
+	DeletedAt     time.Time `db:"deleted_at" json:"-"`
+	DeletedBy     int64     `db:"deleted_by" json:"-"`
+}
+
+type MatchedUser struct {
+	MatchId   int64     `db:"match_id" json:"matchId"`
+	Id        int64     `db:"id" json:"id"`
+	UserName  string    `db:"user_name" json:"userName"`
+	Image     *string   `db:"image" json:"image"`
+	MatchedAt time.Time `db:"matched_at" json:"matchedAt"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"DatingApp/src/formatter"
+	"time"
+)
+
+type Message struct {
+	Id         int64                                 `db:"id" json:"id"`
+	MatchId    int                                   `db:"match_id" json:"matchId"`
+	SenderId   int                                   `db:"sender_id" json:"senderId"`
+	ReceiverId int                                   `db:"receiver_id" json:"receiverId"`
+	Content    string                                `db:"content" json:"content"`
+	ReadAt     formatter.NullableDataType[time.Time] `db:"read_at" json:"readAt"`
+	Status     int64                                 `db:"status" json:"status"`
+	CreatedAt  formatter.NullableDataType[time.Time] `db:"created_at" json:"createdAt"`
+	CreatedBy  formatter.NullableDataType[int64]     `db:"created_by" json:"createdBy"`
+	UpdatedAt  formatter.NullableDataType[time.Time] `db:"updated_at" json:"updatedAt"`
+	UpdatedBy  formatter.NullableDataType[int64]     `db:"updated_by" json:"updatedBy"`
+	DeletedAt  formatter.NullableDataType[time.Time] `db:"deleted_at" json:"deletedAt"`
+	DeletedBy  formatter.NullableDataType[int64]     `db:"deleted_by" json:"deletedBy"`
+}
+
+type MessageInput struct {
+	MatchId    int       `db:"match_id" json:"-"`
+	SenderId   int       `db:"sender_id" json:"-"`
+	ReceiverId int       `db:"receiver_id" json:"-"`
+	Content    string    `db:"content" json:"content"`
+	ReadAt     time.Time `db:"read_at" json:"-"`
+	Status     int64     `db:"status" json:"-"`
+	CreatedAt  time.Time `db:"created_at" json:"-"`
+	CreatedBy  int64     `db:"created_by" json:"-"`
+	UpdatedAt  time.Time `db:"updated_at" json:"-"`
+	UpdatedBy  int64     `db:"updated_by" json:"-"`
+	DeletedAt  time.Time `db:"deleted_at" json:"-"`
+	DeletedBy  int64     `db:"deleted_by" json:"-"`
+}
+
+type Conversation struct {
+	MatchedUser
+	UnreadCount int `json:"unreadCount"`
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"DatingApp/src/formatter"
+	"errors"
+	"time"
+)
+
+const (
+	OrderPending = 1
+	OrderPaid    = 2
+
+	SubscriptionSourcePayment = "payment"
+
+	PaymentEventCheckoutCompleted = "checkout.completed"
+)
+
+var ErrInvalidSignature = errors.New("invalid signature")
+
+type Order struct {
+	Id               int64                                 `db:"id" json:"id"`
+	UserId           int                                   `db:"user_id" json:"userId"`
+	PremiumFeatureId int                                   `db:"premium_feature_id" json:"premiumFeatureId"`
+	Amount           int                                   `db:"amount" json:"amount"`
+	Provider         string                                `db:"provider" json:"provider"`
+	Reference        string                                `db:"reference" json:"reference"`
+	PaidAt           formatter.NullableDataType[time.Time] `db:"paid_at" json:"paidAt"`
+	Status           int64                                 `db:"status" json:"status"`
+	CreatedAt        formatter.NullableDataType[time.Time] `db:"created_at" json:"createdAt"`
+	CreatedBy        formatter.NullableDataType[int64]     `db:"created_by" json:"createdBy"`
+	UpdatedAt        formatter.NullableDataType[time.Time] `db:"updated_at" json:"updatedAt"`
+	UpdatedBy        formatter.NullableDataType[int64]     `db:"updated_by" json:"updatedBy"`
+	DeletedAt        formatter.NullableDataType[time.Time] `db:"deleted_at" json:"deletedAt"`
+	DeletedBy        formatter.NullableDataType[int64]     `db:"deleted_by" json:"deletedBy"`
+}
+
+type OrderInput struct {
+	UserId           int       `db:"user_id" json:"-"`
+	PremiumFeatureId int       `db:"premium_feature_id" json:"-"`
+	Amount           int       `db:"amount" json:"-"`
+	Provider         string    `db:"provider" json:"-"`
+	Reference        string    `db:"reference" json:"-"`
+	PaidAt           time.Time `db:"paid_at" json:"-"`
+	Status           int64     `db:"status" json:"-"`
+	CreatedAt        time.Time `db:"created_at" json:"-"`
+	CreatedBy        int64     `db:"created_by" json:"-"`
+	UpdatedAt        time.Time `db:"updated_at" json:"-"`
+	UpdatedBy        int64     `db:"updated_by" json:"-"`
+	DeletedAt        time.Time `db:"deleted_at" json:"-"`
+	DeletedBy        int64     `db:"deleted_by" json:"-"`
+}
+
+type Checkout struct {
+	PremiumFeatureId int `json:"premiumFeatureId" binding:"required"`
+}
+
+// CheckoutRequest is what is sent to the payment provider to open a checkout.
+type CheckoutRequest struct {
+	UserId      int
+	Amount      int
+	Description string
+}
+
+type CheckoutSession struct {
+	OrderId     int64  `json:"orderId"`
+	Reference   string `json:"reference"`
+	CheckoutUrl string `json:"checkoutUrl"`
+}
+
+// PaymentEvent is the provider agnostic shape of a webhook notification.
+type PaymentEvent struct {
+	Id        string `json:"id"`
+	Type      string `json:"type"`
+	Reference string `json:"reference"`
+	Amount    int    `json:"amount"`
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"DatingApp/src/formatter"
+	"errors"
+	"time"
+)
+
+const (
+	PasswordResetUsed = 2
+
+	// PasswordResetTTL is how long the link of a password reset mail works.
+	PasswordResetTTL        = time.Hour
+	DefaultPasswordResetUrl = "http://localhost:3000/reset-password"
+
+	PasswordResetSubject = "Reset your password"
+	PasswordResetBody    = "Someone asked to reset the password of your account, open %s within an hour to choose a new one.\n" +
+		"If it wasnt you, ignore this mail and your password stays the same."
+)
+
+var (
+	ErrInvalidResetToken = errors.New("invalid or expired reset token")
+	ErrWrongPassword     = errors.New("wrong password")
+)
+
+type PasswordReset struct {
+	Id        int64                                 `db:"id" json:"id"`
+	UserId    int                                   `db:"user_id" json:"userId"`
+	TokenHash string                                `db:"token_hash" json:"-"`
+	ExpiresAt time.Time                             `db:"expires_at" json:"expiresAt"`
+	UsedAt    formatter.NullableDataType[time.Time] `db:"used_at" json:"usedAt"`
+	Status    int64                                 `db:"status" json:"status"`
+	CreatedAt formatter.NullableDataType[time.Time] `db:"created_at" json:"createdAt"`
+	CreatedBy formatter.NullableDataType[int64]     `db:"created_by" json:"createdBy"`
+	UpdatedAt formatter.NullableDataType[time.Time] `db:"updated_at" json:"updatedAt"`
+	UpdatedBy formatter.NullableDataType[int64]     `db:"updated_by" json:"updatedBy"`
+	DeletedAt formatter.NullableDataType[time.Time] `db:"deleted_at" json:"deletedAt"`
+	DeletedBy formatter.NullableDataType[int64]     `db:"deleted_by" json:"deletedBy"`
+}
+
+type PasswordResetInput struct {
+	UserId    int       `db:"user_id" json:"-"`
+	TokenHash string    `db:"token_hash" json:"-"`
+	ExpiresAt time.Time `db:"expires_at" json:"-"`
+	UsedAt    time.Time `db:"used_at" json:"-"`
+	Status    int64     `db:"status" json:"-"`
+	CreatedAt time.Time `db:"created_at" json:"-"`
+	CreatedBy int64     `db:"created_by" json:"-"`
+	UpdatedAt time.Time `db:"updated_at" json:"-"`
+	UpdatedBy int64     `db:"updated_by" json:"-"`
+	DeletedAt time.Time `db:"deleted_at" json:"-"`
+	DeletedBy int64     `db:"deleted_by" json:"-"`
+}
+
+// ForgotPasswordRequest is the body of POST /auth/password/forgot.
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest is the body of POST /auth/password/reset, Token comes from the reset mail.
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8,max=72"`
+}
+
+// ChangePasswordRequest is the body of PUT /user/me/password.
+type ChangePasswordRequest struct {
+	OldPassword string `json:"oldPassword" binding:"required"`
+	NewPassword string `json:"newPassword" binding:"required,min=8,max=72"`
+}
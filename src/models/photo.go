@@ -0,0 +1,78 @@
+package models
+
+import (
+	"DatingApp/src/formatter"
+	"errors"
+	"time"
+)
+
+const (
+	// StorageScheme prefixes images kept in the app storage, anything else is an url set by the client.
+	StorageScheme = "storage://"
+
+	PhotoSizeSmall    = "small"
+	PhotoSizeMedium   = "medium"
+	PhotoSizeLarge    = "large"
+	PhotoSizeOriginal = "original"
+
+	MaxPhotos     = 6
+	MaxPhotoBytes = 10 << 20
+	PhotoUrlTTL   = time.Hour
+
+	DefaultStorageDir = "storage"
+	DefaultStorageUrl = "http://localhost:8080/api/v1/photos"
+)
+
+// PhotoSizes is the longest side in pixels of every thumbnail made of an upload,
+// the original keeps its size.
+var PhotoSizes = map[string]int{
+	PhotoSizeSmall:  160,
+	PhotoSizeMedium: 480,
+	PhotoSizeLarge:  1080,
+}
+
+var (
+	ErrUnsupportedPhoto  = errors.New("photo must be a jpeg, png or gif image")
+	ErrPhotoTooLarge     = errors.New("photo is too large")
+	ErrTooManyPhotos     = errors.New("too many photos")
+	ErrInvalidPhotoOrder = errors.New("photo order must list every photo once")
+	ErrUnknownPhotoUrl   = errors.New("signed photo url isnt one of the user's photos")
+)
+
+type Photo struct {
+	Id        int64                                 `db:"id" json:"id"`
+	UserId    int                                   `db:"user_id" json:"userId"`
+	Url       string                                `db:"url" json:"url"`
+	Position  int                                   `db:"position" json:"position"`
+	Status    int64                                 `db:"status" json:"status"`
+	CreatedAt formatter.NullableDataType[time.Time] `db:"created_at" json:"createdAt"`
+	CreatedBy formatter.NullableDataType[int64]     `db:"created_by" json:"createdBy"`
+	UpdatedAt formatter.NullableDataType[time.Time] `db:"updated_at" json:"updatedAt"`
+	UpdatedBy formatter.NullableDataType[int64]     `db:"updated_by" json:"updatedBy"`
+	DeletedAt formatter.NullableDataType[time.Time] `db:"deleted_at" json:"deletedAt"`
+	DeletedBy formatter.NullableDataType[int64]     `db:"deleted_by" json:"deletedBy"`
+}
+
+type PhotoInput struct {
+	UserId    int       `db:"user_id" json:"-"`
+	Url       string    `db:"url" json:"-"`
+	Position  int       `db:"position" json:"-"`
+	Status    int64     `db:"status" json:"-"`
+	CreatedAt time.Time `db:"created_at" json:"-"`
+	CreatedBy int64     `db:"created_by" json:"-"`
+	UpdatedAt time.Time `db:"updated_at" json:"-"`
+	UpdatedBy int64     `db:"updated_by" json:"-"`
+	DeletedAt time.Time `db:"deleted_at" json:"-"`
+	DeletedBy int64     `db:"deleted_by" json:"-"`
+}
+
+// PhotoOrderRequest is the body of PUT /user/me/photos/order, Ids are every photo of the user first to last.
+type PhotoOrderRequest struct {
+	Ids []int `json:"ids" binding:"required,min=1,max=6,unique"`
+}
+
+// PhotoQuery is the query of the signed urls served by GET /photos/*key.
+type PhotoQuery struct {
+	Expires   int64  `form:"expires" binding:"required"`
+	Signature string `form:"signature" binding:"required"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"DatingApp/src/formatter"
+	"time"
+)
+
+const (
+	DefaultMinAge = MinimumAge
+	DefaultMaxAge = 99
+)
+
+type Preference struct {
+	Id            int64                                 `db:"id" json:"id"`
+	UserId        int                                   `db:"user_id" json:"userId"`
+	MinAge        int                                   `db:"min_age" json:"minAge"`
+	MaxAge        int                                   `db:"max_age" json:"maxAge"`
+	MaxDistanceKm int                                   `db:"max_distance_km" json:"maxDistanceKm"`
+	Status        int64                                 `db:"status" json:"status"`
+	CreatedAt     formatter.NullableDataType[time.Time] `db:"created_at" json:"createdAt"`
+	CreatedBy     formatter.NullableDataType[int64]     `db:"created_by" json:"createdBy"`
+	UpdatedAt     formatter.NullableDataType[time.Time] `db:"updated_at" json:"updatedAt"`
+	UpdatedBy     formatter.NullableDataType[int64]     `db:"updated_by" json:"updatedBy"`
+	DeletedAt     formatter.NullableDataType[time.Time] `db:"deleted_at" json:"deletedAt"`
+	DeletedBy     formatter.NullableDataType[int64]     `db:"deleted_by" json:"deletedBy"`
+}
+
+type PreferenceInput struct {
+	UserId        int       `db:"user_id" json:"-"`
+	MinAge        int       `db:"min_age" json:"-"`
+	MaxAge        int       `db:"max_age" json:"-"`
+	MaxDistanceKm int       `db:"max_distance_km" json:"-"`
+	Status        int64     `db:"status" json:"-"`
+	CreatedAt     time.Time `db:"created_at" json:"-"`
+	CreatedBy     int64     `db:"created_by" json:"-"`
+	UpdatedAt     time.Time `db:"updated_at" json:"-"`
+	UpdatedBy     int64     `db:"updated_by" json:"-"`
+	DeletedAt     time.Time `db:"deleted_at" json:"-"`
+	DeletedBy     int64     `db:"deleted_by" json:"-"`
+}
+
+// PreferenceRequest is the body of PUT /user/me/preferences, a MaxDistanceKm of 0 means no limit.
+// The wanted genders are the InterestedIn of the profile.
+type PreferenceRequest struct {
+	MinAge        int `json:"minAge" binding:"required,min=18,max=99"`
+	MaxAge        int `json:"maxAge" binding:"required,min=18,max=99,gtefield=MinAge"`
+	MaxDistanceKm int `json:"maxDistanceKm" binding:"min=0,max=500"`
+}
+
+type UserPreference struct {
+	MinAge        int      `json:"minAge"`
+	MaxAge        int      `json:"maxAge"`
+	MaxDistanceKm int      `json:"maxDistanceKm"`
+	Genders       []string `json:"genders"`
+}
@@ -2,30 +2,50 @@ package models
 
 import (
 	"DatingApp/src/formatter"
+	"errors"
 	"time"
 )
 
+var (
+	ErrPremiumRequired = errors.New("premium feature required")
+	ErrNotPurchasable  = errors.New("premium feature is not for sale")
+)
+
+// grantedOnlyFlags are features the app grants by itself, they're never sold whatever their price.
+var grantedOnlyFlags = map[string]bool{
+	VerifiedFlag: true,
+}
+
 type PremiumFeature struct {
-	Id        int64                                 `db:"id" json:"id"`
-	Name      string                                `db:"name" json:"name"`
-	Flag      string                                `db:"flag" json:"flag"`
-	Status    int64                                 `db:"status" json:"status"`
-	CreatedAt formatter.NullableDataType[time.Time] `db:"created_at" json:"createdAt"`
-	CreatedBy formatter.NullableDataType[int64]     `db:"created_by" json:"createdBy"`
-	UpdatedAt formatter.NullableDataType[time.Time] `db:"updated_at" json:"updatedAt"`
-	UpdatedBy formatter.NullableDataType[int64]     `db:"updated_by" json:"updatedBy"`
-	DeletedAt formatter.NullableDataType[time.Time] `db:"deleted_at" json:"deletedAt"`
-	DeletedBy formatter.NullableDataType[int64]     `db:"deleted_by" json:"deletedBy"`
+	Id           int64                                 `db:"id" json:"id"`
+	Name         string                                `db:"name" json:"name"`
+	Flag         string                                `db:"flag" json:"flag"`
+	DurationDays int                                   `db:"duration_days" json:"durationDays"`
+	Price        int                                   `db:"price" json:"price"`
+	Status       int64                                 `db:"status" json:"status"`
+	CreatedAt    formatter.NullableDataType[time.Time] `db:"created_at" json:"createdAt"`
+	CreatedBy    formatter.NullableDataType[int64]     `db:"created_by" json:"createdBy"`
+	UpdatedAt    formatter.NullableDataType[time.Time] `db:"updated_at" json:"updatedAt"`
+	UpdatedBy    formatter.NullableDataType[int64]     `db:"updated_by" json:"updatedBy"`
+	DeletedAt    formatter.NullableDataType[time.Time] `db:"deleted_at" json:"deletedAt"`
+	DeletedBy    formatter.NullableDataType[int64]     `db:"deleted_by" json:"deletedBy"`
+}
+
+// Purchasable tells whether users can buy the feature through a checkout.
+func (f PremiumFeature) Purchasable() bool {
+	return f.Price > 0 && !grantedOnlyFlags[f.Flag]
 }
 
 type PremiumFeatureInput struct {
-	Name      string    `db:"name" json:"name"`
-	Flag      string    `db:"flag" json:"flag"`
-	Status    int64     `db:"status" json:"-"`
-	CreatedAt time.Time `db:"created_at" json:"-"`
-	CreatedBy int64     `db:"created_by" json:"-"`
-	UpdatedAt time.Time `db:"updated_at" json:"-"`
-	UpdatedBy int64     `db:"updated_by" json:"-"`
-	DeletedAt time.Time `db:"deleted_at" json:"-"`
-	DeletedBy int64     `db:"deleted_by" json:"-"`
+	Name         string    `db:"name" json:"name"`
+	Flag         string    `db:"flag" json:"flag"`
+	DurationDays int       `db:"duration_days" json:"durationDays"`
+	Price        int       `db:"price" json:"price"`
+	Status       int64     `db:"status" json:"-"`
+	CreatedAt    time.Time `db:"created_at" json:"-"`
+	CreatedBy    int64     `db:"created_by" json:"-"`
+	UpdatedAt    time.Time `db:"updated_at" json:"-"`
+	UpdatedBy    int64     `db:"updated_by" json:"-"`
+	DeletedAt    time.Time `db:"deleted_at" json:"-"`
+	DeletedBy    int64     `db:"deleted_by" json:"-"`
 }
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"DatingApp/src/formatter"
+	"errors"
+	"time"
+)
+
+const (
+	GenderMale      = "male"
+	GenderFemale    = "female"
+	GenderNonBinary = "non-binary"
+
+	BirthdateLayout = "2006-01-02"
+	MinimumAge      = 18
+)
+
+var ErrUnderage = errors.New("user must be at least 18 years old")
+
+type Profile struct {
+	Id          int64                                 `db:"id" json:"id"`
+	UserId      int                                   `db:"user_id" json:"userId"`
+	DisplayName string                                `db:"display_name" json:"displayName"`
+	Birthdate   time.Time                             `db:"birthdate" json:"birthdate"`
+	Gender      string                                `db:"gender" json:"gender"`
+	Bio         string                                `db:"bio" json:"bio"`
+	HeightCm    int                                   `db:"height_cm" json:"heightCm"`
+	Job         string                                `db:"job" json:"job"`
+	Status      int64                                 `db:"status" json:"status"`
+	CreatedAt   formatter.NullableDataType[time.Time] `db:"created_at" json:"createdAt"`
+	CreatedBy   formatter.NullableDataType[int64]     `db:"created_by" json:"createdBy"`
+	UpdatedAt   formatter.NullableDataType[time.Time] `db:"updated_at" json:"updatedAt"`
+	UpdatedBy   formatter.NullableDataType[int64]     `db:"updated_by" json:"updatedBy"`
+	DeletedAt   formatter.NullableDataType[time.Time] `db:"deleted_at" json:"deletedAt"`
+	DeletedBy   formatter.NullableDataType[int64]     `db:"deleted_by" json:"deletedBy"`
+}
+
+type ProfileInput struct {
+	UserId      int       `db:"user_id" json:"-"`
+	DisplayName string    `db:"display_name" json:"-"`
+	Birthdate   time.Time `db:"birthdate" json:"-"`
+	Gender      string    `db:"gender" json:"-"`
+	Bio         string    `db:"bio" json:"-"`
+	HeightCm    int       `db:"height_cm" json:"-"`
+	Job         string    `db:"job" json:"-"`
+	Status      int64     `db:"status" json:"-"`
+	CreatedAt   time.Time `db:"created_at" json:"-"`
+	CreatedBy   int64     `db:"created_by" json:"-"`
+	UpdatedAt   time.Time `db:"updated_at" json:"-"`
+	UpdatedBy   int64     `db:"updated_by" json:"-"`
+	DeletedAt   time.Time `db:"deleted_at" json:"-"`
+	DeletedBy   int64     `db:"deleted_by" json:"-"`
+}
+
+// ProfilePhoto is a photo as shown, Thumbnails are only there for uploaded photos.
+type ProfilePhoto struct {
+	Id         int64             `db:"id" json:"id"`
+	Url        string            `db:"url" json:"url"`
+	Position   int               `db:"position" json:"position"`
+	Thumbnails map[string]string `json:"thumbnails,omitempty"`
+}
+
+// ProfileRequest is the body of PUT /user/me/profile, it replaces the whole profile
+// except the photos when Photos is left out.
+type ProfileRequest struct {
+	DisplayName  string   `json:"displayName" binding:"required,max=64"`
+	Birthdate    string   `json:"birthdate" binding:"required,datetime=2006-01-02"`
+	Gender       string   `json:"gender" binding:"required,oneof=male female non-binary"`
+	InterestedIn []string `json:"interestedIn" binding:"required,min=1,unique,dive,oneof=male female non-binary"`
+	Bio          string   `json:"bio" binding:"max=500"`
+	HeightCm     int      `json:"heightCm" binding:"omitempty,min=100,max=250"`
+	Job          string   `json:"job" binding:"max=128"`
+	Interests    []string `json:"interests" binding:"max=10,unique,dive,required,max=32"`
+	Photos       []string `json:"photos" binding:"max=6,dive,required,http_url"`
+}
+
+type UserProfile struct {
+	UserId       int64          `json:"userId"`
+	DisplayName  string         `json:"displayName"`
+	Birthdate    string         `json:"birthdate"`
+	Age          int            `json:"age"`
+	Gender       string         `json:"gender"`
+	InterestedIn []string       `json:"interestedIn"`
+	Bio          string         `json:"bio"`
+	HeightCm     int            `json:"heightCm"`
+	Job          string         `json:"job"`
+	Interests    []string       `json:"interests"`
+	Photos       []ProfilePhoto `json:"photos"`
+}
+
+// Age returns how old someone born at birthdate is at now.
+func Age(birthdate, now time.Time) int {
+	age := now.Year() - birthdate.Year()
+	if now.Month() < birthdate.Month() || (now.Month() == birthdate.Month() && now.Day() < birthdate.Day()) {
+		age--
+	}
+	return age
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"DatingApp/src/formatter"
+	"errors"
+	"time"
+)
+
+const (
+	ActivityLike = "like"
+	ActivityPass = "pass"
+	// ActivitySuperLike is a like the liked user is always told about, it has its own quota.
+	ActivitySuperLike = "superlike"
+	ActivityAny       = "any"
+	// ActivityRewind isn't a swipe, policies of any activity don't limit it.
+	ActivityRewind = "rewind"
+
+	QuotaPeriodDaily   = "daily"
+	QuotaPeriodRolling = "rolling"
+	QuotaPeriodHourly  = "hourly"
+
+	QuotaUnlimited = -1
+)
+
+var ErrQuotaExceeded = errors.New("reached max activity quota")
+
+// QuotaPolicy limits how many activities a user can do per period. Policies without a premium
+// feature are the default, the ones with a premium feature replace them for entitled users.
+type QuotaPolicy struct {
+	Id               int64                                 `db:"id" json:"id"`
+	Activity         string                                `db:"activity" json:"activity"`
+	PremiumFeatureId formatter.NullableDataType[int64]     `db:"premium_feature_id" json:"premiumFeatureId"`
+	Period           string                                `db:"period" json:"period"`
+	MaxActivity      int                                   `db:"max_activity" json:"maxActivity"`
+	Status           int64                                 `db:"status" json:"status"`
+	CreatedAt        formatter.NullableDataType[time.Time] `db:"created_at" json:"createdAt"`
+	CreatedBy        formatter.NullableDataType[int64]     `db:"created_by" json:"createdBy"`
+	UpdatedAt        formatter.NullableDataType[time.Time] `db:"updated_at" json:"updatedAt"`
+	UpdatedBy        formatter.NullableDataType[int64]     `db:"updated_by" json:"updatedBy"`
+	DeletedAt        formatter.NullableDataType[time.Time] `db:"deleted_at" json:"deletedAt"`
+	DeletedBy        formatter.NullableDataType[int64]     `db:"deleted_by" json:"deletedBy"`
+}
+
+type QuotaPolicyInput struct {
+	Activity         string    `db:"activity" json:"activity"`
+	PremiumFeatureId int       `db:"premium_feature_id" json:"premiumFeatureId"`
+	Period           string    `db:"period" json:"period"`
+	MaxActivity      int       `db:"max_activity" json:"maxActivity"`
+	Status           int64     `db:"status" json:"-"`
+	CreatedAt        time.Time `db:"created_at" json:"-"`
+	CreatedBy        int64     `db:"created_by" json:"-"`
+	UpdatedAt        time.Time `db:"updated_at" json:"-"`
+	UpdatedBy        int64     `db:"updated_by" json:"-"`
+	DeletedAt        time.Time `db:"deleted_at" json:"-"`
+	DeletedBy        int64     `db:"deleted_by" json:"-"`
+}
+
+func (p QuotaPolicy) IsUnlimited() bool {
+	return p.MaxActivity == QuotaUnlimited
+}
+
+// ActivityCount is how many activities a user did since a point in time and when the oldest of them was.
+type ActivityCount struct {
+	Count  int
+	Oldest formatter.NullableDataType[time.Time]
+}
+
+type Quota struct {
+	Activity  string     `json:"activity"`
+	Unlimited bool       `json:"unlimited"`
+	Limit     int        `json:"limit"`
+	Remaining int        `json:"remaining"`
+	ResetAt   *time.Time `json:"resetAt"`
+}
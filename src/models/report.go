@@ -0,0 +1,62 @@
+package models
+
+import (
+	"DatingApp/src/formatter"
+	"errors"
+	"time"
+)
+
+const (
+	ReportOpen      = 1
+	ReportResolved  = 2
+	ReportDismissed = 3
+
+	ReportActionResolve = "resolve"
+	ReportActionDismiss = "dismiss"
+
+	// UserSuspended is the status of a user suspended by a moderator, only status 1 users can log in.
+	UserSuspended = 2
+)
+
+var ErrReportClosed = errors.New("report is already closed")
+
+type Report struct {
+	Id             int64                                 `db:"id" json:"id"`
+	ReporterId     int                                   `db:"reporter_id" json:"reporterId"`
+	ReportedUserId int                                   `db:"reported_user_id" json:"reportedUserId"`
+	Reason         string                                `db:"reason" json:"reason"`
+	Description    formatter.NullableDataType[string]    `db:"description" json:"description"`
+	Status         int64                                 `db:"status" json:"status"`
+	CreatedAt      formatter.NullableDataType[time.Time] `db:"created_at" json:"createdAt"`
+	CreatedBy      formatter.NullableDataType[int64]     `db:"created_by" json:"createdBy"`
+	UpdatedAt      formatter.NullableDataType[time.Time] `db:"updated_at" json:"updatedAt"`
+	UpdatedBy      formatter.NullableDataType[int64]     `db:"updated_by" json:"updatedBy"`
+	DeletedAt      formatter.NullableDataType[time.Time] `db:"deleted_at" json:"deletedAt"`
+	DeletedBy      formatter.NullableDataType[int64]     `db:"deleted_by" json:"deletedBy"`
+}
+
+type ReportInput struct {
+	ReporterId     int       `db:"reporter_id" json:"-"`
+	ReportedUserId int       `db:"reported_user_id" json:"-"`
+	Reason         string    `db:"reason" json:"-"`
+	Description    string    `db:"description" json:"-"`
+	Status         int64     `db:"status" json:"-"`
+	CreatedAt      time.Time `db:"created_at" json:"-"`
+	CreatedBy      int64     `db:"created_by" json:"-"`
+	UpdatedAt      time.Time `db:"updated_at" json:"-"`
+	UpdatedBy      int64     `db:"updated_by" json:"-"`
+	DeletedAt      time.Time `db:"deleted_at" json:"-"`
+	DeletedBy      int64     `db:"deleted_by" json:"-"`
+}
+
+// ReportRequest is the body of POST /user/{id}/report.
+type ReportRequest struct {
+	Reason      string `json:"reason" binding:"required,oneof=spam harassment fake inappropriate underage other"`
+	Description string `json:"description" binding:"max=1000"`
+}
+
+// ReportResolution is the body of PUT /admin/reports/{id}, SuspendUser only counts when the report is resolved.
+type ReportResolution struct {
+	Action      string `json:"action" binding:"required,oneof=resolve dismiss"`
+	SuspendUser bool   `json:"suspendUser"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"DatingApp/src/formatter"
+	"time"
+)
+
+type Session struct {
+	Id               int64                                 `db:"id" json:"id"`
+	UserId           int                                   `db:"user_id" json:"userId"`
+	RefreshTokenHash string                                `db:"refresh_token_hash" json:"-"`
+	ExpiresAt        time.Time                             `db:"expires_at" json:"expiresAt"`
+	Status           int64                                 `db:"status" json:"status"`
+	CreatedAt        formatter.NullableDataType[time.Time] `db:"created_at" json:"createdAt"`
+	CreatedBy        formatter.NullableDataType[int64]     `db:"created_by" json:"createdBy"`
+	UpdatedAt        formatter.NullableDataType[time.Time] `db:"updated_at" json:"updatedAt"`
+	UpdatedBy        formatter.NullableDataType[int64]     `db:"updated_by" json:"updatedBy"`
+	DeletedAt        formatter.NullableDataType[time.Time] `db:"deleted_at" json:"deletedAt"`
+	DeletedBy        formatter.NullableDataType[int64]     `db:"deleted_by" json:"deletedBy"`
+}
+
+type SessionInput struct {
+	UserId           int       `db:"user_id" json:"-"`
+	RefreshTokenHash string    `db:"refresh_token_hash" json:"-"`
+	ExpiresAt        time.Time `db:"expires_at" json:"-"`
+	Status           int64     `db:"status" json:"-"`
+	CreatedAt        time.Time `db:"created_at" json:"-"`
+	CreatedBy        int64     `db:"created_by" json:"-"`
+	UpdatedAt        time.Time `db:"updated_at" json:"-"`
+	UpdatedBy        int64     `db:"updated_by" json:"-"`
+	DeletedAt        time.Time `db:"deleted_at" json:"-"`
+	DeletedBy        int64     `db:"deleted_by" json:"-"`
+}
+
+type RefreshToken struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"DatingApp/src/formatter"
+	"time"
+)
+
+const (
+	SubscriptionActive  = 1
+	SubscriptionExpired = 2
+
+	SubscriptionSourceManual = "manual"
+)
+
+type Subscription struct {
+	Id               int64                                 `db:"id" json:"id"`
+	UserId           int                                   `db:"user_id" json:"userId"`
+	PremiumFeatureId int                                   `db:"premium_feature_id" json:"premiumFeatureId"`
+	StartedAt        time.Time                             `db:"started_at" json:"startedAt"`
+	ExpiresAt        time.Time                             `db:"expires_at" json:"expiresAt"`
+	Source           string                                `db:"source" json:"source"`
+	Status           int64                                 `db:"status" json:"status"`
+	CreatedAt        formatter.NullableDataType[time.Time] `db:"created_at" json:"createdAt"`
+	CreatedBy        formatter.NullableDataType[int64]     `db:"created_by" json:"createdBy"`
+	UpdatedAt        formatter.NullableDataType[time.Time] `db:"updated_at" json:"updatedAt"`
+	UpdatedBy        formatter.NullableDataType[int64]     `db:"updated_by" json:"updatedBy"`
+	DeletedAt        formatter.NullableDataType[time.Time] `db:"deleted_at" json:"deletedAt"`
+	DeletedBy        formatter.NullableDataType[int64]     `db:"deleted_by" json:"deletedBy"`
+}
+
+type SubscriptionInput struct {
+	UserId           int       `db:"user_id" json:"-"`
+	PremiumFeatureId int       `db:"premium_feature_id" json:"-"`
+	StartedAt        time.Time `db:"started_at" json:"-"`
+	ExpiresAt        time.Time `db:"expires_at" json:"-"`
+	Source           string    `db:"source" json:"-"`
+	Status           int64     `db:"status" json:"-"`
+	CreatedAt        time.Time `db:"created_at" json:"-"`
+	CreatedBy        int64     `db:"created_by" json:"-"`
+	UpdatedAt        time.Time `db:"updated_at" json:"-"`
+	UpdatedBy        int64     `db:"updated_by" json:"-"`
+	DeletedAt        time.Time `db:"deleted_at" json:"-"`
+	DeletedBy        int64     `db:"deleted_by" json:"-"`
+}
+
+type UserSubscription struct {
+	Current []Subscription `json:"current"`
+	History []Subscription `json:"history"`
+}
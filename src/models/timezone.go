@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+const DefaultTimezone = "UTC"
+
+// LoadLocation is time.LoadLocation falling back to UTC, so a bad timezone never breaks a request.
+func LoadLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// DayWindow returns the start of the day of now in loc and the start of the next one.
+func DayWindow(now time.Time, loc *time.Location) (time.Time, time.Time) {
+	local := now.In(loc)
+	start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	return start, start.AddDate(0, 0, 1)
+}
+
+func (u User) Location() *time.Location {
+	return LoadLocation(u.Timezone)
+}
@@ -2,45 +2,120 @@ package models
 
 import (
 	"DatingApp/src/formatter"
+	"errors"
 	"time"
 )
 
+var ErrUserNotFound = errors.New("user doesnt exists")
+
 type User struct {
-	Id               int64                                 `db:"id" json:"id"`
-	UserName         string                                `db:"user_name" json:"userName"`
-	Password         string                                `db:"password" json:"password"`
-	Image            formatter.NullableDataType[string]    `db:"image" json:"image"`
-	PremiumFeatureId formatter.NullableDataType[int]       `db:"premium_feature_id" json:"premiumFeatureId"`
-	Status           int64                                 `db:"status" json:"status"`
-	CreatedAt        formatter.NullableDataType[time.Time] `db:"created_at" json:"createdAt"`
-	CreatedBy        formatter.NullableDataType[int64]     `db:"created_by" json:"createdBy"`
-	UpdatedAt        formatter.NullableDataType[time.Time] `db:"updated_at" json:"updatedAt"`
-	UpdatedBy        formatter.NullableDataType[int64]     `db:"updated_by" json:"updatedBy"`
-	DeletedAt        formatter.NullableDataType[time.Time] `db:"deleted_at" json:"deletedAt"`
-	DeletedBy        formatter.NullableDataType[int64]     `db:"deleted_by" json:"deletedBy"`
+	Id        int64                                 `db:"id" json:"id"`
+	UserName  string                                `db:"user_name" json:"userName"`
+	Email     formatter.NullableDataType[string]    `db:"email" json:"-"`
+	Password  string                                `db:"password" json:"-"`
+	Image     formatter.NullableDataType[string]    `db:"image" json:"image"`
+	Role      string                                `db:"role" json:"role"`
+	Timezone  string                                `db:"timezone" json:"timezone"`
+	Latitude  formatter.NullableDataType[float64]   `db:"latitude" json:"-"`
+	Longitude formatter.NullableDataType[float64]   `db:"longitude" json:"-"`
+	Status    int64                                 `db:"status" json:"status"`
+	CreatedAt formatter.NullableDataType[time.Time] `db:"created_at" json:"createdAt"`
+	CreatedBy formatter.NullableDataType[int64]     `db:"created_by" json:"createdBy"`
+	UpdatedAt formatter.NullableDataType[time.Time] `db:"updated_at" json:"updatedAt"`
+	UpdatedBy formatter.NullableDataType[int64]     `db:"updated_by" json:"updatedBy"`
+	DeletedAt formatter.NullableDataType[time.Time] `db:"deleted_at" json:"deletedAt"`
+	DeletedBy formatter.NullableDataType[int64]     `db:"deleted_by" json:"deletedBy"`
 }
 
 type UserInput struct {
-	UserName         string    `db:"user_name" json:"userName"`
-	Password         string    `db:"password" json:"password"`
-	Image            string    `db:"image" json:"image"`
-	PremiumFeatureId int       `db:"premium_feature_id" json:"-"`
-	Status           int64     `db:"status" json:"-"`
-	CreatedAt        time.Time `db:"created_at" json:"-"`
-	CreatedBy        int64     `db:"created_by" json:"-"`
-	UpdatedAt        time.Time `db:"updated_at" json:"-"`
-	UpdatedBy        int64     `db:"updated_by" json:"-"`
-	DeletedAt        time.Time `db:"deleted_at" json:"-"`
-	DeletedBy        int64     `db:"deleted_by" json:"-"`
+	UserName  string    `db:"user_name" json:"userName"`
+	Email     string    `db:"email" json:"email" binding:"omitempty,email,max=255"`
+	Password  string    `db:"password" json:"password"`
+	Image     string    `db:"image" json:"image" binding:"omitempty,http_url"`
+	Role      string    `db:"role" json:"-"`
+	Timezone  string    `db:"timezone" json:"timezone"`
+	Status    int64     `db:"status" json:"-"`
+	CreatedAt time.Time `db:"created_at" json:"-"`
+	CreatedBy int64     `db:"created_by" json:"-"`
+	UpdatedAt time.Time `db:"updated_at" json:"-"`
+	UpdatedBy int64     `db:"updated_by" json:"-"`
+	DeletedAt time.Time `db:"deleted_at" json:"-"`
+	DeletedBy int64     `db:"deleted_by" json:"-"`
+}
+
+func (u User) IsAdmin() bool {
+	return u.Role == RoleAdmin
 }
 
+// UserDetail is a user with their active premium feature flags, Verified is there as a badge
+// so clients dont have to look for the verified flag.
+type UserDetail struct {
+	User
+	Features []string `json:"features"`
+	Verified bool     `json:"verified"`
+}
+
+// Subscribe is what an admin grants without a payment, UserId is who gets the feature.
 type Subscribe struct {
-	PremiumFeatureId int `json:"premiumFeatureId"`
+	UserId           int `json:"userId" binding:"required"`
+	PremiumFeatureId int `json:"premiumFeatureId" binding:"required"`
 }
 
 type RecomendationUser struct {
-	Id               int64   `db:"id" json:"id"`
-	UserName         string  `db:"user_name" json:"userName"`
-	PremiumFeatureId *int    `db:"premium_feature_id" json:"premiumFeatureId"`
-	Image            *string `db:"image" json:"image"`
+	Id            int64    `db:"id" json:"id"`
+	UserName      string   `db:"user_name" json:"userName"`
+	Image         *string  `db:"image" json:"image"`
+	Distance      *float64 `db:"distance" json:"distance"`
+	SuperLikedYou bool     `db:"super_liked_you" json:"superLikedYou"`
+	Verified      bool     `db:"verified" json:"verified"`
+}
+
+// RecomendationCandidate is a user that could be recomended together with what the rankers score on,
+// Distance is exact here and must go through ApproximateDistance before it's shown.
+type RecomendationCandidate struct {
+	Id              int64                                 `db:"id"`
+	UserName        string                                `db:"user_name"`
+	Image           *string                               `db:"image"`
+	Distance        *float64                              `db:"distance"`
+	LastActiveAt    formatter.NullableDataType[time.Time] `db:"last_active_at"`
+	ProfileFields   int                                   `db:"profile_fields"`
+	PhotoCount      int                                   `db:"photo_count"`
+	InterestCount   int                                   `db:"interest_count"`
+	MutualInterests int                                   `db:"mutual_interests"`
+	LikedYou        bool                                  `db:"liked_you"`
+	SuperLikedYou   bool                                  `db:"super_liked_you"`
+	Premium         bool                                  `db:"premium"`
+	Boosted         bool                                  `db:"boosted"`
+	Verified        bool                                  `db:"verified"`
+}
+
+func (c RecomendationCandidate) RecomendationUser() RecomendationUser {
+	result := RecomendationUser{
+		Id:            c.Id,
+		UserName:      c.UserName,
+		Image:         c.Image,
+		SuperLikedYou: c.SuperLikedYou,
+		Verified:      c.Verified,
+	}
+	if c.Distance != nil {
+		distance := ApproximateDistance(*c.Distance)
+		result.Distance = &distance
+	}
+	return result
+}
+
+// RecomendationQuery is the query of GET /user/recomendation, Size is how many cards the deck holds.
+type RecomendationQuery struct {
+	Size int `form:"size" binding:"omitempty,min=1,max=50"`
+}
+
+// RecomendationParam holds what the recomendation query needs to know about the user asking,
+// Origin is nil when the user has no location and MaxDistanceKm 0 means no limit,
+// users passed since PassedSince are still cooling down.
+type RecomendationParam struct {
+	UserId        int
+	Timezone      *time.Location
+	Origin        *Coordinate
+	MaxDistanceKm int
+	PassedSince   time.Time
 }
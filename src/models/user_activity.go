@@ -10,6 +10,8 @@ type UserActivity struct {
 	UserId       int                                   `db:"user_id" json:"userId"`
 	PassedUserId formatter.NullableDataType[int]       `db:"passed_user_id" json:"passedUserId"`
 	LikedUserId  formatter.NullableDataType[int]       `db:"liked_user_id" json:"likedUserId"`
+	Activity     string                                `db:"activity" json:"activity"`
+	RewoundAt    formatter.NullableDataType[time.Time] `db:"rewound_at" json:"rewoundAt"`
 	Status       int64                                 `db:"status" json:"status"`
 	CreatedAt    formatter.NullableDataType[time.Time] `db:"created_at" json:"createdAt"`
 	CreatedBy    formatter.NullableDataType[int64]     `db:"created_by" json:"createdBy"`
@@ -23,6 +25,8 @@ type UserActivityInput struct {
 	UserId       int       `db:"user_id" json:"userId"`
 	PassedUserId int       `db:"passed_user_id" json:"passedUserId"`
 	LikedUserId  int       `db:"liked_user_id" json:"likedUserId"`
+	Activity     string    `db:"activity" json:"-"`
+	RewoundAt    time.Time `db:"rewound_at" json:"-"`
 	Status       int64     `db:"status" json:"-"`
 	CreatedAt    time.Time `db:"created_at" json:"-"`
 	CreatedBy    int64     `db:"created_by" json:"-"`
@@ -33,5 +37,46 @@ type UserActivityInput struct {
 }
 
 type UserActivityInputJson struct {
-	TargetUserId int `json:"targetUserId"`
+	TargetUserId int `json:"targetUserId" binding:"required"`
+}
+
+// UserActivityPath is the path of POST /user-activity/{activity}.
+type UserActivityPath struct {
+	Activity string `uri:"activity" binding:"required,oneof=like pass superlike"`
+}
+
+// LikeReceived is a user who liked the user and wasn't swiped back yet.
+type LikeReceived struct {
+	Id        int64     `db:"id"`
+	UserName  string    `db:"user_name"`
+	Image     *string   `db:"image"`
+	SuperLike bool      `db:"super_like"`
+	LikedAt   time.Time `db:"liked_at"`
+}
+
+// LikeReceivedUser is a LikeReceived as shown to the user, a blurred one doesn't tell who liked.
+type LikeReceivedUser struct {
+	Id        int64     `json:"id,omitempty"`
+	UserName  string    `json:"userName,omitempty"`
+	Image     *string   `json:"image,omitempty"`
+	SuperLike bool      `json:"superLike"`
+	LikedAt   time.Time `json:"likedAt"`
+	Blurred   bool      `json:"blurred"`
+}
+
+func (l LikeReceived) LikeReceivedUser(blurred bool) LikeReceivedUser {
+	if blurred {
+		return LikeReceivedUser{
+			SuperLike: l.SuperLike,
+			LikedAt:   l.LikedAt,
+			Blurred:   true,
+		}
+	}
+	return LikeReceivedUser{
+		Id:        l.Id,
+		UserName:  l.UserName,
+		Image:     l.Image,
+		SuperLike: l.SuperLike,
+		LikedAt:   l.LikedAt,
+	}
 }
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"DatingApp/src/formatter"
+	"errors"
+	"time"
+)
+
+const (
+	VerificationPending  = 1
+	VerificationApproved = 2
+	VerificationRejected = 3
+
+	VerificationActionApprove = "approve"
+	VerificationActionReject  = "reject"
+
+	// VerifiedFlag is the premium feature an approved verification grants.
+	VerifiedFlag = "verified"
+
+	SubscriptionSourceVerification = "verification"
+)
+
+var (
+	ErrVerificationPending  = errors.New("a verification is already waiting for review")
+	ErrAlreadyVerified      = errors.New("user is already verified")
+	ErrVerificationNotFound = errors.New("verification doesnt exists")
+)
+
+type Verification struct {
+	Id        int64                                 `db:"id" json:"id"`
+	UserId    int                                   `db:"user_id" json:"userId"`
+	Selfie    string                                `db:"selfie" json:"selfie"`
+	Reason    formatter.NullableDataType[string]    `db:"reason" json:"reason"`
+	Status    int64                                 `db:"status" json:"status"`
+	CreatedAt formatter.NullableDataType[time.Time] `db:"created_at" json:"createdAt"`
+	CreatedBy formatter.NullableDataType[int64]     `db:"created_by" json:"createdBy"`
+	UpdatedAt formatter.NullableDataType[time.Time] `db:"updated_at" json:"updatedAt"`
+	UpdatedBy formatter.NullableDataType[int64]     `db:"updated_by" json:"updatedBy"`
+	DeletedAt formatter.NullableDataType[time.Time] `db:"deleted_at" json:"deletedAt"`
+	DeletedBy formatter.NullableDataType[int64]     `db:"deleted_by" json:"deletedBy"`
+}
+
+type VerificationInput struct {
+	UserId    int       `db:"user_id" json:"-"`
+	Selfie    string    `db:"selfie" json:"-"`
+	Reason    string    `db:"reason" json:"-"`
+	Status    int64     `db:"status" json:"-"`
+	CreatedAt time.Time `db:"created_at" json:"-"`
+	CreatedBy int64     `db:"created_by" json:"-"`
+	UpdatedAt time.Time `db:"updated_at" json:"-"`
+	UpdatedBy int64     `db:"updated_by" json:"-"`
+	DeletedAt time.Time `db:"deleted_at" json:"-"`
+	DeletedBy int64     `db:"deleted_by" json:"-"`
+}
+
+// VerificationReview is the body of PUT /admin/verifications/{id}, a rejection has to tell why.
+type VerificationReview struct {
+	Action string `json:"action" binding:"required,oneof=approve reject"`
+	Reason string `json:"reason" binding:"required_if=Action reject,max=255"`
+}
@@ -1,18 +1,29 @@
 package auth
 
 import (
-	"math/rand"
 	"DatingApp/src/models"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	mathrand "math/rand"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	accessTokenDuration = time.Minute * 15
+	refreshTokenLength  = 32
+)
+
 type Interface interface {
 	HashPassword(pwd []byte) (string, error)
 	ComparePassword(hashedPassword, inputPassword []byte) error
-	GenerateToken(userId int, userName string) (string, error)
+	GenerateToken(userId, sessionId int, role string) (string, error)
+	GenerateRefreshToken() (string, error)
+	HashToken(token string) string
 }
 
 type authRepository struct {
@@ -23,7 +34,7 @@ func Init() Interface {
 }
 
 func (r *authRepository) HashPassword(pwd []byte) (string, error) {
-	key := rand.Intn(9)
+	key := mathrand.Intn(9)
 	password, err := bcrypt.GenerateFromPassword(pwd, key)
 	if err != nil {
 		return "", err
@@ -35,11 +46,13 @@ func (r *authRepository) ComparePassword(hashedPassword, inputPassword []byte) e
 	return bcrypt.CompareHashAndPassword(hashedPassword, inputPassword)
 }
 
-func (s *authRepository) GenerateToken(userId int, userName string) (string, error) {
+func (s *authRepository) GenerateToken(userId, sessionId int, role string) (string, error) {
 	claim := jwt.MapClaims{}
 
 	claim["user_id"] = userId
-	claim["time"] = time.Now().Add(time.Hour * 24 * 3)
+	claim["session_id"] = sessionId
+	claim["role"] = role
+	claim["time"] = time.Now().Add(accessTokenDuration)
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claim)
 
@@ -51,3 +64,17 @@ func (s *authRepository) GenerateToken(userId int, userName string) (string, err
 
 	return signedToken, nil
 }
+
+func (s *authRepository) GenerateRefreshToken() (string, error) {
+	token := make([]byte, refreshTokenLength)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// HashToken hashes random tokens before they are stored, a fast hash is enough since they are not guessable.
+func (s *authRepository) HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
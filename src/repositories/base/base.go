@@ -13,6 +13,7 @@ type BaseInterface[T, M, F comparable] interface {
 	Get(ctx context.Context, paging filter.Paging[F]) ([]M, int, error)
 	Create(ctx context.Context, input models.Query[T]) error
 	Update(ctx context.Context, input models.Query[T], id int) error
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type BaseRepository[T, M, F comparable] struct {
@@ -21,60 +22,30 @@ type BaseRepository[T, M, F comparable] struct {
 }
 
 func (r *BaseRepository[T, M, F]) Update(ctx context.Context, input models.Query[T], id int) error {
-	tx, err := r.Db.Begin()
-	if err != nil {
-		return err
-	}
-
-	updateQuery := input.BuildUpdateQuery(id)
-
-	if _, err = tx.ExecContext(ctx, Update+r.TableName+updateQuery); err != nil {
-		tx.Rollback()
-		return err
-	}
-
-	if err = tx.Commit(); err != nil {
-		tx.Rollback()
-		return err
-	}
+	updateQuery, args := input.BuildUpdateQuery(id)
 
-	return nil
+	return r.exec(ctx, Update+r.TableName+updateQuery, args...)
 }
 
 func (r *BaseRepository[T, M, F]) Create(ctx context.Context, input models.Query[T]) error {
-	tx, err := r.Db.Begin()
-	if err != nil {
-		return err
-	}
-
-	createQuery := input.BuildCreateQuery()
-
-	if _, err = tx.ExecContext(ctx, Create+r.TableName+createQuery); err != nil {
-		tx.Rollback()
-		return err
-	}
-
-	if err = tx.Commit(); err != nil {
-		tx.Rollback()
-		return err
-	}
+	createQuery, args := input.BuildCreateQuery()
 
-	return nil
+	return r.exec(ctx, Create+r.TableName+createQuery, args...)
 }
 
 func (r *BaseRepository[T, M, F]) Get(ctx context.Context, paging filter.Paging[F]) ([]M, int, error) {
 
 	var (
-		where      = paging.QueryBuilder()
-		pagination = paging.PaginationQuery()
-		tempModels = models.Query[M]{}
-		member     = tempModels.BuildTableMember()
-		query      = fmt.Sprintf(Select, member)
-		models     = []M{}
-		count      int
+		where, args = paging.QueryBuilder()
+		pagination  = paging.PaginationQuery()
+		tempModels  = models.Query[M]{}
+		member      = tempModels.BuildTableMember()
+		query       = fmt.Sprintf(Select, member)
+		models      = []M{}
+		count       int
 	)
 
-	rowCount, err := r.Db.QueryContext(ctx, Count+r.TableName+where)
+	rowCount, err := r.Conn(ctx).QueryContext(ctx, Count+r.TableName+where, args...)
 	if err != nil {
 		return models, 0, err
 	}
@@ -87,7 +58,7 @@ func (r *BaseRepository[T, M, F]) Get(ctx context.Context, paging filter.Paging[
 		}
 	}
 
-	row, err := r.Db.QueryContext(ctx, query+r.TableName+where+pagination)
+	row, err := r.Conn(ctx).QueryContext(ctx, query+r.TableName+where+pagination, args...)
 	if err != nil {
 		return models, count, err
 	}
@@ -0,0 +1,75 @@
+package base
+
+import (
+	"context"
+	"database/sql"
+)
+
+type txKey struct{}
+
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// Transaction runs fn inside a single db transaction. Every repository call that
+// receives the ctx passed to fn will join the same transaction.
+func (r *BaseRepository[T, M, F]) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+// Conn returns the transaction carried by ctx, or the db when there is none.
+func (r *BaseRepository[T, M, F]) Conn(ctx context.Context) Executor {
+	return Conn(ctx, r.Db)
+}
+
+// Conn is BaseRepository.Conn for repositories that don't embed BaseRepository.
+func Conn(ctx context.Context, db *sql.DB) Executor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+func (r *BaseRepository[T, M, F]) exec(ctx context.Context, query string, args ...interface{}) error {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		_, err := tx.ExecContext(ctx, query, args...)
+		return err
+	}
+
+	tx, err := r.Db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package block
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/base"
+	"context"
+	"database/sql"
+)
+
+type Interface interface {
+	base.BaseInterface[models.BlockInput, models.Block, filter.BlockFilter]
+	Block(ctx context.Context, input models.BlockInput) error
+	IsBlocked(ctx context.Context, userId, otherUserId int) (bool, error)
+}
+
+type blockRepository struct {
+	base.BaseRepository[models.BlockInput, models.Block, filter.BlockFilter]
+}
+type Param struct {
+	Db        *sql.DB
+	TableName string
+}
+
+func Init(param Param) Interface {
+	return &blockRepository{
+		BaseRepository: base.BaseRepository[models.BlockInput, models.Block, filter.BlockFilter]{
+			Db:        param.Db,
+			TableName: param.TableName,
+		},
+	}
+}
+
+// Block blocks the user, blocking an already blocked user again only refreshes the block.
+func (r *blockRepository) Block(ctx context.Context, input models.BlockInput) error {
+	_, err := r.Conn(ctx).ExecContext(ctx, Block,
+		input.UserId, input.BlockedUserId, input.CreatedAt, input.CreatedBy, input.UpdatedAt, input.UpdatedBy,
+	)
+	return err
+}
+
+// IsBlocked tells whether either user blocked the other one.
+func (r *blockRepository) IsBlocked(ctx context.Context, userId, otherUserId int) (bool, error) {
+	var count int
+
+	rowCount, err := r.Conn(ctx).QueryContext(ctx, IsBlocked, userId, otherUserId, otherUserId, userId)
+	if err != nil {
+		return false, err
+	}
+	defer rowCount.Close()
+	for rowCount.Next() {
+		if err := rowCount.Scan(&count); err != nil {
+			return false, err
+		}
+	}
+	return count > 0, nil
+}
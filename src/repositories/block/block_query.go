@@ -0,0 +1,25 @@
+package block
+
+const (
+	Block = `
+	INSERT INTO 
+		blocks (user_id, blocked_user_id, created_at, created_by) 
+	VALUES 
+		(?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE 
+		status = 1,
+		deleted_at = NULL,
+		deleted_by = NULL,
+		updated_at = ?,
+		updated_by = ?
+	`
+	IsBlocked = `
+	SELECT 
+		COUNT(*)
+	FROM 
+		blocks 
+	WHERE 
+		((user_id = ? AND blocked_user_id = ?) OR (user_id = ? AND blocked_user_id = ?)) 
+		AND status = 1
+	`
+)
@@ -0,0 +1,342 @@
+package block
+
+import (
+	"DatingApp/src/models"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreate(t *testing.T) {
+	query := regexp.QuoteMeta("INSERT INTO blocks () VALUES ()")
+
+	type args struct {
+		ctx    context.Context
+		models models.Query[models.BlockInput]
+	}
+	tests := []struct {
+		name        string
+		args        args
+		prepSqlMock func() (*sql.DB, error)
+		wantErr     bool
+	}{
+		{
+			name: "sql begin failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.BlockInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin().WillReturnError(err)
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql exec failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.BlockInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql no row affected",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.BlockInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(0))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql commit failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.BlockInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectCommit().WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql commit success",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.BlockInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectCommit()
+				return sqlServer, err
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "blocks",
+			})
+			err = init.Create(tt.args.ctx, tt.args.models)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("blocks.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	query := regexp.QuoteMeta("UPDATE blocks SET  WHERE ")
+
+	type args struct {
+		ctx    context.Context
+		models models.Query[models.BlockInput]
+		id     int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		prepSqlMock func() (*sql.DB, error)
+		wantErr     bool
+	}{
+		{
+			name: "sql begin failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.BlockInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin().WillReturnError(err)
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql exec failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.BlockInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql no row affected",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.BlockInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(0))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql commit failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.BlockInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectCommit().WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql commit success",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.BlockInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectCommit()
+				return sqlServer, err
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "blocks",
+			})
+			err = init.Update(tt.args.ctx, tt.args.models, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("blocks.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBlock(t *testing.T) {
+	query := regexp.QuoteMeta(Block)
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	input := models.BlockInput{
+		UserId:        1,
+		BlockedUserId: 2,
+		CreatedAt:     mockTime,
+		CreatedBy:     1,
+		UpdatedAt:     mockTime,
+		UpdatedBy:     1,
+	}
+
+	tests := []struct {
+		name        string
+		prepSqlMock func() (*sql.DB, error)
+		wantErr     bool
+	}{
+		{
+			name: "sql exec failed",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(query).WithArgs(1, 2, mockTime, 1, mockTime, 1).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql exec success",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(query).WithArgs(1, 2, mockTime, 1, mockTime, 1).WillReturnResult(driver.RowsAffected(1))
+				return sqlServer, err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "blocks",
+			})
+			err = init.Block(context.Background(), input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("blocks.Block() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsBlocked(t *testing.T) {
+	query := regexp.QuoteMeta(IsBlocked)
+
+	tests := []struct {
+		name        string
+		prepSqlMock func() (*sql.DB, error)
+		want        bool
+		wantErr     bool
+	}{
+		{
+			name: "sql query failed",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectQuery(query).WithArgs(1, 2, 2, 1).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql not blocked",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				rowCount := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(0)
+				sqlMock.ExpectQuery(query).WithArgs(1, 2, 2, 1).WillReturnRows(rowCount)
+				return sqlServer, err
+			},
+			want: false,
+		},
+		{
+			name: "sql blocked",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				rowCount := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(1)
+				sqlMock.ExpectQuery(query).WithArgs(1, 2, 2, 1).WillReturnRows(rowCount)
+				return sqlServer, err
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "blocks",
+			})
+			isBlocked, err := init.IsBlocked(context.Background(), 1, 2)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("blocks.IsBlocked() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, isBlocked)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package boost
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/base"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type Interface interface {
+	base.BaseInterface[models.BoostInput, models.Boost, filter.BoostFilter]
+	CreateBoost(ctx context.Context, input models.Query[models.BoostInput]) (int, error)
+	HasActive(ctx context.Context, userId int, now time.Time) (bool, error)
+	CountStarted(ctx context.Context, userId int, since time.Time) (int, error)
+	AddImpressions(ctx context.Context, userIds []int64, now time.Time) error
+	CountLikesGained(ctx context.Context, userId int, from, to time.Time) (models.LikesGained, error)
+}
+
+type boostRepository struct {
+	base.BaseRepository[models.BoostInput, models.Boost, filter.BoostFilter]
+}
+type Param struct {
+	Db        *sql.DB
+	TableName string
+}
+
+func Init(param Param) Interface {
+	return &boostRepository{
+		BaseRepository: base.BaseRepository[models.BoostInput, models.Boost, filter.BoostFilter]{
+			Db:        param.Db,
+			TableName: param.TableName,
+		},
+	}
+}
+
+// CreateBoost is like Create but returns the id of the new boost.
+func (r *boostRepository) CreateBoost(ctx context.Context, input models.Query[models.BoostInput]) (int, error) {
+	createQuery, args := input.BuildCreateQuery()
+
+	result, err := r.Conn(ctx).ExecContext(ctx, base.Create+r.TableName+createQuery, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// HasActive tells whether the user has a boost running at now.
+func (r *boostRepository) HasActive(ctx context.Context, userId int, now time.Time) (bool, error) {
+	var count int
+
+	rowCount, err := r.Conn(ctx).QueryContext(ctx, HasActive, userId, now, now)
+	if err != nil {
+		return false, err
+	}
+	defer rowCount.Close()
+	for rowCount.Next() {
+		if err := rowCount.Scan(&count); err != nil {
+			return false, err
+		}
+	}
+	return count > 0, nil
+}
+
+// AddImpressions counts one more impression on the running boost of each user.
+func (r *boostRepository) AddImpressions(ctx context.Context, userIds []int64, now time.Time) error {
+	if len(userIds) == 0 {
+		return nil
+	}
+
+	args := []interface{}{}
+	for _, userId := range userIds {
+		args = append(args, userId)
+	}
+	args = append(args, now, now)
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(userIds)), ", ")
+
+	_, err := r.Conn(ctx).ExecContext(ctx, fmt.Sprintf(AddImpressions, placeholders), args...)
+	return err
+}
+
+// CountLikesGained counts the likes and super likes the user got from from until to,
+// rewound ones don't count.
+func (r *boostRepository) CountLikesGained(ctx context.Context, userId int, from, to time.Time) (models.LikesGained, error) {
+	var result models.LikesGained
+
+	rows, err := r.Conn(ctx).QueryContext(ctx, CountLikesGained, userId, from, to)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := rows.Scan(&result.Likes, &result.SuperLikes); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// CountStarted counts the boosts the user started since, deleted ones included so they still count.
+func (r *boostRepository) CountStarted(ctx context.Context, userId int, since time.Time) (int, error) {
+	var count int
+
+	rowCount, err := r.Conn(ctx).QueryContext(ctx, CountStarted, userId, since)
+	if err != nil {
+		return 0, err
+	}
+	defer rowCount.Close()
+	for rowCount.Next() {
+		if err := rowCount.Scan(&count); err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
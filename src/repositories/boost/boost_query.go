@@ -0,0 +1,47 @@
+package boost
+
+const (
+	HasActive = `
+	SELECT 
+		COUNT(*)
+	FROM 
+		boosts 
+	WHERE 
+		user_id = ? 
+		AND started_at <= ? 
+		AND ends_at > ? 
+		AND status = 1
+	`
+	AddImpressions = `
+	UPDATE 
+		boosts 
+	SET 
+		impressions = impressions + 1 
+	WHERE 
+		user_id IN (%s) 
+		AND started_at <= ? 
+		AND ends_at > ? 
+		AND status = 1
+	`
+	CountLikesGained = `
+	SELECT 
+		COALESCE(SUM(activity = 'like'), 0) AS likes, 
+		COALESCE(SUM(activity = 'superlike'), 0) AS super_likes
+	FROM 
+		user_activities 
+	WHERE 
+		liked_user_id = ? 
+		AND created_at >= ? 
+		AND created_at < ? 
+		AND status = 1
+	`
+	CountStarted = `
+	SELECT 
+		COUNT(*)
+	FROM 
+		boosts 
+	WHERE 
+		user_id = ? 
+		AND started_at >= ?
+	`
+)
@@ -0,0 +1,501 @@
+package boost
+
+import (
+	"DatingApp/src/models"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreate(t *testing.T) {
+	query := regexp.QuoteMeta("INSERT INTO boosts () VALUES ()")
+
+	type args struct {
+		ctx    context.Context
+		models models.Query[models.BoostInput]
+	}
+	tests := []struct {
+		name        string
+		args        args
+		prepSqlMock func() (*sql.DB, error)
+		wantErr     bool
+	}{
+		{
+			name: "sql begin failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.BoostInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin().WillReturnError(err)
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql exec failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.BoostInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql no row affected",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.BoostInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(0))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql commit failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.BoostInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectCommit().WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql commit success",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.BoostInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectCommit()
+				return sqlServer, err
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "boosts",
+			})
+			err = init.Create(tt.args.ctx, tt.args.models)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("boosts.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	query := regexp.QuoteMeta("UPDATE boosts SET  WHERE ")
+
+	type args struct {
+		ctx    context.Context
+		models models.Query[models.BoostInput]
+		id     int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		prepSqlMock func() (*sql.DB, error)
+		wantErr     bool
+	}{
+		{
+			name: "sql begin failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.BoostInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin().WillReturnError(err)
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql exec failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.BoostInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql no row affected",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.BoostInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(0))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql commit failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.BoostInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectCommit().WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql commit success",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.BoostInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectCommit()
+				return sqlServer, err
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "boosts",
+			})
+			err = init.Update(tt.args.ctx, tt.args.models, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("boosts.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateBoost(t *testing.T) {
+	query := regexp.QuoteMeta("INSERT INTO boosts (user_id) VALUES (?)")
+
+	tests := []struct {
+		name        string
+		prepSqlMock func() (*sql.DB, error)
+		want        int
+		wantErr     bool
+	}{
+		{
+			name: "sql exec failed",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql exec success",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(sqlmock.NewResult(5, 1))
+				return sqlServer, err
+			},
+			want: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "boosts",
+			})
+			id, err := init.CreateBoost(context.Background(), models.Query[models.BoostInput]{
+				Model: models.BoostInput{UserId: 1},
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("boost.CreateBoost() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, id)
+		})
+	}
+}
+
+func TestHasActive(t *testing.T) {
+	query := regexp.QuoteMeta(HasActive)
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		prepSqlMock func() (*sql.DB, error)
+		want        bool
+		wantErr     bool
+	}{
+		{
+			name: "sql query failed",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectQuery(query).WithArgs(1, mockTime, mockTime).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "no active boost",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				rowCount := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(0)
+				sqlMock.ExpectQuery(query).WithArgs(1, mockTime, mockTime).WillReturnRows(rowCount)
+				return sqlServer, err
+			},
+			want: false,
+		},
+		{
+			name: "active boost",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				rowCount := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(1)
+				sqlMock.ExpectQuery(query).WithArgs(1, mockTime, mockTime).WillReturnRows(rowCount)
+				return sqlServer, err
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "boosts",
+			})
+			active, err := init.HasActive(context.Background(), 1, mockTime)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("boost.HasActive() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, active)
+		})
+	}
+}
+
+func TestAddImpressions(t *testing.T) {
+	query := regexp.QuoteMeta(fmt.Sprintf(AddImpressions, "?, ?"))
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		userIds     []int64
+		prepSqlMock func() (*sql.DB, error)
+		wantErr     bool
+	}{
+		{
+			name:    "no user",
+			userIds: []int64{},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, _, err := sqlmock.New()
+				return sqlServer, err
+			},
+		},
+		{
+			name:    "sql exec failed",
+			userIds: []int64{2, 3},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(query).WithArgs(2, 3, mockTime, mockTime).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name:    "sql exec success",
+			userIds: []int64{2, 3},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(query).WithArgs(2, 3, mockTime, mockTime).WillReturnResult(driver.RowsAffected(1))
+				return sqlServer, err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "boosts",
+			})
+			if err := init.AddImpressions(context.Background(), tt.userIds, mockTime); (err != nil) != tt.wantErr {
+				t.Errorf("boost.AddImpressions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCountLikesGained(t *testing.T) {
+	query := regexp.QuoteMeta(CountLikesGained)
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	mockEnd := mockTime.Add(models.BoostDuration)
+
+	tests := []struct {
+		name        string
+		prepSqlMock func() (*sql.DB, error)
+		want        models.LikesGained
+		wantErr     bool
+	}{
+		{
+			name: "sql query failed",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectQuery(query).WithArgs(1, mockTime, mockEnd).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql query success",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				row := sqlMock.NewRows([]string{"likes", "super_likes"}).AddRow(4, 1)
+				sqlMock.ExpectQuery(query).WithArgs(1, mockTime, mockEnd).WillReturnRows(row)
+				return sqlServer, err
+			},
+			want: models.LikesGained{Likes: 4, SuperLikes: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "boosts",
+			})
+			likes, err := init.CountLikesGained(context.Background(), 1, mockTime, mockEnd)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("boost.CountLikesGained() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, likes)
+		})
+	}
+}
+
+func TestCountStarted(t *testing.T) {
+	query := regexp.QuoteMeta(CountStarted)
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		prepSqlMock func() (*sql.DB, error)
+		want        int
+		wantErr     bool
+	}{
+		{
+			name: "sql query failed",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectQuery(query).WithArgs(1, mockTime).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql query success",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				rowCount := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(2)
+				sqlMock.ExpectQuery(query).WithArgs(1, mockTime).WillReturnRows(rowCount)
+				return sqlServer, err
+			},
+			want: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "boosts",
+			})
+			count, err := init.CountStarted(context.Background(), 1, mockTime)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("boost.CountStarted() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, count)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package broker
+
+import (
+	"DatingApp/src/models"
+	"context"
+	"sync"
+)
+
+const (
+	subscriberBuffer = 16
+)
+
+// Interface fans out events to the subscribers of a user. The memory implementation only
+// reaches subscribers of the same process, other implementations can be plugged in Init.
+type Interface interface {
+	Publish(ctx context.Context, event models.Event) error
+	Subscribe(userId int) (<-chan models.Event, func())
+}
+
+type memoryBroker struct {
+	mu          sync.RWMutex
+	subscribers map[int]map[chan models.Event]struct{}
+}
+
+func Init() Interface {
+	return &memoryBroker{
+		subscribers: map[int]map[chan models.Event]struct{}{},
+	}
+}
+
+func (b *memoryBroker) Publish(ctx context.Context, event models.Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for subscriber := range b.subscribers[event.UserId] {
+		select {
+		case subscriber <- event:
+		default:
+			// slow subscriber, drop the event instead of blocking the publisher
+		}
+	}
+	return nil
+}
+
+func (b *memoryBroker) Subscribe(userId int) (<-chan models.Event, func()) {
+	subscriber := make(chan models.Event, subscriberBuffer)
+
+	b.mu.Lock()
+	if _, ok := b.subscribers[userId]; !ok {
+		b.subscribers[userId] = map[chan models.Event]struct{}{}
+	}
+	b.subscribers[userId][subscriber] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subscribers[userId], subscriber)
+			if len(b.subscribers[userId]) == 0 {
+				delete(b.subscribers, userId)
+			}
+			close(subscriber)
+		})
+	}
+	return subscriber, unsubscribe
+}
@@ -0,0 +1,43 @@
+package broker
+
+import (
+	"DatingApp/src/models"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublish(t *testing.T) {
+	broker := Init()
+
+	events, unsubscribe := broker.Subscribe(1)
+	otherEvents, otherUnsubscribe := broker.Subscribe(2)
+	defer otherUnsubscribe()
+
+	err := broker.Publish(context.Background(), models.Event{UserId: 1, Type: models.EventNewMatch})
+	assert.NoError(t, err)
+
+	assert.Equal(t, models.Event{UserId: 1, Type: models.EventNewMatch}, <-events)
+	assert.Len(t, otherEvents, 0)
+
+	unsubscribe()
+	_, ok := <-events
+	assert.False(t, ok)
+
+	// publishing without subscriber and unsubscribing twice must not panic
+	assert.NoError(t, broker.Publish(context.Background(), models.Event{UserId: 1}))
+	unsubscribe()
+}
+
+func TestPublishSlowSubscriber(t *testing.T) {
+	broker := Init()
+
+	events, unsubscribe := broker.Subscribe(1)
+	defer unsubscribe()
+
+	for i := 0; i < subscriberBuffer+5; i++ {
+		assert.NoError(t, broker.Publish(context.Background(), models.Event{UserId: 1}))
+	}
+	assert.Len(t, events, subscriberBuffer)
+}
@@ -0,0 +1,74 @@
+package deck
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/base"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Interface keeps which candidates were already handed out to a user so decks don't repeat cards.
+type Interface interface {
+	base.BaseInterface[models.DeckCardInput, models.DeckCard, filter.DeckCardFilter]
+	Lock(ctx context.Context, userId int) error
+	AddCards(ctx context.Context, userId int, candidateIds []int64, expiresAt time.Time) error
+	ClearExpired(ctx context.Context, userId int, now time.Time) error
+	Clear(ctx context.Context, userId int) error
+}
+
+type deckRepository struct {
+	base.BaseRepository[models.DeckCardInput, models.DeckCard, filter.DeckCardFilter]
+}
+type Param struct {
+	Db        *sql.DB
+	TableName string
+}
+
+func Init(param Param) Interface {
+	return &deckRepository{
+		BaseRepository: base.BaseRepository[models.DeckCardInput, models.DeckCard, filter.DeckCardFilter]{
+			Db:        param.Db,
+			TableName: param.TableName,
+		},
+	}
+}
+
+// Lock holds the row of the user until the transaction in ctx ends, so two decks of the same user
+// are built one after the other and can't hand out the same cards.
+func (r *deckRepository) Lock(ctx context.Context, userId int) error {
+	rows, err := r.Conn(ctx).QueryContext(ctx, Lock, userId)
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+func (r *deckRepository) AddCards(ctx context.Context, userId int, candidateIds []int64, expiresAt time.Time) error {
+	if len(candidateIds) == 0 {
+		return nil
+	}
+
+	args := []interface{}{}
+	for _, candidateId := range candidateIds {
+		args = append(args, userId, candidateId, expiresAt)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("(?, ?, ?), ", len(candidateIds)), ", ")
+
+	_, err := r.Conn(ctx).ExecContext(ctx, fmt.Sprintf(AddCards, placeholders), args...)
+	return err
+}
+
+func (r *deckRepository) ClearExpired(ctx context.Context, userId int, now time.Time) error {
+	_, err := r.Conn(ctx).ExecContext(ctx, ClearExpired, userId, now)
+	return err
+}
+
+// Clear drops every card of the user, the next deck starts over.
+func (r *deckRepository) Clear(ctx context.Context, userId int) error {
+	_, err := r.Conn(ctx).ExecContext(ctx, Clear, userId)
+	return err
+}
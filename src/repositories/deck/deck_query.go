@@ -0,0 +1,33 @@
+package deck
+
+const (
+	Lock = `
+	SELECT 
+		id 
+	FROM 
+		users 
+	WHERE 
+		id = ? 
+	FOR UPDATE
+	`
+	AddCards = `
+	INSERT INTO 
+		recomendation_deck_cards (user_id, candidate_id, expires_at) 
+	VALUES %s
+	ON DUPLICATE KEY UPDATE 
+		expires_at = VALUES(expires_at)
+	`
+	ClearExpired = `
+	DELETE FROM 
+		recomendation_deck_cards 
+	WHERE 
+		user_id = ? 
+		AND expires_at <= ?
+	`
+	Clear = `
+	DELETE FROM 
+		recomendation_deck_cards 
+	WHERE 
+		user_id = ?
+	`
+)
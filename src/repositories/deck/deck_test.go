@@ -0,0 +1,376 @@
+package deck
+
+import (
+	"DatingApp/src/models"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestCreate(t *testing.T) {
+	query := regexp.QuoteMeta("INSERT INTO recomendation_deck_cards () VALUES ()")
+
+	type args struct {
+		ctx    context.Context
+		models models.Query[models.DeckCardInput]
+	}
+	tests := []struct {
+		name        string
+		args        args
+		prepSqlMock func() (*sql.DB, error)
+		wantErr     bool
+	}{
+		{
+			name: "sql begin failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.DeckCardInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin().WillReturnError(err)
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql exec failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.DeckCardInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql no row affected",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.DeckCardInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(0))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql commit failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.DeckCardInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectCommit().WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql commit success",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.DeckCardInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectCommit()
+				return sqlServer, err
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "recomendation_deck_cards",
+			})
+			err = init.Create(tt.args.ctx, tt.args.models)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("recomendation_deck_cards.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	query := regexp.QuoteMeta("UPDATE recomendation_deck_cards SET  WHERE ")
+
+	type args struct {
+		ctx    context.Context
+		models models.Query[models.DeckCardInput]
+		id     int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		prepSqlMock func() (*sql.DB, error)
+		wantErr     bool
+	}{
+		{
+			name: "sql begin failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.DeckCardInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin().WillReturnError(err)
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql exec failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.DeckCardInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql no row affected",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.DeckCardInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(0))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql commit failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.DeckCardInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectCommit().WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql commit success",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.DeckCardInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectCommit()
+				return sqlServer, err
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "recomendation_deck_cards",
+			})
+			err = init.Update(tt.args.ctx, tt.args.models, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("recomendation_deck_cards.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLock(t *testing.T) {
+	query := regexp.QuoteMeta(Lock)
+
+	tests := []struct {
+		name        string
+		prepSqlMock func() (*sql.DB, error)
+		wantErr     bool
+	}{
+		{
+			name: "sql query failed",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectQuery(query).WithArgs(1).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql query success",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				return sqlServer, err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "recomendation_deck_cards",
+			})
+			err = init.Lock(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("recomendation_deck_cards.Lock() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAddCards(t *testing.T) {
+	query := regexp.QuoteMeta(fmt.Sprintf(AddCards, "(?, ?, ?), (?, ?, ?)"))
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		candidateIds []int64
+		prepSqlMock  func() (*sql.DB, error)
+		wantErr      bool
+	}{
+		{
+			name:         "no cards",
+			candidateIds: []int64{},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, _, err := sqlmock.New()
+				return sqlServer, err
+			},
+		},
+		{
+			name:         "sql exec failed",
+			candidateIds: []int64{2, 3},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(query).WithArgs(1, 2, mockTime, 1, 3, mockTime).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name:         "sql exec success",
+			candidateIds: []int64{2, 3},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(query).WithArgs(1, 2, mockTime, 1, 3, mockTime).WillReturnResult(driver.RowsAffected(2))
+				return sqlServer, err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "recomendation_deck_cards",
+			})
+			err = init.AddCards(context.Background(), 1, tt.candidateIds, mockTime)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("recomendation_deck_cards.AddCards() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClear(t *testing.T) {
+	query := regexp.QuoteMeta(Clear)
+
+	tests := []struct {
+		name        string
+		prepSqlMock func() (*sql.DB, error)
+		wantErr     bool
+	}{
+		{
+			name: "sql exec failed",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql exec success",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(3))
+				return sqlServer, err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "recomendation_deck_cards",
+			})
+			err = init.Clear(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("recomendation_deck_cards.Clear() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package entitlement
+
+import (
+	"DatingApp/src/repositories/base"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Interface looks up which premium features a user is entitled to,
+// every active subscription not expired at now is one entitlement.
+type Interface interface {
+	HasFeature(ctx context.Context, userId int, flag string, now time.Time) (bool, error)
+	GetFlags(ctx context.Context, userIds []int, now time.Time) (map[int][]string, error)
+}
+
+type entitlementRepository struct {
+	Db *sql.DB
+}
+
+type Param struct {
+	Db *sql.DB
+}
+
+func Init(param Param) Interface {
+	return &entitlementRepository{
+		Db: param.Db,
+	}
+}
+
+// Conn returns the transaction carried by ctx, or the db when there is none.
+func (r *entitlementRepository) Conn(ctx context.Context) base.Executor {
+	return base.Conn(ctx, r.Db)
+}
+
+func (r *entitlementRepository) HasFeature(ctx context.Context, userId int, flag string, now time.Time) (bool, error) {
+	count := 0
+
+	rows, err := r.Conn(ctx).QueryContext(ctx, HasFeature, userId, flag, now)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return false, err
+		}
+	}
+	return count > 0, nil
+}
+
+// GetFlags returns the active feature flags of each given user, users without any are left out.
+func (r *entitlementRepository) GetFlags(ctx context.Context, userIds []int, now time.Time) (map[int][]string, error) {
+	result := map[int][]string{}
+	if len(userIds) == 0 {
+		return result, nil
+	}
+
+	args := []interface{}{}
+	for _, id := range userIds {
+		args = append(args, id)
+	}
+	args = append(args, now)
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(userIds)), ", ")
+
+	rows, err := r.Conn(ctx).QueryContext(ctx, fmt.Sprintf(GetFlags, placeholders), args...)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			userId int
+			flag   string
+		)
+		if err := rows.Scan(&userId, &flag); err != nil {
+			return result, err
+		}
+		result[userId] = append(result[userId], flag)
+	}
+	return result, nil
+}
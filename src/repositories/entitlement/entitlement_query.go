@@ -0,0 +1,30 @@
+package entitlement
+
+const (
+	HasFeature = `
+	SELECT 
+		COUNT(*)
+	FROM 
+		subscriptions s
+		JOIN premium_features pf ON pf.id = s.premium_feature_id
+	WHERE 
+		s.user_id = ? 
+		AND pf.flag = ? 
+		AND s.expires_at > ?
+		AND s.status = 1 
+		AND pf.status = 1
+	`
+	GetFlags = `
+	SELECT DISTINCT
+		s.user_id, pf.flag
+	FROM 
+		subscriptions s
+		JOIN premium_features pf ON pf.id = s.premium_feature_id
+	WHERE 
+		s.user_id IN (%s) 
+		AND s.expires_at > ?
+		AND s.status = 1 
+		AND pf.status = 1
+	ORDER BY s.user_id, pf.flag
+	`
+)
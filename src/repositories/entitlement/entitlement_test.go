@@ -0,0 +1,138 @@
+package entitlement
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasFeature(t *testing.T) {
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta(HasFeature)
+
+	tests := []struct {
+		name        string
+		prepSqlMock func() (*sql.DB, error)
+		want        bool
+		wantErr     bool
+	}{
+		{
+			name: "sql query failed",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectQuery(query).WithArgs(1, "verified", mockTime).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "feature not owned",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				row := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(0)
+				sqlMock.ExpectQuery(query).WithArgs(1, "verified", mockTime).WillReturnRows(row)
+				return sqlServer, err
+			},
+		},
+		{
+			name: "feature owned",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				row := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(1)
+				sqlMock.ExpectQuery(query).WithArgs(1, "verified", mockTime).WillReturnRows(row)
+				return sqlServer, err
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db: sqlServer,
+			})
+			hasFeature, err := init.HasFeature(context.Background(), 1, "verified", mockTime)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("entitlement.HasFeature() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, hasFeature)
+		})
+	}
+}
+
+func TestGetFlags(t *testing.T) {
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta(fmt.Sprintf(GetFlags, "?, ?"))
+
+	tests := []struct {
+		name        string
+		userIds     []int
+		prepSqlMock func() (*sql.DB, error)
+		want        map[int][]string
+		wantErr     bool
+	}{
+		{
+			name:    "no user",
+			userIds: []int{},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, _, err := sqlmock.New()
+				return sqlServer, err
+			},
+			want: map[int][]string{},
+		},
+		{
+			name:    "sql query failed",
+			userIds: []int{1, 2},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectQuery(query).WithArgs(1, 2, mockTime).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			want:    map[int][]string{},
+			wantErr: true,
+		},
+		{
+			name:    "sql success",
+			userIds: []int{1, 2},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				row := sqlMock.NewRows([]string{"user_id", "flag"}).
+					AddRow(1, "no-swipe-quota-limit").
+					AddRow(1, "verified")
+				sqlMock.ExpectQuery(query).WithArgs(1, 2, mockTime).WillReturnRows(row)
+				return sqlServer, err
+			},
+			want: map[int][]string{1: {"no-swipe-quota-limit", "verified"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db: sqlServer,
+			})
+			flags, err := init.GetFlags(context.Background(), tt.userIds, mockTime)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("entitlement.GetFlags() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, flags)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package mailer
+
+import (
+	"DatingApp/src/models"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// DriverFile is the mailer writing the mails to a file, the only one so far and meant for development.
+const DriverFile = "file"
+
+var ErrNoMailer = errors.New("no mailer configured, set MAILER")
+
+// Interface sends mails to the users. The file implementation is meant for development,
+// an SMTP or provider backed one can be added to Init under its own driver.
+type Interface interface {
+	Send(ctx context.Context, mail models.Mail) error
+}
+
+type fileMailer struct {
+	mu   sync.Mutex
+	path string
+}
+
+type Param struct {
+	Driver string
+	Path   string
+}
+
+// Init returns the mailer of Driver. There is no default since the mails carry reset links,
+// a server without a mailer set on purpose refuses to start instead of leaking them somewhere.
+func Init(param Param) (Interface, error) {
+	switch param.Driver {
+	case DriverFile:
+		if param.Path == "" {
+			return nil, errors.New("the file mailer needs MAIL_FILE")
+		}
+		return &fileMailer{
+			path: param.Path,
+		}, nil
+	case "":
+		return nil, ErrNoMailer
+	default:
+		return nil, fmt.Errorf("unknown mailer %q", param.Driver)
+	}
+}
+
+// Send appends the mail to the file, it's meant to be read by a developer and never shipped.
+func (m *fileMailer) Send(ctx context.Context, mail models.Mail) error {
+	content := fmt.Sprintf("Date: %s\nTo: %s\nSubject: %s\n\n%s\n\n", time.Now().Format(time.RFC1123Z), mail.To, mail.Subject, mail.Body)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// the mails hold reset links, keep the file readable by the owner only
+	file, err := os.OpenFile(m.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,54 @@
+package mailer
+
+import (
+	"DatingApp/src/models"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.log")
+	mailer, err := Init(Param{Driver: DriverFile, Path: path})
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	assert.NoError(t, mailer.Send(ctx, models.Mail{To: "a@mail.com", Subject: "first", Body: "hello a"}))
+	assert.NoError(t, mailer.Send(ctx, models.Mail{To: "b@mail.com", Subject: "second", Body: "hello b"}))
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	content := string(data)
+	assert.Contains(t, content, "To: a@mail.com\nSubject: first\n\nhello a\n")
+	assert.Contains(t, content, "To: b@mail.com\nSubject: second\n\nhello b\n")
+	assert.Less(t, strings.Index(content, "first"), strings.Index(content, "second"))
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestInit(t *testing.T) {
+	_, err := Init(Param{})
+	assert.ErrorIs(t, err, ErrNoMailer)
+
+	_, err = Init(Param{Path: "mail.log"})
+	assert.ErrorIs(t, err, ErrNoMailer)
+
+	_, err = Init(Param{Driver: DriverFile})
+	assert.Error(t, err)
+
+	_, err = Init(Param{Driver: "smtp", Path: "mail.log"})
+	assert.Error(t, err)
+}
+
+func TestSendError(t *testing.T) {
+	mailer, err := Init(Param{Driver: DriverFile, Path: filepath.Join(t.TempDir(), "missing", "mail.log")})
+	assert.NoError(t, err)
+
+	assert.Error(t, mailer.Send(context.Background(), models.Mail{To: "a@mail.com"}))
+}
@@ -0,0 +1,100 @@
+package match
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/base"
+	"context"
+	"database/sql"
+	"reflect"
+	"time"
+)
+
+type Interface interface {
+	base.BaseInterface[models.MatchInput, models.Match, filter.MatchFilter]
+	GetUserMatches(ctx context.Context, userId int, paging filter.Paging[filter.MatchFilter]) ([]models.MatchedUser, int, error)
+	Unmatch(ctx context.Context, userId, otherUserId int, deletedAt time.Time, deletedBy int64) error
+	CreateMatch(ctx context.Context, input models.MatchInput) (bool, error)
+}
+
+type matchRepository struct {
+	base.BaseRepository[models.MatchInput, models.Match, filter.MatchFilter]
+}
+type Param struct {
+	Db        *sql.DB
+	TableName string
+}
+
+func Init(param Param) Interface {
+	return &matchRepository{
+		BaseRepository: base.BaseRepository[models.MatchInput, models.Match, filter.MatchFilter]{
+			Db:        param.Db,
+			TableName: param.TableName,
+		},
+	}
+}
+
+func (r *matchRepository) GetUserMatches(ctx context.Context, userId int, paging filter.Paging[filter.MatchFilter]) ([]models.MatchedUser, int, error) {
+	var (
+		result = []models.MatchedUser{}
+		count  int
+	)
+
+	// matches are always listed newest first
+	paging.OrderBy = ""
+	pagination := paging.PaginationQuery()
+
+	rowCount, err := r.Conn(ctx).QueryContext(ctx, CountUserMatches, userId, userId, userId)
+	if err != nil {
+		return result, count, err
+	}
+	defer rowCount.Close()
+	for rowCount.Next() {
+		if err := rowCount.Scan(&count); err != nil {
+			return result, count, err
+		}
+	}
+
+	rows, err := r.Conn(ctx).QueryContext(ctx, GetUserMatches+pagination, userId, userId, userId)
+	if err != nil {
+		return result, count, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var model models.MatchedUser
+
+		s := reflect.ValueOf(&model).Elem()
+		numCols := s.NumField()
+		columns := make([]interface{}, numCols)
+		for i := 0; i < numCols; i++ {
+			field := s.Field(i)
+			columns[i] = field.Addr().Interface()
+		}
+
+		if err := rows.Scan(columns...); err != nil {
+			return result, count, err
+		}
+		result = append(result, model)
+	}
+	return result, count, nil
+}
+
+// Unmatch soft deletes the active match between both users whoever is stored first.
+func (r *matchRepository) Unmatch(ctx context.Context, userId, otherUserId int, deletedAt time.Time, deletedBy int64) error {
+	_, err := r.Conn(ctx).ExecContext(ctx, Unmatch, deletedAt, deletedBy, userId, otherUserId, otherUserId, userId)
+	return err
+}
+
+// CreateMatch inserts the match unless the pair already has an active one, the unique key on the pair
+// makes this hold when both users like each other at the same time. It returns true only when it inserted.
+func (r *matchRepository) CreateMatch(ctx context.Context, input models.MatchInput) (bool, error) {
+	result, err := r.Conn(ctx).ExecContext(ctx, CreateMatch, input.UserId, input.MatchedUserId, input.CreatedAt, input.CreatedBy)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
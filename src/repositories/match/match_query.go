@@ -0,0 +1,42 @@
+package match
+
+const (
+	GetUserMatches = `
+	SELECT 
+		m.id AS match_id, u.id, u.user_name, u.image, m.created_at AS matched_at
+	FROM 
+		matches m
+		JOIN users u ON u.id = IF(m.user_id = ?, m.matched_user_id, m.user_id)
+	WHERE 
+		(m.user_id = ? OR m.matched_user_id = ?) 
+		AND m.status = 1 
+		AND u.status = 1
+	ORDER BY m.created_at DESC
+	`
+	CountUserMatches = `
+	SELECT 
+		COUNT(*)
+	FROM 
+		matches m
+		JOIN users u ON u.id = IF(m.user_id = ?, m.matched_user_id, m.user_id)
+	WHERE 
+		(m.user_id = ? OR m.matched_user_id = ?) 
+		AND m.status = 1 
+		AND u.status = 1
+	`
+	Unmatch = `
+	UPDATE 
+		matches 
+	SET 
+		status = -1, deleted_at = ?, deleted_by = ?
+	WHERE 
+		((user_id = ? AND matched_user_id = ?) OR (user_id = ? AND matched_user_id = ?)) 
+		AND status = 1
+	`
+	CreateMatch = `
+	INSERT IGNORE INTO 
+		matches (user_id, matched_user_id, created_at, created_by)
+	VALUES 
+		(?, ?, ?, ?)
+	`
+)
@@ -0,0 +1,551 @@
+package match
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/formatter"
+	"DatingApp/src/models"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreate(t *testing.T) {
+	query := regexp.QuoteMeta("INSERT INTO match () VALUES ()")
+
+	type args struct {
+		ctx    context.Context
+		models models.Query[models.MatchInput]
+	}
+	tests := []struct {
+		name        string
+		args        args
+		prepSqlMock func() (*sql.DB, error)
+		wantErr     bool
+	}{
+		{
+			name: "sql begin failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.MatchInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin().WillReturnError(err)
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql exec failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.MatchInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql no row affected",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.MatchInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(0))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql commit failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.MatchInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectCommit().WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql commit success",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.MatchInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectCommit()
+				return sqlServer, err
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "match",
+			})
+			err = init.Create(tt.args.ctx, tt.args.models)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("match.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	query := regexp.QuoteMeta("UPDATE match SET  WHERE ")
+
+	type args struct {
+		ctx    context.Context
+		models models.Query[models.MatchInput]
+		id     int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		prepSqlMock func() (*sql.DB, error)
+		wantErr     bool
+	}{
+		{
+			name: "sql begin failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.MatchInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin().WillReturnError(err)
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql exec failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.MatchInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql no row affected",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.MatchInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(0))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql commit failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.MatchInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectCommit().WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql commit success",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.MatchInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectCommit()
+				return sqlServer, err
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "match",
+			})
+			err = init.Update(tt.args.ctx, tt.args.models, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("match.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	tempModels := models.Query[models.Match]{}
+	member := tempModels.BuildTableMember()
+	query := regexp.QuoteMeta("SELECT " + member + " FROM match WHERE 1=1")
+	queryCount := regexp.QuoteMeta("SELECT COUNT(*) FROM match")
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		ctx    context.Context
+		models filter.Paging[filter.MatchFilter]
+	}
+	tests := []struct {
+		name        string
+		args        args
+		prepSqlMock func() (*sql.DB, error)
+		wantMatch   []models.Match
+		wantCount   int
+		wantErr     bool
+	}{
+		{
+			name: "sql count query failed",
+			args: args{
+				ctx:    context.Background(),
+				models: filter.Paging[filter.MatchFilter]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectQuery(queryCount).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantMatch: []models.Match{},
+			wantErr:   true,
+		},
+		{
+			name: "sql query failed",
+			args: args{
+				ctx:    context.Background(),
+				models: filter.Paging[filter.MatchFilter]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				rowCount := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(1)
+				sqlMock.ExpectQuery(queryCount).WillReturnRows(rowCount)
+				sqlMock.ExpectQuery(query).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr:   true,
+			wantMatch: []models.Match{},
+			wantCount: 1,
+		},
+		{
+			name: "sql success",
+			args: args{
+				ctx: context.Background(),
+				models: filter.Paging[filter.MatchFilter]{
+					Filter: filter.MatchFilter{UserId: 1, MatchedUserId: 2},
+				},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				rowCount := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(1)
+				sqlMock.ExpectQuery(queryCount).WithArgs(1, 2).WillReturnRows(rowCount)
+				row := sqlMock.NewRows([]string{"id", "user_id", "matched_user_id", "status", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by"})
+				row.AddRow(1, 1, 2, 1, formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}, 1, formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}, 1, formatter.NullableDataType[time.Time]{Valid: false}, formatter.NullableDataType[int64]{Valid: false})
+				sqlMock.ExpectQuery(query).WithArgs(1, 2).WillReturnRows(row)
+				return sqlServer, err
+			},
+			wantMatch: []models.Match{
+				{
+					Id:            1,
+					UserId:        1,
+					MatchedUserId: 2,
+					Status:        1,
+					CreatedAt: formatter.NullableDataType[time.Time]{
+						Data:  mockTime,
+						Valid: true,
+					},
+					UpdatedAt: formatter.NullableDataType[time.Time]{
+						Data:  mockTime,
+						Valid: true,
+					},
+					CreatedBy: formatter.NullableDataType[int64]{
+						Data:  1,
+						Valid: true,
+					},
+					UpdatedBy: formatter.NullableDataType[int64]{
+						Data:  1,
+						Valid: true,
+					},
+				},
+			},
+			wantCount: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "match",
+			})
+			matches, count, err := init.Get(tt.args.ctx, tt.args.models)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("match.Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantMatch, matches)
+			assert.Equal(t, tt.wantCount, count)
+		})
+	}
+}
+
+func TestGetUserMatches(t *testing.T) {
+	query := regexp.QuoteMeta(GetUserMatches + " LIMIT 10 OFFSET 0")
+	queryCount := regexp.QuoteMeta(CountUserMatches)
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	mockImage := "image"
+
+	type args struct {
+		ctx    context.Context
+		userId int
+		paging filter.Paging[filter.MatchFilter]
+	}
+	tests := []struct {
+		name        string
+		args        args
+		prepSqlMock func() (*sql.DB, error)
+		wantMatch   []models.MatchedUser
+		wantCount   int
+		wantErr     bool
+	}{
+		{
+			name: "sql count query failed",
+			args: args{
+				ctx:    context.Background(),
+				userId: 1,
+				paging: filter.Paging[filter.MatchFilter]{Page: 1, Take: 10},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectQuery(queryCount).WithArgs(1, 1, 1).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantMatch: []models.MatchedUser{},
+			wantErr:   true,
+		},
+		{
+			name: "sql query failed",
+			args: args{
+				ctx:    context.Background(),
+				userId: 1,
+				paging: filter.Paging[filter.MatchFilter]{Page: 1, Take: 10},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				rowCount := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(1)
+				sqlMock.ExpectQuery(queryCount).WithArgs(1, 1, 1).WillReturnRows(rowCount)
+				sqlMock.ExpectQuery(query).WithArgs(1, 1, 1).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantMatch: []models.MatchedUser{},
+			wantCount: 1,
+			wantErr:   true,
+		},
+		{
+			name: "sql success",
+			args: args{
+				ctx:    context.Background(),
+				userId: 1,
+				paging: filter.Paging[filter.MatchFilter]{Page: 1, Take: 10, OrderBy: "id"},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				rowCount := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(1)
+				sqlMock.ExpectQuery(queryCount).WithArgs(1, 1, 1).WillReturnRows(rowCount)
+				row := sqlMock.NewRows([]string{"match_id", "id", "user_name", "image", "matched_at"})
+				row.AddRow(3, 2, "test", mockImage, mockTime)
+				sqlMock.ExpectQuery(query).WithArgs(1, 1, 1).WillReturnRows(row)
+				return sqlServer, err
+			},
+			wantMatch: []models.MatchedUser{
+				{
+					MatchId:   3,
+					Id:        2,
+					UserName:  "test",
+					Image:     &mockImage,
+					MatchedAt: mockTime,
+				},
+			},
+			wantCount: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "match",
+			})
+			matches, count, err := init.GetUserMatches(tt.args.ctx, tt.args.userId, tt.args.paging)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("match.GetUserMatches() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantMatch, matches)
+			assert.Equal(t, tt.wantCount, count)
+		})
+	}
+}
+
+func TestUnmatch(t *testing.T) {
+	query := regexp.QuoteMeta(Unmatch)
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		prepSqlMock func() (*sql.DB, error)
+		wantErr     bool
+	}{
+		{
+			name: "sql exec failed",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(query).WithArgs(mockTime, 1, 1, 2, 2, 1).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql exec success",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(query).WithArgs(mockTime, 1, 1, 2, 2, 1).WillReturnResult(driver.RowsAffected(1))
+				return sqlServer, err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "matches",
+			})
+			err = init.Unmatch(context.Background(), 1, 2, mockTime, 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("match.Unmatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateMatch(t *testing.T) {
+	query := regexp.QuoteMeta(CreateMatch)
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	input := models.MatchInput{UserId: 1, MatchedUserId: 2, CreatedAt: mockTime, CreatedBy: 2}
+
+	tests := []struct {
+		name        string
+		prepSqlMock func() (*sql.DB, error)
+		want        bool
+		wantErr     bool
+	}{
+		{
+			name: "sql exec failed",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(query).WithArgs(1, 2, mockTime, 2).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "pair already matched",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(query).WithArgs(1, 2, mockTime, 2).WillReturnResult(driver.RowsAffected(0))
+				return sqlServer, err
+			},
+		},
+		{
+			name: "match created",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(query).WithArgs(1, 2, mockTime, 2).WillReturnResult(driver.RowsAffected(1))
+				return sqlServer, err
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "matches",
+			})
+			created, err := init.CreateMatch(context.Background(), input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("match.CreateMatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, created)
+		})
+	}
+}
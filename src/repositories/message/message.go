@@ -0,0 +1,91 @@
+package message
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/base"
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+type Interface interface {
+	base.BaseInterface[models.MessageInput, models.Message, filter.MessageFilter]
+	GetConversation(ctx context.Context, matchId int, cursor filter.Cursor) ([]models.Message, error)
+	MarkAsRead(ctx context.Context, matchId, receiverId int, readAt time.Time) error
+	GetUnreadCounts(ctx context.Context, receiverId int) (map[int]int, error)
+}
+
+type messageRepository struct {
+	base.BaseRepository[models.MessageInput, models.Message, filter.MessageFilter]
+}
+type Param struct {
+	Db        *sql.DB
+	TableName string
+}
+
+func Init(param Param) Interface {
+	return &messageRepository{
+		BaseRepository: base.BaseRepository[models.MessageInput, models.Message, filter.MessageFilter]{
+			Db:        param.Db,
+			TableName: param.TableName,
+		},
+	}
+}
+
+func (r *messageRepository) GetConversation(ctx context.Context, matchId int, cursor filter.Cursor) ([]models.Message, error) {
+	var (
+		tempMessage = models.Query[models.Message]{}
+		member      = tempMessage.BuildTableMember()
+		query       = fmt.Sprintf(GetConversation, member)
+		result      = []models.Message{}
+	)
+
+	rows, err := r.Conn(ctx).QueryContext(ctx, query, matchId, cursor.Cursor, cursor.Cursor, cursor.Take)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var model models.Message
+
+		s := reflect.ValueOf(&model).Elem()
+		numCols := s.NumField()
+		columns := make([]interface{}, numCols)
+		for i := 0; i < numCols; i++ {
+			field := s.Field(i)
+			columns[i] = field.Addr().Interface()
+		}
+
+		if err := rows.Scan(columns...); err != nil {
+			return result, err
+		}
+		result = append(result, model)
+	}
+	return result, nil
+}
+
+func (r *messageRepository) MarkAsRead(ctx context.Context, matchId, receiverId int, readAt time.Time) error {
+	_, err := r.Conn(ctx).ExecContext(ctx, MarkAsRead, readAt, readAt, receiverId, matchId, receiverId)
+	return err
+}
+
+func (r *messageRepository) GetUnreadCounts(ctx context.Context, receiverId int) (map[int]int, error) {
+	result := map[int]int{}
+
+	rows, err := r.Conn(ctx).QueryContext(ctx, GetUnreadCounts, receiverId)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var matchId, count int
+		if err := rows.Scan(&matchId, &count); err != nil {
+			return result, err
+		}
+		result[matchId] = count
+	}
+	return result, nil
+}
@@ -0,0 +1,37 @@
+package message
+
+const (
+	GetConversation = `
+	SELECT %s 
+	FROM 
+		messages 
+	WHERE 
+		match_id = ? 
+		AND status = 1 
+		AND (? = 0 OR id < ?)
+	ORDER BY id DESC
+	LIMIT ?
+	`
+	MarkAsRead = `
+	UPDATE 
+		messages 
+	SET 
+		read_at = ?, updated_at = ?, updated_by = ?
+	WHERE 
+		match_id = ? 
+		AND receiver_id = ? 
+		AND read_at IS NULL 
+		AND status = 1
+	`
+	GetUnreadCounts = `
+	SELECT 
+		match_id, COUNT(*)
+	FROM 
+		messages 
+	WHERE 
+		receiver_id = ? 
+		AND read_at IS NULL 
+		AND status = 1
+	GROUP BY match_id
+	`
+)
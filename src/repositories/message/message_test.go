@@ -0,0 +1,410 @@
+package message
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/formatter"
+	"DatingApp/src/models"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreate(t *testing.T) {
+	query := regexp.QuoteMeta("INSERT INTO message () VALUES ()")
+
+	type args struct {
+		ctx    context.Context
+		models models.Query[models.MessageInput]
+	}
+	tests := []struct {
+		name        string
+		args        args
+		prepSqlMock func() (*sql.DB, error)
+		wantErr     bool
+	}{
+		{
+			name: "sql begin failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.MessageInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin().WillReturnError(err)
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql exec failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.MessageInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql no row affected",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.MessageInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(0))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql commit failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.MessageInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectCommit().WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql commit success",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.MessageInput]{},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectCommit()
+				return sqlServer, err
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "message",
+			})
+			err = init.Create(tt.args.ctx, tt.args.models)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("message.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	query := regexp.QuoteMeta("UPDATE message SET  WHERE ")
+
+	type args struct {
+		ctx    context.Context
+		models models.Query[models.MessageInput]
+		id     int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		prepSqlMock func() (*sql.DB, error)
+		wantErr     bool
+	}{
+		{
+			name: "sql begin failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.MessageInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin().WillReturnError(err)
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql exec failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.MessageInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql no row affected",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.MessageInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(0))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql commit failed",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.MessageInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectCommit().WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql commit success",
+			args: args{
+				ctx:    context.Background(),
+				models: models.Query[models.MessageInput]{},
+				id:     1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectCommit()
+				return sqlServer, err
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "message",
+			})
+			err = init.Update(tt.args.ctx, tt.args.models, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("message.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetConversation(t *testing.T) {
+	tempModels := models.Query[models.Message]{}
+	member := tempModels.BuildTableMember()
+	query := regexp.QuoteMeta(fmt.Sprintf(GetConversation, member))
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		ctx     context.Context
+		matchId int
+		cursor  filter.Cursor
+	}
+	tests := []struct {
+		name        string
+		args        args
+		prepSqlMock func() (*sql.DB, error)
+		want        []models.Message
+		wantErr     bool
+	}{
+		{
+			name: "sql query failed",
+			args: args{
+				ctx:     context.Background(),
+				matchId: 1,
+				cursor:  filter.Cursor{Cursor: 10, Take: 20},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectQuery(query).WithArgs(1, 10, 10, 20).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			want:    []models.Message{},
+			wantErr: true,
+		},
+		{
+			name: "sql success",
+			args: args{
+				ctx:     context.Background(),
+				matchId: 1,
+				cursor:  filter.Cursor{Take: 20},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				row := sqlMock.NewRows([]string{"id", "match_id", "sender_id", "receiver_id", "content", "read_at", "status", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by"})
+				row.AddRow(1, 1, 1, 2, "hi", nil, 1, mockTime, 1, mockTime, nil, nil, nil)
+				sqlMock.ExpectQuery(query).WithArgs(1, 0, 0, 20).WillReturnRows(row)
+				return sqlServer, err
+			},
+			want: []models.Message{
+				{
+					Id:         1,
+					MatchId:    1,
+					SenderId:   1,
+					ReceiverId: 2,
+					Content:    "hi",
+					Status:     1,
+					CreatedAt:  formatter.NullableDataType[time.Time]{Data: mockTime, Valid: true},
+					CreatedBy:  formatter.NullableDataType[int64]{Data: 1, Valid: true},
+					UpdatedAt:  formatter.NullableDataType[time.Time]{Data: mockTime, Valid: true},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "message",
+			})
+			messages, err := init.GetConversation(tt.args.ctx, tt.args.matchId, tt.args.cursor)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("message.GetConversation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, messages)
+		})
+	}
+}
+
+func TestMarkAsRead(t *testing.T) {
+	query := regexp.QuoteMeta(MarkAsRead)
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		prepSqlMock func() (*sql.DB, error)
+		wantErr     bool
+	}{
+		{
+			name: "sql exec failed",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(query).WithArgs(mockTime, mockTime, 2, 1, 2).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql exec success",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(query).WithArgs(mockTime, mockTime, 2, 1, 2).WillReturnResult(driver.RowsAffected(3))
+				return sqlServer, err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "message",
+			})
+			err = init.MarkAsRead(context.Background(), 1, 2, mockTime)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("message.MarkAsRead() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetUnreadCounts(t *testing.T) {
+	query := regexp.QuoteMeta(GetUnreadCounts)
+
+	tests := []struct {
+		name        string
+		prepSqlMock func() (*sql.DB, error)
+		want        map[int]int
+		wantErr     bool
+	}{
+		{
+			name: "sql query failed",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectQuery(query).WithArgs(1).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			want:    map[int]int{},
+			wantErr: true,
+		},
+		{
+			name: "sql success",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				row := sqlMock.NewRows([]string{"match_id", "COUNT(*)"}).AddRow(3, 2).AddRow(4, 1)
+				sqlMock.ExpectQuery(query).WithArgs(1).WillReturnRows(row)
+				return sqlServer, err
+			},
+			want: map[int]int{3: 2, 4: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "message",
+			})
+			counts, err := init.GetUnreadCounts(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("message.GetUnreadCounts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, counts)
+		})
+	}
+}
@@ -48,18 +48,47 @@ func (mr *MockInterfaceMockRecorder) ComparePassword(hashedPassword, inputPasswo
 }
 
 // GenerateToken mocks base method.
-func (m *MockInterface) GenerateToken(userId int, userName string) (string, error) {
+func (m *MockInterface) GenerateToken(userId, sessionId int, role string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateToken", userId, userName)
+	ret := m.ctrl.Call(m, "GenerateToken", userId, sessionId, role)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GenerateToken indicates an expected call of GenerateToken.
-func (mr *MockInterfaceMockRecorder) GenerateToken(userId, userName interface{}) *gomock.Call {
+func (mr *MockInterfaceMockRecorder) GenerateToken(userId, sessionId, role interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockInterface)(nil).GenerateToken), userId, userName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockInterface)(nil).GenerateToken), userId, sessionId, role)
+}
+
+// GenerateRefreshToken mocks base method.
+func (m *MockInterface) GenerateRefreshToken() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateRefreshToken")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateRefreshToken indicates an expected call of GenerateRefreshToken.
+func (mr *MockInterfaceMockRecorder) GenerateRefreshToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateRefreshToken", reflect.TypeOf((*MockInterface)(nil).GenerateRefreshToken))
+}
+
+// HashToken mocks base method.
+func (m *MockInterface) HashToken(token string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HashToken", token)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// HashToken indicates an expected call of HashToken.
+func (mr *MockInterfaceMockRecorder) HashToken(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashToken", reflect.TypeOf((*MockInterface)(nil).HashToken), token)
 }
 
 // HashPassword mocks base method.
@@ -69,3 +69,15 @@ func (mr *MockInterfaceMockRecorder) Update(ctx, input, id interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockInterface)(nil).Update), ctx, input, id)
 }
+
+func (m *MockInterface) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockInterfaceMockRecorder) Transaction(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockInterface)(nil).Transaction), ctx, fn)
+}
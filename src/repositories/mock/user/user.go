@@ -7,6 +7,7 @@ package mock_user
 import (
 	"context"
 	"reflect"
+	"time"
 	"DatingApp/src/filter"
 	"DatingApp/src/models"
 
@@ -58,18 +59,30 @@ func (mr *MockInterfaceMockRecorder) Get(ctx, paging interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInterface)(nil).Get), ctx, paging)
 }
 
-func (m *MockInterface) GetRecomendedUser(ctx context.Context, userId int) (models.RecomendationUser, error) {
+func (m *MockInterface) GetCandidates(ctx context.Context, param models.RecomendationParam, limit int) ([]models.RecomendationCandidate, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRecomendedUser", ctx, userId)
-	ret0, _ := ret[0].(models.RecomendationUser)
+	ret := m.ctrl.Call(m, "GetCandidates", ctx, param, limit)
+	ret0, _ := ret[0].([]models.RecomendationCandidate)
 	ret2, _ := ret[1].(error)
 	return ret0, ret2
 }
 
 
-func (mr *MockInterfaceMockRecorder) GetRecomendedUser(ctx, userId interface{}) *gomock.Call {
+func (mr *MockInterfaceMockRecorder) GetCandidates(ctx, param, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecomendedUser", reflect.TypeOf((*MockInterface)(nil).GetRecomendedUser), ctx, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandidates", reflect.TypeOf((*MockInterface)(nil).GetCandidates), ctx, param, limit)
+}
+
+func (m *MockInterface) UpdateLocation(ctx context.Context, userId int, coordinate models.Coordinate, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLocation", ctx, userId, coordinate, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockInterfaceMockRecorder) UpdateLocation(ctx, userId, coordinate, updatedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLocation", reflect.TypeOf((*MockInterface)(nil).UpdateLocation), ctx, userId, coordinate, updatedAt)
 }
 
 func (m *MockInterface) Update(ctx context.Context, input models.Query[models.UserInput], id int) error {
@@ -83,3 +96,39 @@ func (mr *MockInterfaceMockRecorder) Update(ctx, input, id interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockInterface)(nil).Update), ctx, input, id)
 }
+
+func (m *MockInterface) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockInterfaceMockRecorder) Transaction(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockInterface)(nil).Transaction), ctx, fn)
+}
+
+func (m *MockInterface) UpdateImage(ctx context.Context, userId int, image string, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateImage", ctx, userId, image, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockInterfaceMockRecorder) UpdateImage(ctx, userId, image, updatedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateImage", reflect.TypeOf((*MockInterface)(nil).UpdateImage), ctx, userId, image, updatedAt)
+}
+
+func (m *MockInterface) Lock(ctx context.Context, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockInterfaceMockRecorder) Lock(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockInterface)(nil).Lock), ctx, userId)
+}
@@ -7,6 +7,7 @@ package mock_user_activity
 import (
 	"context"
 	"reflect"
+	"time"
 	"DatingApp/src/filter"
 	"DatingApp/src/models"
 
@@ -70,15 +71,53 @@ func (mr *MockInterfaceMockRecorder) Update(ctx, input, id interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockInterface)(nil).Update), ctx, input, id)
 }
 
-func (m *MockInterface) GetTotalTodayActivity(ctx context.Context, userId int) (int, error) {
+func (m *MockInterface) CountActivity(ctx context.Context, userId int, activity string, since time.Time) (models.ActivityCount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTotalTodayActivity", ctx, userId)
+	ret := m.ctrl.Call(m, "CountActivity", ctx, userId, activity, since)
+	ret0, _ := ret[0].(models.ActivityCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockInterfaceMockRecorder) CountActivity(ctx, userId, activity, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActivity", reflect.TypeOf((*MockInterface)(nil).CountActivity), ctx, userId, activity, since)
+}
+func (m *MockInterface) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockInterfaceMockRecorder) Transaction(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockInterface)(nil).Transaction), ctx, fn)
+}
+
+func (m *MockInterface) HasLiked(ctx context.Context, userId, likedUserId int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasLiked", ctx, userId, likedUserId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockInterfaceMockRecorder) HasLiked(ctx, userId, likedUserId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasLiked", reflect.TypeOf((*MockInterface)(nil).HasLiked), ctx, userId, likedUserId)
+}
+
+func (m *MockInterface) GetLikesReceived(ctx context.Context, userId int, paging filter.Paging[filter.UserActivityFilter]) ([]models.LikeReceived, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikesReceived", ctx, userId, paging)
+	ret0, _ := ret[0].([]models.LikeReceived)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
-	return ret1, ret2
+	return ret0, ret1, ret2
 }
 
-func (mr *MockInterfaceMockRecorder) GetTotalTodayActivity(ctx, userId interface{}) *gomock.Call {
+func (mr *MockInterfaceMockRecorder) GetLikesReceived(ctx, userId, paging interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalTodayActivity", reflect.TypeOf((*MockInterface)(nil).GetTotalTodayActivity), ctx, userId)
-}
\ No newline at end of file
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikesReceived", reflect.TypeOf((*MockInterface)(nil).GetLikesReceived), ctx, userId, paging)
+}
@@ -0,0 +1,64 @@
+package order
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/base"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type Interface interface {
+	base.BaseInterface[models.OrderInput, models.Order, filter.OrderFilter]
+	CreateOrder(ctx context.Context, input models.Query[models.OrderInput]) (int, error)
+	MarkPaid(ctx context.Context, id int, paidAt time.Time) (bool, error)
+}
+
+type orderRepository struct {
+	base.BaseRepository[models.OrderInput, models.Order, filter.OrderFilter]
+}
+type Param struct {
+	Db        *sql.DB
+	TableName string
+}
+
+func Init(param Param) Interface {
+	return &orderRepository{
+		BaseRepository: base.BaseRepository[models.OrderInput, models.Order, filter.OrderFilter]{
+			Db:        param.Db,
+			TableName: param.TableName,
+		},
+	}
+}
+
+// CreateOrder is like Create but returns the id of the new order.
+func (r *orderRepository) CreateOrder(ctx context.Context, input models.Query[models.OrderInput]) (int, error) {
+	createQuery, args := input.BuildCreateQuery()
+
+	result, err := r.Conn(ctx).ExecContext(ctx, base.Create+r.TableName+createQuery, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// MarkPaid moves a pending order to paid and reports whether it did, so a webhook
+// delivered twice only activates the order once.
+func (r *orderRepository) MarkPaid(ctx context.Context, id int, paidAt time.Time) (bool, error) {
+	result, err := r.Conn(ctx).ExecContext(ctx, MarkPaid, paidAt, paidAt, id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
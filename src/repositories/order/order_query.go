@@ -0,0 +1,13 @@
+package order
+
+const (
+	MarkPaid = `
+	UPDATE 
+		orders 
+	SET 
+		status = 2, paid_at = ?, updated_at = ?, updated_by = user_id
+	WHERE 
+		id = ? 
+		AND status = 1
+	`
+)
@@ -0,0 +1,55 @@
+package passwordreset
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/base"
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Interface stores the password reset tokens by their hash, a token works once and only until it expires.
+type Interface interface {
+	base.BaseInterface[models.PasswordResetInput, models.PasswordReset, filter.PasswordResetFilter]
+	Consume(ctx context.Context, id int, usedAt time.Time) (bool, error)
+	RevokeUserResets(ctx context.Context, userId int, revokedAt time.Time) error
+}
+
+type passwordResetRepository struct {
+	base.BaseRepository[models.PasswordResetInput, models.PasswordReset, filter.PasswordResetFilter]
+}
+type Param struct {
+	Db        *sql.DB
+	TableName string
+}
+
+func Init(param Param) Interface {
+	return &passwordResetRepository{
+		BaseRepository: base.BaseRepository[models.PasswordResetInput, models.PasswordReset, filter.PasswordResetFilter]{
+			Db:        param.Db,
+			TableName: param.TableName,
+		},
+	}
+}
+
+// Consume marks an unused and unexpired token as used and reports whether it did,
+// so the same token can't reset the password twice.
+func (r *passwordResetRepository) Consume(ctx context.Context, id int, usedAt time.Time) (bool, error) {
+	result, err := r.Conn(ctx).ExecContext(ctx, Consume, usedAt, usedAt, id, usedAt)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// RevokeUserResets ends every unused token of the user, only the latest reset mail should work.
+func (r *passwordResetRepository) RevokeUserResets(ctx context.Context, userId int, revokedAt time.Time) error {
+	_, err := r.Conn(ctx).ExecContext(ctx, RevokeUserResets, revokedAt, userId, userId)
+	return err
+}
@@ -0,0 +1,23 @@
+package passwordreset
+
+const (
+	Consume = `
+	UPDATE 
+		password_resets 
+	SET 
+		status = 2, used_at = ?, updated_at = ?, updated_by = user_id
+	WHERE 
+		id = ? 
+		AND status = 1
+		AND expires_at > ?
+	`
+	RevokeUserResets = `
+	UPDATE 
+		password_resets 
+	SET 
+		status = -1, deleted_at = ?, deleted_by = ?
+	WHERE 
+		user_id = ? 
+		AND status = 1
+	`
+)
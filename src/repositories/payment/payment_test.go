@@ -0,0 +1,122 @@
+package payment
+
+import (
+	"DatingApp/src/models"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateCheckout(t *testing.T) {
+	provider, err := Init(Param{Provider: FakeProvider, Secret: []byte("secret")})
+	assert.NoError(t, err)
+
+	reference, checkoutUrl, err := provider.CreateCheckout(context.Background(), models.CheckoutRequest{UserId: 1, Amount: 10000})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(reference, fakeReferencePrefix))
+	assert.Equal(t, fakeCheckoutUrl+reference, checkoutUrl)
+
+	otherReference, _, err := provider.CreateCheckout(context.Background(), models.CheckoutRequest{UserId: 1, Amount: 10000})
+	assert.NoError(t, err)
+	assert.NotEqual(t, reference, otherReference)
+
+	_, _, err = provider.CreateCheckout(context.Background(), models.CheckoutRequest{UserId: 1})
+	assert.Error(t, err)
+}
+
+func TestVerifySignature(t *testing.T) {
+	secret := []byte("secret")
+	provider, err := Init(Param{Provider: FakeProvider, Secret: secret})
+	assert.NoError(t, err)
+	payload := []byte(`{"id":"evt_1","type":"checkout.completed","reference":"fake_1","amount":10000}`)
+
+	tests := []struct {
+		name      string
+		payload   []byte
+		signature string
+		wantErr   bool
+	}{
+		{
+			name:      "signature isnt hex",
+			payload:   payload,
+			signature: "not hex",
+			wantErr:   true,
+		},
+		{
+			name:      "signed with another secret",
+			payload:   payload,
+			signature: Sign([]byte("other"), payload),
+			wantErr:   true,
+		},
+		{
+			name:      "payload tampered",
+			payload:   []byte(`{"id":"evt_1","type":"checkout.completed","reference":"fake_1","amount":1}`),
+			signature: Sign(secret, payload),
+			wantErr:   true,
+		},
+		{
+			name:      "valid signature",
+			payload:   payload,
+			signature: Sign(secret, payload),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := provider.VerifySignature(tt.payload, tt.signature)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("payment.VerifySignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInit(t *testing.T) {
+	_, err := Init(Param{Secret: []byte("secret")})
+	assert.ErrorIs(t, err, ErrNoProvider)
+
+	_, err = Init(Param{Provider: FakeProvider})
+	assert.Error(t, err)
+
+	_, err = Init(Param{Provider: "stripe", Secret: []byte("secret")})
+	assert.Error(t, err)
+}
+
+func TestParseEvent(t *testing.T) {
+	provider, err := Init(Param{Provider: FakeProvider, Secret: []byte("secret")})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		payload string
+		want    models.PaymentEvent
+		wantErr bool
+	}{
+		{
+			name:    "invalid json",
+			payload: `{`,
+			wantErr: true,
+		},
+		{
+			name:    "missing reference",
+			payload: `{"id":"evt_1","type":"checkout.completed"}`,
+			wantErr: true,
+		},
+		{
+			name:    "parse success",
+			payload: `{"id":"evt_1","type":"checkout.completed","reference":"fake_1","amount":10000}`,
+			want:    models.PaymentEvent{Id: "evt_1", Type: models.PaymentEventCheckoutCompleted, Reference: "fake_1", Amount: 10000},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := provider.ParseEvent([]byte(tt.payload))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("payment.ParseEvent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, event)
+		})
+	}
+}
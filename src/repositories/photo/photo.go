@@ -0,0 +1,70 @@
+package photo
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/base"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type Interface interface {
+	base.BaseInterface[models.PhotoInput, models.Photo, filter.PhotoFilter]
+	CreatePhoto(ctx context.Context, input models.PhotoInput) (int, error)
+	Reorder(ctx context.Context, userId int, ids []int, updatedAt time.Time) error
+}
+
+type photoRepository struct {
+	base.BaseRepository[models.PhotoInput, models.Photo, filter.PhotoFilter]
+}
+type Param struct {
+	Db        *sql.DB
+	TableName string
+}
+
+func Init(param Param) Interface {
+	return &photoRepository{
+		BaseRepository: base.BaseRepository[models.PhotoInput, models.Photo, filter.PhotoFilter]{
+			Db:        param.Db,
+			TableName: param.TableName,
+		},
+	}
+}
+
+// CreatePhoto adds a photo and returns its id, unlike Create a first photo at position 0 is written too.
+func (r *photoRepository) CreatePhoto(ctx context.Context, input models.PhotoInput) (int, error) {
+	result, err := r.Conn(ctx).ExecContext(ctx, CreatePhoto, input.UserId, input.Url, input.Position, input.CreatedAt, input.CreatedBy)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// Reorder moves the photos of the user so the order of ids is their position.
+func (r *photoRepository) Reorder(ctx context.Context, userId int, ids []int, updatedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	args := []interface{}{}
+	for position, id := range ids {
+		args = append(args, id, position)
+	}
+	args = append(args, updatedAt, userId, userId)
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	cases := strings.TrimSuffix(strings.Repeat("WHEN ? THEN ? ", len(ids)), " ")
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+
+	_, err := r.Conn(ctx).ExecContext(ctx, fmt.Sprintf(Reorder, cases, placeholders), args...)
+	return err
+}
@@ -0,0 +1,21 @@
+package photo
+
+const (
+	CreatePhoto = `
+	INSERT INTO 
+		profile_photos (user_id, url, position, created_at, created_by) 
+	VALUES 
+		(?, ?, ?, ?, ?)
+	`
+	Reorder = `
+	UPDATE 
+		profile_photos 
+	SET 
+		position = CASE id %s END, 
+		updated_at = ?, 
+		updated_by = ? 
+	WHERE 
+		user_id = ? 
+		AND id IN (%s)
+	`
+)
@@ -0,0 +1,40 @@
+package preference
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/base"
+	"context"
+	"database/sql"
+)
+
+type Interface interface {
+	base.BaseInterface[models.PreferenceInput, models.Preference, filter.PreferenceFilter]
+	SavePreference(ctx context.Context, input models.PreferenceInput) error
+}
+
+type preferenceRepository struct {
+	base.BaseRepository[models.PreferenceInput, models.Preference, filter.PreferenceFilter]
+}
+type Param struct {
+	Db        *sql.DB
+	TableName string
+}
+
+func Init(param Param) Interface {
+	return &preferenceRepository{
+		BaseRepository: base.BaseRepository[models.PreferenceInput, models.Preference, filter.PreferenceFilter]{
+			Db:        param.Db,
+			TableName: param.TableName,
+		},
+	}
+}
+
+// SavePreference creates or overwrites the preferences of the user, a zero MaxDistanceKm is written too.
+func (r *preferenceRepository) SavePreference(ctx context.Context, input models.PreferenceInput) error {
+	_, err := r.Conn(ctx).ExecContext(ctx, SavePreference,
+		input.UserId, input.MinAge, input.MaxAge, input.MaxDistanceKm, input.CreatedAt, input.CreatedBy,
+		input.UpdatedAt, input.UpdatedBy,
+	)
+	return err
+}
@@ -0,0 +1,17 @@
+package preference
+
+const (
+	SavePreference = `
+	INSERT INTO 
+		preferences (user_id, min_age, max_age, max_distance_km, created_at, created_by) 
+	VALUES 
+		(?, ?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE 
+		min_age = VALUES(min_age), 
+		max_age = VALUES(max_age), 
+		max_distance_km = VALUES(max_distance_km), 
+		status = 1,
+		updated_at = ?,
+		updated_by = ?
+	`
+)
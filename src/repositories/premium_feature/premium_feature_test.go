@@ -158,7 +158,7 @@ func TestUpdate(t *testing.T) {
 			prepSqlMock: func() (*sql.DB, error) {
 				sqlServer, sqlMock, err := sqlmock.New()
 				sqlMock.ExpectBegin()
-				sqlMock.ExpectExec(query).WillReturnError(errors.New(""))
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnError(errors.New(""))
 				return sqlServer, err
 			},
 			wantErr: true,
@@ -173,7 +173,7 @@ func TestUpdate(t *testing.T) {
 			prepSqlMock: func() (*sql.DB, error) {
 				sqlServer, sqlMock, err := sqlmock.New()
 				sqlMock.ExpectBegin()
-				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(0))
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(0))
 				return sqlServer, err
 			},
 			wantErr: true,
@@ -188,7 +188,7 @@ func TestUpdate(t *testing.T) {
 			prepSqlMock: func() (*sql.DB, error) {
 				sqlServer, sqlMock, err := sqlmock.New()
 				sqlMock.ExpectBegin()
-				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(1))
 				sqlMock.ExpectCommit().WillReturnError(errors.New(""))
 				return sqlServer, err
 			},
@@ -204,7 +204,7 @@ func TestUpdate(t *testing.T) {
 			prepSqlMock: func() (*sql.DB, error) {
 				sqlServer, sqlMock, err := sqlmock.New()
 				sqlMock.ExpectBegin()
-				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(1))
 				sqlMock.ExpectCommit()
 				return sqlServer, err
 			},
@@ -290,8 +290,8 @@ func TestGet(t *testing.T) {
 				sqlServer, sqlMock, err := sqlmock.New()
 				rowCount := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(1)
 				sqlMock.ExpectQuery(queryCount).WillReturnRows(rowCount)
-				row := sqlMock.NewRows([]string{"id", "name", "flag",  "status", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by"})
-				row.AddRow(1, "test", "test",  1, formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}, 1, formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}, 1, formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}, 1)
+				row := sqlMock.NewRows([]string{"id", "name", "flag", "duration_days", "price", "status", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by"})
+				row.AddRow(1, "test", "test", 30, 10000, 1, formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}, 1, formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}, 1, formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}, 1)
 				sqlMock.ExpectQuery(query).WillReturnRows(row)
 				return sqlServer, err
 			},
@@ -300,6 +300,8 @@ func TestGet(t *testing.T) {
 					Id:       1,
 					Name: "test", 
 Flag: "test", 
+					DurationDays: 30,
+					Price: 10000,
 
 					Status: 1,
 					CreatedAt: formatter.NullableDataType[time.Time]{
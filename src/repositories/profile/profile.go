@@ -0,0 +1,119 @@
+package profile
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/base"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+type Interface interface {
+	base.BaseInterface[models.ProfileInput, models.Profile, filter.ProfileFilter]
+	SaveProfile(ctx context.Context, input models.ProfileInput) error
+	GetInterestedGenders(ctx context.Context, userId int) ([]string, error)
+	GetInterests(ctx context.Context, userId int) ([]string, error)
+	GetPhotos(ctx context.Context, userId int) ([]models.ProfilePhoto, error)
+	ReplaceInterestedGenders(ctx context.Context, userId int, genders []string) error
+	ReplaceInterests(ctx context.Context, userId int, tags []string) error
+}
+
+type profileRepository struct {
+	base.BaseRepository[models.ProfileInput, models.Profile, filter.ProfileFilter]
+}
+type Param struct {
+	Db        *sql.DB
+	TableName string
+}
+
+func Init(param Param) Interface {
+	return &profileRepository{
+		BaseRepository: base.BaseRepository[models.ProfileInput, models.Profile, filter.ProfileFilter]{
+			Db:        param.Db,
+			TableName: param.TableName,
+		},
+	}
+}
+
+// SaveProfile creates the profile of the user or overwrites every field of it, unlike Update
+// empty values are written too so a field can be cleared.
+func (r *profileRepository) SaveProfile(ctx context.Context, input models.ProfileInput) error {
+	_, err := r.Conn(ctx).ExecContext(ctx, SaveProfile,
+		input.UserId, input.DisplayName, input.Birthdate, input.Gender, input.Bio, input.HeightCm, input.Job, input.CreatedAt, input.CreatedBy,
+		input.UpdatedAt, input.UpdatedBy,
+	)
+	return err
+}
+
+func (r *profileRepository) GetInterestedGenders(ctx context.Context, userId int) ([]string, error) {
+	return r.getStrings(ctx, GetInterestedGenders, userId)
+}
+
+func (r *profileRepository) GetInterests(ctx context.Context, userId int) ([]string, error) {
+	return r.getStrings(ctx, GetInterests, userId)
+}
+
+func (r *profileRepository) GetPhotos(ctx context.Context, userId int) ([]models.ProfilePhoto, error) {
+	result := []models.ProfilePhoto{}
+
+	rows, err := r.Conn(ctx).QueryContext(ctx, GetPhotos, userId)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var photo models.ProfilePhoto
+		if err := rows.Scan(&photo.Id, &photo.Url, &photo.Position); err != nil {
+			return result, err
+		}
+		result = append(result, photo)
+	}
+	return result, nil
+}
+
+func (r *profileRepository) ReplaceInterestedGenders(ctx context.Context, userId int, genders []string) error {
+	return r.replace(ctx, DeleteInterestedGenders, InsertInterestedGenders, userId, genders)
+}
+
+func (r *profileRepository) ReplaceInterests(ctx context.Context, userId int, tags []string) error {
+	return r.replace(ctx, DeleteInterests, InsertInterests, userId, tags)
+}
+
+func (r *profileRepository) getStrings(ctx context.Context, query string, userId int) ([]string, error) {
+	result := []string{}
+
+	rows, err := r.Conn(ctx).QueryContext(ctx, query, userId)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return result, err
+		}
+		result = append(result, value)
+	}
+	return result, nil
+}
+
+// replace swaps every row of the user in a (user_id, value) table with values.
+func (r *profileRepository) replace(ctx context.Context, deleteQuery, insertQuery string, userId int, values []string) error {
+	if _, err := r.Conn(ctx).ExecContext(ctx, deleteQuery, userId); err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	args := []interface{}{}
+	for _, value := range values {
+		args = append(args, userId, value)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("(?, ?), ", len(values)), ", ")
+
+	_, err := r.Conn(ctx).ExecContext(ctx, fmt.Sprintf(insertQuery, placeholders), args...)
+	return err
+}
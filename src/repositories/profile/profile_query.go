@@ -0,0 +1,70 @@
+package profile
+
+const (
+	SaveProfile = `
+	INSERT INTO 
+		profiles (user_id, display_name, birthdate, gender, bio, height_cm, job, created_at, created_by) 
+	VALUES 
+		(?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE 
+		display_name = VALUES(display_name), 
+		birthdate = VALUES(birthdate), 
+		gender = VALUES(gender), 
+		bio = VALUES(bio), 
+		height_cm = VALUES(height_cm), 
+		job = VALUES(job), 
+		status = 1,
+		updated_at = ?,
+		updated_by = ?
+	`
+	GetInterestedGenders = `
+	SELECT 
+		gender 
+	FROM 
+		profile_interested_genders 
+	WHERE 
+		user_id = ?
+	ORDER BY gender
+	`
+	GetInterests = `
+	SELECT 
+		tag 
+	FROM 
+		profile_interests 
+	WHERE 
+		user_id = ?
+	ORDER BY tag
+	`
+	GetPhotos = `
+	SELECT 
+		id, url, position 
+	FROM 
+		profile_photos 
+	WHERE 
+		user_id = ? 
+		AND status = 1
+	ORDER BY position
+	`
+	DeleteInterestedGenders = `
+	DELETE FROM 
+		profile_interested_genders 
+	WHERE 
+		user_id = ?
+	`
+	InsertInterestedGenders = `
+	INSERT INTO 
+		profile_interested_genders (user_id, gender) 
+	VALUES %s
+	`
+	DeleteInterests = `
+	DELETE FROM 
+		profile_interests 
+	WHERE 
+		user_id = ?
+	`
+	InsertInterests = `
+	INSERT INTO 
+		profile_interests (user_id, tag) 
+	VALUES %s
+	`
+)
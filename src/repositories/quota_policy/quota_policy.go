@@ -0,0 +1,68 @@
+package quotapolicy
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/base"
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+type Interface interface {
+	base.BaseInterface[models.QuotaPolicyInput, models.QuotaPolicy, filter.QuotaPolicyFilter]
+	GetUserPolicies(ctx context.Context, userId int, activity string, now time.Time) ([]models.QuotaPolicy, error)
+}
+
+type quotaPolicyRepository struct {
+	base.BaseRepository[models.QuotaPolicyInput, models.QuotaPolicy, filter.QuotaPolicyFilter]
+}
+type Param struct {
+	Db        *sql.DB
+	TableName string
+}
+
+func Init(param Param) Interface {
+	return &quotaPolicyRepository{
+		BaseRepository: base.BaseRepository[models.QuotaPolicyInput, models.QuotaPolicy, filter.QuotaPolicyFilter]{
+			Db:        param.Db,
+			TableName: param.TableName,
+		},
+	}
+}
+
+// GetUserPolicies returns the active policies of the activity, and of any activity, which apply to the user:
+// the default ones and the ones of premium features the user is subscribed to at now.
+func (r *quotaPolicyRepository) GetUserPolicies(ctx context.Context, userId int, activity string, now time.Time) ([]models.QuotaPolicy, error) {
+	var (
+		tempModels = models.Query[models.QuotaPolicy]{}
+		member     = tempModels.BuildTableMember()
+		query      = fmt.Sprintf(GetUserPolicies, member)
+		result     = []models.QuotaPolicy{}
+	)
+
+	rows, err := r.Conn(ctx).QueryContext(ctx, query, activity, models.ActivityAny, userId, now)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var model models.QuotaPolicy
+
+		s := reflect.ValueOf(&model).Elem()
+		numCols := s.NumField()
+		columns := make([]interface{}, numCols)
+		for i := 0; i < numCols; i++ {
+			field := s.Field(i)
+			columns[i] = field.Addr().Interface()
+		}
+
+		if err := rows.Scan(columns...); err != nil {
+			return result, err
+		}
+		result = append(result, model)
+	}
+	return result, nil
+}
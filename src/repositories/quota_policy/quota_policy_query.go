@@ -0,0 +1,25 @@
+package quotapolicy
+
+const (
+	GetUserPolicies = `
+	SELECT %s 
+	FROM 
+		quota_policies 
+	WHERE 
+		status = 1 
+		AND activity IN (?, ?)
+		AND (
+			premium_feature_id IS NULL
+			OR premium_feature_id IN (
+				SELECT 
+					premium_feature_id 
+				FROM 
+					subscriptions 
+				WHERE 
+					user_id = ? 
+					AND status = 1 
+					AND expires_at > ?
+			)
+		)
+	`
+)
@@ -0,0 +1,47 @@
+package report
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/base"
+	"context"
+	"database/sql"
+)
+
+// Interface stores the reports of the moderation queue, the status of a report is its state in the queue.
+type Interface interface {
+	base.BaseInterface[models.ReportInput, models.Report, filter.ReportFilter]
+	Resolve(ctx context.Context, id int, input models.ReportInput) (bool, error)
+}
+
+type reportRepository struct {
+	base.BaseRepository[models.ReportInput, models.Report, filter.ReportFilter]
+}
+type Param struct {
+	Db        *sql.DB
+	TableName string
+}
+
+func Init(param Param) Interface {
+	return &reportRepository{
+		BaseRepository: base.BaseRepository[models.ReportInput, models.Report, filter.ReportFilter]{
+			Db:        param.Db,
+			TableName: param.TableName,
+		},
+	}
+}
+
+// Resolve moves an open report to the status of input and reports whether it did,
+// so a report handled by two admins at once is only closed by one of them.
+func (r *reportRepository) Resolve(ctx context.Context, id int, input models.ReportInput) (bool, error) {
+	result, err := r.Conn(ctx).ExecContext(ctx, Resolve, input.Status, input.UpdatedAt, input.UpdatedBy, id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
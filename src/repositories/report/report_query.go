@@ -0,0 +1,13 @@
+package report
+
+const (
+	Resolve = `
+	UPDATE 
+		reports 
+	SET 
+		status = ?, updated_at = ?, updated_by = ?
+	WHERE 
+		id = ? 
+		AND status = 1
+	`
+)
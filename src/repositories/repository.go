@@ -2,10 +2,31 @@ package repositories
 
 import (
 	"database/sql"
+	"DatingApp/src/models"
 	"DatingApp/src/repositories/auth"
+	"DatingApp/src/repositories/broker"
+	"DatingApp/src/repositories/entitlement"
     user "DatingApp/src/repositories/user"
     useractivity "DatingApp/src/repositories/user_activity"
     premiumfeature "DatingApp/src/repositories/premium_feature"
+    match "DatingApp/src/repositories/match"
+    message "DatingApp/src/repositories/message"
+    session "DatingApp/src/repositories/session"
+    subscription "DatingApp/src/repositories/subscription"
+    order "DatingApp/src/repositories/order"
+    payment "DatingApp/src/repositories/payment"
+    quotapolicy "DatingApp/src/repositories/quota_policy"
+    profile "DatingApp/src/repositories/profile"
+    preference "DatingApp/src/repositories/preference"
+    deck "DatingApp/src/repositories/deck"
+    block "DatingApp/src/repositories/block"
+    report "DatingApp/src/repositories/report"
+    boost "DatingApp/src/repositories/boost"
+    photo "DatingApp/src/repositories/photo"
+    storage "DatingApp/src/repositories/storage"
+    verification "DatingApp/src/repositories/verification"
+    passwordreset "DatingApp/src/repositories/password_reset"
+    mailer "DatingApp/src/repositories/mailer"
     
 )
 
@@ -14,6 +35,26 @@ type Repositories struct {
 	User user.Interface
     UserActivity useractivity.Interface
     PremiumFeature premiumfeature.Interface
+    Match match.Interface
+    Message message.Interface
+    Broker broker.Interface
+    Session session.Interface
+    Subscription subscription.Interface
+    Entitlement entitlement.Interface
+    Order order.Interface
+    Payment payment.Interface
+    QuotaPolicy quotapolicy.Interface
+    Profile profile.Interface
+    Preference preference.Interface
+    Deck deck.Interface
+    Block block.Interface
+    Report report.Interface
+    Boost boost.Interface
+    Photo photo.Interface
+    Storage storage.Interface
+    Verification verification.Interface
+    PasswordReset passwordreset.Interface
+    Mailer mailer.Interface
     
 }
 
@@ -21,12 +62,46 @@ type Param struct {
 	Db *sql.DB
 }
 
-func Init(param Param) *Repositories {
+// Init fails when a repository is missing the config it needs, the server shouldn't start half set up.
+func Init(param Param) (*Repositories, error) {
+	paymentRepository, err := payment.Init(payment.Param{Provider: models.GetPaymentProvider(), Secret: models.GetPaymentSecret()})
+	if err != nil {
+		return nil, err
+	}
+	storageRepository, err := storage.Init(storage.Param{Dir: models.GetStorageDir(), BaseUrl: models.GetStorageUrl(), Secret: models.GetStorageSecret()})
+	if err != nil {
+		return nil, err
+	}
+	mailerRepository, err := mailer.Init(mailer.Param{Driver: models.GetMailer(), Path: models.GetMailFile()})
+	if err != nil {
+		return nil, err
+	}
+
 	return &Repositories{
 		Auth: auth.Init(),
 		User: user.Init(user.Param{Db: param.Db, TableName: "users"}),
         UserActivity: useractivity.Init(useractivity.Param{Db: param.Db, TableName: "user_activities"}),
         PremiumFeature: premiumfeature.Init(premiumfeature.Param{Db: param.Db, TableName: "premium_features"}),
+        Match: match.Init(match.Param{Db: param.Db, TableName: "matches"}),
+        Message: message.Init(message.Param{Db: param.Db, TableName: "messages"}),
+        Broker: broker.Init(),
+        Session: session.Init(session.Param{Db: param.Db, TableName: "sessions"}),
+        Subscription: subscription.Init(subscription.Param{Db: param.Db, TableName: "subscriptions"}),
+        Entitlement: entitlement.Init(entitlement.Param{Db: param.Db}),
+        Order: order.Init(order.Param{Db: param.Db, TableName: "orders"}),
+        Payment: paymentRepository,
+        QuotaPolicy: quotapolicy.Init(quotapolicy.Param{Db: param.Db, TableName: "quota_policies"}),
+        Profile: profile.Init(profile.Param{Db: param.Db, TableName: "profiles"}),
+        Preference: preference.Init(preference.Param{Db: param.Db, TableName: "preferences"}),
+        Deck: deck.Init(deck.Param{Db: param.Db, TableName: "recomendation_deck_cards"}),
+        Block: block.Init(block.Param{Db: param.Db, TableName: "blocks"}),
+        Report: report.Init(report.Param{Db: param.Db, TableName: "reports"}),
+        Boost: boost.Init(boost.Param{Db: param.Db, TableName: "boosts"}),
+        Photo: photo.Init(photo.Param{Db: param.Db, TableName: "profile_photos"}),
+        Storage: storageRepository,
+        Verification: verification.Init(verification.Param{Db: param.Db, TableName: "verifications"}),
+        PasswordReset: passwordreset.Init(passwordreset.Param{Db: param.Db, TableName: "password_resets"}),
+        Mailer: mailerRepository,
         
-	}
+	}, nil
 }
@@ -0,0 +1,74 @@
+package session
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/base"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type Interface interface {
+	base.BaseInterface[models.SessionInput, models.Session, filter.SessionFilter]
+	CreateSession(ctx context.Context, input models.Query[models.SessionInput]) (int, error)
+	RotateRefreshToken(ctx context.Context, id int, oldHash, newHash string, expiresAt time.Time) error
+	RevokeUserSessions(ctx context.Context, userId, exceptId int, revokedAt time.Time) error
+}
+
+type sessionRepository struct {
+	base.BaseRepository[models.SessionInput, models.Session, filter.SessionFilter]
+}
+type Param struct {
+	Db        *sql.DB
+	TableName string
+}
+
+func Init(param Param) Interface {
+	return &sessionRepository{
+		BaseRepository: base.BaseRepository[models.SessionInput, models.Session, filter.SessionFilter]{
+			Db:        param.Db,
+			TableName: param.TableName,
+		},
+	}
+}
+
+// CreateSession is like Create but returns the id of the new session, which is carried in the access token.
+func (r *sessionRepository) CreateSession(ctx context.Context, input models.Query[models.SessionInput]) (int, error) {
+	createQuery, args := input.BuildCreateQuery()
+
+	result, err := r.Conn(ctx).ExecContext(ctx, base.Create+r.TableName+createQuery, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// RotateRefreshToken replaces the refresh token only when oldHash is still the current one,
+// so the same refresh token can't be used twice.
+func (r *sessionRepository) RotateRefreshToken(ctx context.Context, id int, oldHash, newHash string, expiresAt time.Time) error {
+	result, err := r.Conn(ctx).ExecContext(ctx, RotateRefreshToken, newHash, expiresAt, id, oldHash)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New("refresh token already used")
+	}
+	return nil
+}
+
+func (r *sessionRepository) RevokeUserSessions(ctx context.Context, userId, exceptId int, revokedAt time.Time) error {
+	_, err := r.Conn(ctx).ExecContext(ctx, RevokeUserSessions, revokedAt, userId, userId, exceptId)
+	return err
+}
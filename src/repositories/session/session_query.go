@@ -0,0 +1,24 @@
+package session
+
+const (
+	RotateRefreshToken = `
+	UPDATE 
+		sessions 
+	SET 
+		refresh_token_hash = ?, expires_at = ?, updated_by = user_id
+	WHERE 
+		id = ? 
+		AND refresh_token_hash = ? 
+		AND status = 1
+	`
+	RevokeUserSessions = `
+	UPDATE 
+		sessions 
+	SET 
+		status = -1, deleted_at = ?, deleted_by = ?
+	WHERE 
+		user_id = ? 
+		AND id <> ?
+		AND status = 1
+	`
+)
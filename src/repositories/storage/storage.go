@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"DatingApp/src/models"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidKey = errors.New("invalid storage key")
+	ErrNoSecret   = errors.New("storage secret is not set")
+)
+
+// Interface keeps files by key, the service only talks to it through this so an S3 compatible
+// storage presigning its own urls can replace the local one in Init.
+type Interface interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+	SignedUrl(key string, expiresAt time.Time) string
+	VerifyUrl(key string, expires int64, signature string, now time.Time) error
+}
+
+type localStorage struct {
+	dir     string
+	baseUrl string
+	secret  []byte
+}
+
+type Param struct {
+	Dir     string
+	BaseUrl string
+	Secret  []byte
+}
+
+// Init returns a storage keeping the files under Dir, they are served by the app at BaseUrl
+// behind urls signed with HMAC-SHA256 of the key and when they expire.
+// Without a Secret anyone could sign an url so Init refuses it.
+func Init(param Param) (Interface, error) {
+	if len(param.Secret) == 0 {
+		return nil, ErrNoSecret
+	}
+	return &localStorage{
+		dir:     param.Dir,
+		baseUrl: strings.TrimSuffix(param.BaseUrl, "/"),
+		secret:  param.Secret,
+	}, nil
+}
+
+func (s *localStorage) Put(ctx context.Context, key string, data []byte) error {
+	file, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0o644)
+}
+
+func (s *localStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	file, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(file)
+}
+
+// Delete removes the file of key, a folder is refused so a key can never take more than one file with it.
+// A missing key is not an error, the folder of the file goes too once it's empty.
+func (s *localStorage) Delete(ctx context.Context, key string) error {
+	file, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	info, err := os.Lstat(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return ErrInvalidKey
+	}
+	if err := os.Remove(file); err != nil {
+		return err
+	}
+	// fails while the folder still has files, which is fine
+	if folder := filepath.Dir(file); folder != filepath.Clean(s.dir) {
+		os.Remove(folder)
+	}
+	return nil
+}
+
+func (s *localStorage) SignedUrl(key string, expiresAt time.Time) string {
+	expires := expiresAt.Unix()
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", hex.EncodeToString(s.sign(key, expires)))
+	return s.baseUrl + "/" + key + "?" + query.Encode()
+}
+
+func (s *localStorage) VerifyUrl(key string, expires int64, signature string, now time.Time) error {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return models.ErrInvalidSignature
+	}
+	if !hmac.Equal(expected, s.sign(key, expires)) {
+		return models.ErrInvalidSignature
+	}
+	if now.Unix() >= expires {
+		return models.ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *localStorage) sign(key string, expires int64) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "%s\n%d", key, expires)
+	return mac.Sum(nil)
+}
+
+// path maps key to a file under the storage folder, keys escaping it are refused.
+func (s *localStorage) path(key string) (string, error) {
+	if key == "" || path.IsAbs(key) || path.Clean(key) != key || strings.HasPrefix(key, "..") {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"DatingApp/src/models"
+	"context"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func initStorage(t *testing.T, param Param) Interface {
+	storage, err := Init(param)
+	assert.NoError(t, err)
+	return storage
+}
+
+func TestInit(t *testing.T) {
+	_, err := Init(Param{Dir: t.TempDir()})
+	assert.ErrorIs(t, err, ErrNoSecret)
+}
+
+func TestPutGetDelete(t *testing.T) {
+	dir := t.TempDir()
+	storage := initStorage(t, Param{Dir: dir, Secret: []byte("secret")})
+	ctx := context.Background()
+
+	assert.NoError(t, storage.Put(ctx, "photos/1/abc/small.jpg", []byte("small")))
+	assert.NoError(t, storage.Put(ctx, "photos/1/abc/large.jpg", []byte("large")))
+
+	data, err := storage.Get(ctx, "photos/1/abc/small.jpg")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("small"), data)
+
+	assert.ErrorIs(t, storage.Delete(ctx, "photos/1/abc"), ErrInvalidKey)
+	assert.ErrorIs(t, storage.Delete(ctx, "."), ErrInvalidKey)
+	assert.ErrorIs(t, storage.Delete(ctx, "photos"), ErrInvalidKey)
+
+	assert.NoError(t, storage.Delete(ctx, "photos/1/abc/large.jpg"))
+	_, err = storage.Get(ctx, "photos/1/abc/large.jpg")
+	assert.Error(t, err)
+	data, err = storage.Get(ctx, "photos/1/abc/small.jpg")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("small"), data)
+
+	assert.NoError(t, storage.Delete(ctx, "photos/1/abc/small.jpg"))
+	_, err = os.Stat(filepath.Join(dir, "photos", "1", "abc"))
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	assert.NoError(t, storage.Delete(ctx, "photos/1/missing"))
+}
+
+func TestInvalidKey(t *testing.T) {
+	storage := initStorage(t, Param{Dir: t.TempDir(), Secret: []byte("secret")})
+	ctx := context.Background()
+
+	for _, key := range []string{"", "/etc/passwd", "../secret", "photos/../../secret", "photos//1", "photos/1/"} {
+		t.Run(key, func(t *testing.T) {
+			assert.ErrorIs(t, storage.Put(ctx, key, []byte("data")), ErrInvalidKey)
+			_, err := storage.Get(ctx, key)
+			assert.ErrorIs(t, err, ErrInvalidKey)
+			assert.ErrorIs(t, storage.Delete(ctx, key), ErrInvalidKey)
+		})
+	}
+}
+
+func TestSignedUrl(t *testing.T) {
+	storage := initStorage(t, Param{BaseUrl: "http://localhost:8080/api/v1/photos/", Secret: []byte("secret")})
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	expiresAt := mockTime.Add(time.Hour)
+
+	signedUrl := storage.SignedUrl("photos/1/abc/small.jpg", expiresAt)
+	assert.True(t, strings.HasPrefix(signedUrl, "http://localhost:8080/api/v1/photos/photos/1/abc/small.jpg?"))
+
+	parsed, err := url.Parse(signedUrl)
+	assert.NoError(t, err)
+	expires, err := strconv.ParseInt(parsed.Query().Get("expires"), 10, 64)
+	assert.NoError(t, err)
+	assert.Equal(t, expiresAt.Unix(), expires)
+	signature := parsed.Query().Get("signature")
+
+	tests := []struct {
+		name      string
+		storage   Interface
+		key       string
+		expires   int64
+		signature string
+		now       time.Time
+		wantErr   error
+	}{
+		{
+			name:      "signature isnt hex",
+			storage:   storage,
+			key:       "photos/1/abc/small.jpg",
+			expires:   expires,
+			signature: "not hex",
+			now:       mockTime,
+			wantErr:   models.ErrInvalidSignature,
+		},
+		{
+			name:      "signed with another secret",
+			storage:   initStorage(t, Param{Secret: []byte("other")}),
+			key:       "photos/1/abc/small.jpg",
+			expires:   expires,
+			signature: signature,
+			now:       mockTime,
+			wantErr:   models.ErrInvalidSignature,
+		},
+		{
+			name:      "other key",
+			storage:   storage,
+			key:       "photos/1/abc/large.jpg",
+			expires:   expires,
+			signature: signature,
+			now:       mockTime,
+			wantErr:   models.ErrInvalidSignature,
+		},
+		{
+			name:      "expiry tampered",
+			storage:   storage,
+			key:       "photos/1/abc/small.jpg",
+			expires:   expires + 3600,
+			signature: signature,
+			now:       mockTime,
+			wantErr:   models.ErrInvalidSignature,
+		},
+		{
+			name:      "expired",
+			storage:   storage,
+			key:       "photos/1/abc/small.jpg",
+			expires:   expires,
+			signature: signature,
+			now:       expiresAt,
+			wantErr:   models.ErrInvalidSignature,
+		},
+		{
+			name:      "valid url",
+			storage:   storage,
+			key:       "photos/1/abc/small.jpg",
+			expires:   expires,
+			signature: signature,
+			now:       mockTime,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.storage.VerifyUrl(tt.key, tt.expires, tt.signature, tt.now)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
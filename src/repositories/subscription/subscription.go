@@ -0,0 +1,74 @@
+package subscription
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/base"
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+type Interface interface {
+	base.BaseInterface[models.SubscriptionInput, models.Subscription, filter.SubscriptionFilter]
+	GetExpired(ctx context.Context, now time.Time) ([]models.Subscription, error)
+	ExpireUserSubscriptions(ctx context.Context, userId, premiumFeatureId int, expiredAt time.Time) error
+}
+
+type subscriptionRepository struct {
+	base.BaseRepository[models.SubscriptionInput, models.Subscription, filter.SubscriptionFilter]
+}
+type Param struct {
+	Db        *sql.DB
+	TableName string
+}
+
+func Init(param Param) Interface {
+	return &subscriptionRepository{
+		BaseRepository: base.BaseRepository[models.SubscriptionInput, models.Subscription, filter.SubscriptionFilter]{
+			Db:        param.Db,
+			TableName: param.TableName,
+		},
+	}
+}
+
+// GetExpired returns active subscriptions which expires_at already passed.
+func (r *subscriptionRepository) GetExpired(ctx context.Context, now time.Time) ([]models.Subscription, error) {
+	var (
+		tempModels = models.Query[models.Subscription]{}
+		member     = tempModels.BuildTableMember()
+		query      = fmt.Sprintf(GetExpired, member)
+		result     = []models.Subscription{}
+	)
+
+	rows, err := r.Conn(ctx).QueryContext(ctx, query, now)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var model models.Subscription
+
+		s := reflect.ValueOf(&model).Elem()
+		numCols := s.NumField()
+		columns := make([]interface{}, numCols)
+		for i := 0; i < numCols; i++ {
+			field := s.Field(i)
+			columns[i] = field.Addr().Interface()
+		}
+
+		if err := rows.Scan(columns...); err != nil {
+			return result, err
+		}
+		result = append(result, model)
+	}
+	return result, nil
+}
+
+// ExpireUserSubscriptions ends the active subscriptions of the user on the given feature at expiredAt, used when the user renews it.
+func (r *subscriptionRepository) ExpireUserSubscriptions(ctx context.Context, userId, premiumFeatureId int, expiredAt time.Time) error {
+	_, err := r.Conn(ctx).ExecContext(ctx, ExpireUserSubscriptions, expiredAt, expiredAt, userId, userId, premiumFeatureId)
+	return err
+}
@@ -0,0 +1,22 @@
+package subscription
+
+const (
+	GetExpired = `
+	SELECT %s 
+	FROM 
+		subscriptions 
+	WHERE 
+		status = 1 
+		AND expires_at <= ?
+	`
+	ExpireUserSubscriptions = `
+	UPDATE 
+		subscriptions 
+	SET 
+		status = 2, expires_at = LEAST(expires_at, ?), updated_at = ?, updated_by = ?
+	WHERE 
+		user_id = ? 
+		AND premium_feature_id = ?
+		AND status = 1
+	`
+)
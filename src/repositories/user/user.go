@@ -13,7 +13,10 @@ import (
 
 type Interface interface {
 	base.BaseInterface[models.UserInput, models.User, filter.UserFilter]
-	GetRecomendedUser(ctx context.Context, userId int) (models.RecomendationUser, error)
+	GetCandidates(ctx context.Context, param models.RecomendationParam, limit int) ([]models.RecomendationCandidate, error)
+	UpdateLocation(ctx context.Context, userId int, coordinate models.Coordinate, updatedAt time.Time) error
+	UpdateImage(ctx context.Context, userId int, image string, updatedAt time.Time) error
+	Lock(ctx context.Context, userId int) error
 }
 
 type userRepository struct {
@@ -33,23 +36,52 @@ func Init(param Param) Interface {
 	}
 }
 
-func (r *userRepository) GetRecomendedUser(ctx context.Context, userId int) (models.RecomendationUser, error) {
+var Now = time.Now
+
+// GetCandidates returns up to limit users that could be recomended along with what they are ranked on.
+// Users liked or matched are never returned again and users passed only once param.PassedSince is past the pass.
+// The candidate and the user must both have a profile and match each other's wanted genders and age range.
+// Cards still in an unexpired deck of the user are skipped too, so are users blocked by or blocking the user.
+// Candidates who super liked the user come first, then the boosted ones, then the closest ones
+// when the user has a location, both sides max distance is honored.
+func (r *userRepository) GetCandidates(ctx context.Context, param models.RecomendationParam, limit int) ([]models.RecomendationCandidate, error) {
 	var (
-		tempUser   = models.Query[models.RecomendationUser]{}
-		member     = tempUser.BuildTableMember()
-		query      = fmt.Sprintf(GetRecomendUser, member)
-		result     = models.RecomendationUser{}
-		timeNowMin = time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), 0, 0, 0, 0, time.UTC)
-		timeNowMax = time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), 23, 59, 59, 1e9, time.UTC)
+		now             = Now()
+		result          = []models.RecomendationCandidate{}
+		today           = now.In(param.Timezone).Format(models.BirthdateLayout)
+		distance        = UnknownDistance
+		boundingBox     = ""
+		maxDistance     = ""
+		args            = []interface{}{param.UserId, param.UserId, param.UserId, models.VerifiedFlag, now, now, now, models.VerifiedFlag, now}
+		maxDistanceArgs = []interface{}{}
 	)
 
-	rows, err := r.Db.QueryContext(ctx, query, userId, timeNowMin, timeNowMax, userId, timeNowMin, timeNowMax, userId)
+	if param.Origin != nil {
+		distance = DistanceKm
+		args = append(args, models.EarthRadiusKm, param.Origin.Latitude, param.Origin.Latitude, param.Origin.Longitude)
+		if param.MaxDistanceKm > 0 {
+			minLat, maxLat, minLng, maxLng := param.Origin.BoundingBox(float64(param.MaxDistanceKm))
+			boundingBox = BoundingBox
+			args = append(args, minLat, maxLat, minLng, maxLng)
+			maxDistance = MaxDistance
+			maxDistanceArgs = append(maxDistanceArgs, param.MaxDistanceKm)
+		}
+	}
+	args = append(args, param.UserId, param.UserId, param.UserId, param.PassedSince, param.UserId, param.UserId, param.UserId, now, param.UserId, param.UserId,
+		param.UserId, today, models.DefaultMinAge, models.DefaultMaxAge, today, models.DefaultMinAge, models.DefaultMaxAge,
+	)
+	args = append(args, maxDistanceArgs...)
+	args = append(args, limit)
+	query := fmt.Sprintf(GetCandidates, distance, boundingBox, maxDistance)
+
+	rows, err := r.Conn(ctx).QueryContext(ctx, query, args...)
 	if err != nil {
 		return result, err
 	}
 	defer rows.Close()
 	for rows.Next() {
-		s := reflect.ValueOf(&result).Elem()
+		var candidate models.RecomendationCandidate
+		s := reflect.ValueOf(&candidate).Elem()
 		numCols := s.NumField()
 		columns := make([]interface{}, numCols)
 		for i := 0; i < numCols; i++ {
@@ -61,6 +93,28 @@ func (r *userRepository) GetRecomendedUser(ctx context.Context, userId int) (mod
 		if err != nil {
 			return result, err
 		}
+		result = append(result, candidate)
 	}
 	return result, nil
 }
+
+func (r *userRepository) UpdateLocation(ctx context.Context, userId int, coordinate models.Coordinate, updatedAt time.Time) error {
+	_, err := r.Conn(ctx).ExecContext(ctx, UpdateLocation, coordinate.Latitude, coordinate.Longitude, updatedAt, userId, userId)
+	return err
+}
+
+// UpdateImage sets the image of the user, an empty image clears it which Update can't.
+func (r *userRepository) UpdateImage(ctx context.Context, userId int, image string, updatedAt time.Time) error {
+	_, err := r.Conn(ctx).ExecContext(ctx, UpdateImage, image, updatedAt, userId, userId)
+	return err
+}
+
+// Lock holds a row lock on the user until the transaction of ctx ends, so a check and the write depending on it
+// can't interleave with another request of the same user. Outside a transaction it locks nothing.
+func (r *userRepository) Lock(ctx context.Context, userId int) error {
+	rows, err := r.Conn(ctx).QueryContext(ctx, Lock, userId)
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
@@ -1,21 +1,103 @@
 package user
 
 const (
-	GetRecomendUser = `
-	SELECT %s 
+	GetCandidates = `
+	SELECT 
+		u.id, u.user_name, u.image, u.distance,
+		(SELECT MAX(ua.created_at) FROM user_activities ua WHERE ua.user_id = u.id) AS last_active_at,
+		(SELECT (p.bio <> '') + (p.job <> '') + (p.height_cm > 0) FROM profiles p WHERE p.user_id = u.id) AS profile_fields,
+		(SELECT COUNT(*) FROM profile_photos pp WHERE pp.user_id = u.id AND pp.status = 1) AS photo_count,
+		(SELECT COUNT(*) FROM profile_interests cpi WHERE cpi.user_id = u.id) AS interest_count,
+		(SELECT COUNT(*) FROM profile_interests cpi 
+			JOIN profile_interests vpi ON vpi.tag = cpi.tag AND vpi.user_id = ? 
+			WHERE cpi.user_id = u.id) AS mutual_interests,
+		EXISTS (SELECT 1 FROM user_activities ua 
+			WHERE ua.user_id = u.id AND ua.liked_user_id = ? AND ua.status = 1) AS liked_you,
+		EXISTS (SELECT 1 FROM user_activities ua 
+			WHERE ua.user_id = u.id AND ua.liked_user_id = ? AND ua.activity = 'superlike' AND ua.status = 1) AS super_liked_you,
+		EXISTS (SELECT 1 FROM subscriptions s 
+			JOIN premium_features spf ON spf.id = s.premium_feature_id 
+			WHERE s.user_id = u.id AND spf.flag <> ? AND s.status = 1 AND s.expires_at > ?) AS premium,
+		EXISTS (SELECT 1 FROM boosts bo 
+			WHERE bo.user_id = u.id AND bo.status = 1 AND bo.started_at <= ? AND bo.ends_at > ?) AS boosted,
+		EXISTS (SELECT 1 FROM subscriptions vs 
+			JOIN premium_features vpf ON vpf.id = vs.premium_feature_id 
+			WHERE vs.user_id = u.id AND vpf.flag = ? AND vs.status = 1 AND vpf.status = 1 AND vs.expires_at > ?) AS verified
 	FROM 
-		users u 
+		(SELECT u.*, %s AS distance FROM users u WHERE u.status = 1 %s) u 
 	WHERE 
-		u.id NOT IN 
-			(SELECT ua.passed_user_id FROM user_activities ua 
-				WHERE ua.user_id = ? AND created_at > ? AND created_at < ? AND ua.passed_user_id IS NOT NULL)
-		AND
-		u.id NOT IN 
-			(SELECT ua.liked_user_id FROM user_activities ua 
-				WHERE ua.user_id = ? AND created_at > ? AND created_at < ? AND ua.liked_user_id IS NOT NULL) 
-		AND 
-		u.id NOT IN (?)
+		u.id <> ?
+		AND NOT EXISTS 
+			(SELECT 1 FROM user_activities ua 
+				WHERE ua.user_id = ? AND ua.liked_user_id = u.id AND ua.status = 1)
+		AND NOT EXISTS 
+			(SELECT 1 FROM user_activities ua 
+				WHERE ua.user_id = ? AND ua.passed_user_id = u.id AND ua.status = 1 AND ua.created_at >= ?)
+		AND NOT EXISTS 
+			(SELECT 1 FROM matches m WHERE m.user_id = ? AND m.matched_user_id = u.id)
+		AND NOT EXISTS 
+			(SELECT 1 FROM matches m WHERE m.user_id = u.id AND m.matched_user_id = ?)
+		AND NOT EXISTS 
+			(SELECT 1 FROM recomendation_deck_cards rdc 
+				WHERE rdc.user_id = ? AND rdc.candidate_id = u.id AND rdc.expires_at > ?)
+		AND NOT EXISTS 
+			(SELECT 1 FROM blocks b WHERE b.user_id = ? AND b.blocked_user_id = u.id AND b.status = 1)
+		AND NOT EXISTS 
+			(SELECT 1 FROM blocks b WHERE b.user_id = u.id AND b.blocked_user_id = ? AND b.status = 1)
 		AND u.status = 1
-	LIMIT 1
+		AND EXISTS 
+			(SELECT 1 FROM profiles cp 
+				JOIN profiles vp ON vp.user_id = ? AND vp.status = 1 
+				LEFT JOIN preferences cpref ON cpref.user_id = cp.user_id AND cpref.status = 1 
+				LEFT JOIN preferences vpref ON vpref.user_id = vp.user_id AND vpref.status = 1 
+				WHERE cp.user_id = u.id AND cp.status = 1 
+				AND cp.gender IN (SELECT pig.gender FROM profile_interested_genders pig WHERE pig.user_id = vp.user_id) 
+				AND vp.gender IN (SELECT pig.gender FROM profile_interested_genders pig WHERE pig.user_id = cp.user_id) 
+				AND TIMESTAMPDIFF(YEAR, cp.birthdate, ?) BETWEEN COALESCE(vpref.min_age, ?) AND COALESCE(vpref.max_age, ?) 
+				AND TIMESTAMPDIFF(YEAR, vp.birthdate, ?) BETWEEN COALESCE(cpref.min_age, ?) AND COALESCE(cpref.max_age, ?))
+		AND NOT EXISTS 
+			(SELECT 1 FROM preferences cpref 
+				WHERE cpref.user_id = u.id AND cpref.status = 1 AND cpref.max_distance_km > 0 
+				AND (u.distance IS NULL OR u.distance > cpref.max_distance_km))
+		%s
+	ORDER BY super_liked_you DESC, boosted DESC, u.distance IS NULL, u.distance
+	LIMIT ?
+	`
+	DistanceKm = `
+		(? * 2 * ASIN(SQRT(
+			POWER(SIN(RADIANS(u.latitude - ?) / 2), 2) + 
+			COS(RADIANS(?)) * COS(RADIANS(u.latitude)) * POWER(SIN(RADIANS(u.longitude - ?) / 2), 2))))`
+	UnknownDistance = `NULL`
+	BoundingBox     = `AND u.latitude BETWEEN ? AND ? AND u.longitude BETWEEN ? AND ?`
+	MaxDistance     = `AND u.distance <= ?`
+	UpdateLocation  = `
+	UPDATE 
+		users 
+	SET 
+		latitude = ?, 
+		longitude = ?, 
+		updated_at = ?, 
+		updated_by = ? 
+	WHERE 
+		id = ?
+	`
+	UpdateImage = `
+	UPDATE 
+		users 
+	SET 
+		image = NULLIF(?, ''), 
+		updated_at = ?, 
+		updated_by = ? 
+	WHERE 
+		id = ?
+	`
+	Lock = `
+	SELECT 
+		id 
+	FROM 
+		users 
+	WHERE 
+		id = ? 
+	FOR UPDATE
 	`
 )
@@ -101,6 +101,23 @@ func TestCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "sql commit success with quoted value",
+			args: args{
+				ctx: context.Background(),
+				models: models.Query[models.UserInput]{
+					Model: models.UserInput{UserName: "o'neil", Password: "test"},
+				},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO user (user_name, password) VALUES (?, ?)")).WithArgs("o'neil", "test").WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectCommit()
+				return sqlServer, err
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -159,7 +176,7 @@ func TestUpdate(t *testing.T) {
 			prepSqlMock: func() (*sql.DB, error) {
 				sqlServer, sqlMock, err := sqlmock.New()
 				sqlMock.ExpectBegin()
-				sqlMock.ExpectExec(query).WillReturnError(errors.New(""))
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnError(errors.New(""))
 				return sqlServer, err
 			},
 			wantErr: true,
@@ -174,7 +191,7 @@ func TestUpdate(t *testing.T) {
 			prepSqlMock: func() (*sql.DB, error) {
 				sqlServer, sqlMock, err := sqlmock.New()
 				sqlMock.ExpectBegin()
-				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(0))
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(0))
 				return sqlServer, err
 			},
 			wantErr: true,
@@ -189,7 +206,7 @@ func TestUpdate(t *testing.T) {
 			prepSqlMock: func() (*sql.DB, error) {
 				sqlServer, sqlMock, err := sqlmock.New()
 				sqlMock.ExpectBegin()
-				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(1))
 				sqlMock.ExpectCommit().WillReturnError(errors.New(""))
 				return sqlServer, err
 			},
@@ -205,7 +222,7 @@ func TestUpdate(t *testing.T) {
 			prepSqlMock: func() (*sql.DB, error) {
 				sqlServer, sqlMock, err := sqlmock.New()
 				sqlMock.ExpectBegin()
-				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(1))
 				sqlMock.ExpectCommit()
 				return sqlServer, err
 			},
@@ -281,6 +298,22 @@ func TestGet(t *testing.T) {
 			wantUser:  []models.User{},
 			wantCount: 1,
 		},
+		{
+			name: "sql filter query failed",
+			args: args{
+				ctx: context.Background(),
+				models: filter.Paging[filter.UserFilter]{
+					Filter: filter.UserFilter{UserName: "' OR '1'='1"},
+				},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM user WHERE 1=1 AND user_name=?")).WithArgs("' OR '1'='1").WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantUser: []models.User{},
+			wantErr:  true,
+		},
 		{
 			name: "sql success",
 			args: args{
@@ -291,19 +324,21 @@ func TestGet(t *testing.T) {
 				sqlServer, sqlMock, err := sqlmock.New()
 				rowCount := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(1)
 				sqlMock.ExpectQuery(queryCount).WillReturnRows(rowCount)
-				row := sqlMock.NewRows([]string{"id", "user_name", "password", "image", "premium_feature_id", "status", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by"})
-				row.AddRow(1, "test", "test", formatter.NullableDataType[string]{Valid: true, Data: "test"}, formatter.NullableDataType[int]{Valid: false, Data: 0}, 1, formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}, 1, formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}, 1, formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}, 1)
+				row := sqlMock.NewRows([]string{"id", "user_name", "email", "password", "image", "role", "timezone", "latitude", "longitude", "status", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by"})
+				row.AddRow(1, "test", formatter.NullableDataType[string]{Valid: true, Data: "test@mail.com"}, "test", formatter.NullableDataType[string]{Valid: true, Data: "test"}, "user", "Asia/Jakarta", nil, nil, 1, formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}, 1, formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}, 1, formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}, 1)
 				sqlMock.ExpectQuery(query).WillReturnRows(row)
 				return sqlServer, err
 			},
 			wantUser: []models.User{
 				{
-					Id:               1,
-					UserName:         "test",
-					Password:         "test",
-					Image:            formatter.NullableDataType[string]{Valid: true, Data: "test"},
-					PremiumFeatureId: formatter.NullableDataType[int]{Valid: false, Data: 0},
-					Status:           1,
+					Id:       1,
+					UserName: "test",
+					Email:    formatter.NullableDataType[string]{Valid: true, Data: "test@mail.com"},
+					Password: "test",
+					Image:    formatter.NullableDataType[string]{Valid: true, Data: "test"},
+					Role:     "user",
+					Timezone: "Asia/Jakarta",
+					Status:   1,
 					CreatedAt: formatter.NullableDataType[time.Time]{
 						Data:  mockTime,
 						Valid: true,
@@ -354,60 +389,128 @@ func TestGet(t *testing.T) {
 	}
 }
 
-func TestGetRecomendedUser(t *testing.T) {
-	tempModels := models.Query[models.RecomendationUser]{}
-	member := tempModels.BuildTableMember()
-	query := regexp.QuoteMeta(fmt.Sprintf(GetRecomendUser, member))
+func TestGetCandidates(t *testing.T) {
+	query := regexp.QuoteMeta(fmt.Sprintf(GetCandidates, UnknownDistance, "", ""))
+	nearbyQuery := regexp.QuoteMeta(fmt.Sprintf(GetCandidates, DistanceKm, BoundingBox, MaxDistance))
+	origin := models.Coordinate{Latitude: -6.2, Longitude: 106.8}
+	minLat, maxLat, minLng, maxLng := origin.BoundingBox(10)
+	distance := 3.4
 	var mockImage *string
-	var mockId *int
-	timeNowMin := time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), 0, 0, 0, 0, time.UTC)
-	timeNowMax := time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), 23, 59, 59, 1e9, time.UTC)
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 20:00 UTC is already the next day in Jakarta
+	mockTime := time.Date(2022, 5, 11, 20, 0, 0, 0, time.UTC)
+	Now = func() time.Time {
+		return mockTime
+	}
+	defer func() {
+		Now = time.Now
+	}()
+	passedSince := mockTime.AddDate(0, 0, -30)
+	columns := []string{"id", "user_name", "image", "distance", "last_active_at", "profile_fields", "photo_count", "interest_count", "mutual_interests", "liked_you", "super_liked_you", "premium", "boosted", "verified"}
 
 	type args struct {
-		ctx    context.Context
-		userId int
+		ctx   context.Context
+		param models.RecomendationParam
 	}
 	tests := []struct {
 		name        string
 		args        args
 		prepSqlMock func() (*sql.DB, error)
-		wantUser    models.RecomendationUser
+		want        []models.RecomendationCandidate
 		wantErr     bool
 	}{
 		{
 			name: "sql query failed",
 			args: args{
-				ctx:    context.Background(),
-				userId: 1,
+				ctx:   context.Background(),
+				param: models.RecomendationParam{UserId: 1, Timezone: time.UTC, PassedSince: passedSince},
 			},
 			prepSqlMock: func() (*sql.DB, error) {
 				sqlServer, sqlMock, err := sqlmock.New()
 				sqlMock.ExpectQuery(query).WillReturnError(errors.New(""))
 				return sqlServer, err
 			},
-			wantErr:  true,
-			wantUser: models.RecomendationUser{},
+			wantErr: true,
+			want:    []models.RecomendationCandidate{},
 		},
 		{
 			name: "sql success",
 			args: args{
-				ctx:    context.Background(),
-				userId: 1,
+				ctx:   context.Background(),
+				param: models.RecomendationParam{UserId: 1, Timezone: time.UTC, PassedSince: passedSince},
 			},
 			prepSqlMock: func() (*sql.DB, error) {
 				sqlServer, sqlMock, err := sqlmock.New()
-				row := sqlMock.NewRows([]string{"id", "user_name", "image", "premium_feature_id"})
-				row.AddRow(2, "test", mockImage, mockId)
-				sqlMock.ExpectQuery(query).WithArgs(1, timeNowMin, timeNowMax, 1, timeNowMin, timeNowMax, 1).WillReturnRows(row)
+				row := sqlMock.NewRows(columns)
+				row.AddRow(2, "test", mockImage, nil, mockTime, 2, 1, 3, 1, true, true, false, true, true)
+				sqlMock.ExpectQuery(query).WithArgs(1, 1, 1, models.VerifiedFlag, mockTime, mockTime, mockTime, models.VerifiedFlag, mockTime,
+					1, 1, 1, passedSince, 1, 1, 1, mockTime, 1, 1,
+					1, "2022-05-11", models.DefaultMinAge, models.DefaultMaxAge, "2022-05-11", models.DefaultMinAge, models.DefaultMaxAge, 20).WillReturnRows(row)
 				return sqlServer, err
 			},
-			wantUser: models.RecomendationUser{
-				Id:               2,
-				UserName:         "test",
-				PremiumFeatureId: mockId,
-				Image:            mockImage,
+			want: []models.RecomendationCandidate{{
+				Id:              2,
+				UserName:        "test",
+				Image:           mockImage,
+				LastActiveAt:    formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime},
+				ProfileFields:   2,
+				PhotoCount:      1,
+				InterestCount:   3,
+				MutualInterests: 1,
+				LikedYou:        true,
+				SuperLikedYou:   true,
+				Boosted:         true,
+				Verified:        true,
+			}},
+		},
+		{
+			name: "sql success in user timezone",
+			args: args{
+				ctx:   context.Background(),
+				param: models.RecomendationParam{UserId: 1, Timezone: jakarta, PassedSince: passedSince},
 			},
-			wantErr: false,
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				row := sqlMock.NewRows(columns)
+				row.AddRow(2, "test", mockImage, nil, nil, 0, 0, 0, 0, false, false, false, false, false)
+				sqlMock.ExpectQuery(query).WithArgs(1, 1, 1, models.VerifiedFlag, mockTime, mockTime, mockTime, models.VerifiedFlag, mockTime,
+					1, 1, 1, passedSince, 1, 1, 1, mockTime, 1, 1,
+					1, "2022-05-12", models.DefaultMinAge, models.DefaultMaxAge, "2022-05-12", models.DefaultMinAge, models.DefaultMaxAge, 20).WillReturnRows(row)
+				return sqlServer, err
+			},
+			want: []models.RecomendationCandidate{{
+				Id:       2,
+				UserName: "test",
+				Image:    mockImage,
+			}},
+		},
+		{
+			name: "sql success sorted by distance within max distance",
+			args: args{
+				ctx:   context.Background(),
+				param: models.RecomendationParam{UserId: 1, Timezone: time.UTC, Origin: &origin, MaxDistanceKm: 10, PassedSince: passedSince},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				row := sqlMock.NewRows(columns)
+				row.AddRow(2, "test", mockImage, distance, nil, 0, 0, 0, 0, false, false, true, false, false)
+				sqlMock.ExpectQuery(nearbyQuery).WithArgs(1, 1, 1, models.VerifiedFlag, mockTime, mockTime, mockTime, models.VerifiedFlag, mockTime,
+					models.EarthRadiusKm, -6.2, -6.2, 106.8, minLat, maxLat, minLng, maxLng,
+					1, 1, 1, passedSince, 1, 1, 1, mockTime, 1, 1,
+					1, "2022-05-11", models.DefaultMinAge, models.DefaultMaxAge, "2022-05-11", models.DefaultMinAge, models.DefaultMaxAge, 10, 20).WillReturnRows(row)
+				return sqlServer, err
+			},
+			want: []models.RecomendationCandidate{{
+				Id:       2,
+				UserName: "test",
+				Image:    mockImage,
+				Distance: &distance,
+				Premium:  true,
+			}},
 		},
 	}
 	for _, tt := range tests {
@@ -421,12 +524,103 @@ func TestGetRecomendedUser(t *testing.T) {
 				Db:        sqlServer,
 				TableName: "user",
 			})
-			user, err := init.GetRecomendedUser(tt.args.ctx, tt.args.userId)
-			fmt.Println("hehe", user)
+			candidates, err := init.GetCandidates(tt.args.ctx, tt.args.param, 20)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("user.GetRecomendedUser() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("user.GetCandidates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, candidates)
+		})
+	}
+}
+
+func TestUpdateImage(t *testing.T) {
+	query := regexp.QuoteMeta(UpdateImage)
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		image       string
+		prepSqlMock func() (*sql.DB, error)
+		wantErr     bool
+	}{
+		{
+			name:  "sql exec failed",
+			image: "storage://photos/1/abc",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(query).WithArgs("storage://photos/1/abc", mockTime, 1, 1).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name:  "clear image",
+			image: "",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(query).WithArgs("", mockTime, 1, 1).WillReturnResult(driver.RowsAffected(1))
+				return sqlServer, err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "user",
+			})
+			if err := init.UpdateImage(context.Background(), 1, tt.image, mockTime); (err != nil) != tt.wantErr {
+				t.Errorf("user.UpdateImage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLock(t *testing.T) {
+	query := regexp.QuoteMeta(Lock)
+
+	tests := []struct {
+		name        string
+		prepSqlMock func() (*sql.DB, error)
+		wantErr     bool
+	}{
+		{
+			name: "sql query failed",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectQuery(query).WithArgs(1).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql query success",
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				return sqlServer, err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "user",
+			})
+			if err := init.Lock(context.Background(), 1); (err != nil) != tt.wantErr {
+				t.Errorf("user.Lock() error = %v, wantErr %v", err, tt.wantErr)
 			}
-			assert.Equal(t, tt.wantUser, user)
 		})
 	}
 }
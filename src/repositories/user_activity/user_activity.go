@@ -6,13 +6,15 @@ import (
 	"DatingApp/src/repositories/base"
 	"context"
 	"database/sql"
-	"fmt"
+	"reflect"
 	"time"
 )
 
 type Interface interface {
 	base.BaseInterface[models.UserActivityInput, models.UserActivity, filter.UserActivityFilter]
-	GetTotalTodayActivity(ctx context.Context, userId int) (int, error)
+	CountActivity(ctx context.Context, userId int, activity string, since time.Time) (models.ActivityCount, error)
+	HasLiked(ctx context.Context, userId, likedUserId int) (bool, error)
+	GetLikesReceived(ctx context.Context, userId int, paging filter.Paging[filter.UserActivityFilter]) ([]models.LikeReceived, int, error)
 }
 
 type userActivityRepository struct {
@@ -32,20 +34,92 @@ func Init(param Param) Interface {
 	}
 }
 
-func (r *userActivityRepository) GetTotalTodayActivity(ctx context.Context, userId int) (int, error) {
+// CountActivity counts every activity of the kind the user did since, deleted ones included
+// so undoing a swipe doesn't give it back. Rewinds are counted on when the swipe was rewound.
+func (r *userActivityRepository) CountActivity(ctx context.Context, userId int, activity string, since time.Time) (models.ActivityCount, error) {
+	var result models.ActivityCount
+
+	query := CountActivity
+	args := []interface{}{userId, since}
+	switch activity {
+	case models.ActivityLike, models.ActivityPass, models.ActivitySuperLike:
+		query += activityCondition
+		args = append(args, activity)
+	case models.ActivityRewind:
+		query = CountRewind
+	}
+
+	rows, err := r.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := rows.Scan(&result.Count, &result.Oldest); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func (r *userActivityRepository) HasLiked(ctx context.Context, userId, likedUserId int) (bool, error) {
+	var count int
+
+	rowCount, err := r.Conn(ctx).QueryContext(ctx, HasLiked, userId, likedUserId)
+	if err != nil {
+		return false, err
+	}
+	defer rowCount.Close()
+	for rowCount.Next() {
+		if err := rowCount.Scan(&count); err != nil {
+			return false, err
+		}
+	}
+	return count > 0, nil
+}
+
+// GetLikesReceived lists the users who liked the user and weren't liked or passed back yet,
+// super likes first then the newest. Users blocked either way are left out.
+func (r *userActivityRepository) GetLikesReceived(ctx context.Context, userId int, paging filter.Paging[filter.UserActivityFilter]) ([]models.LikeReceived, int, error) {
 	var (
-		timeNowMin = time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), 0, 0, 0, 0, time.UTC)
-		timeNowMax = time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), 23, 59, 59, 1e9, time.UTC)
-		where      = fmt.Sprintf(" WHERE user_id = %d AND created_at > '%s' AND created_at < '%s'", userId, timeNowMin, timeNowMax)
-		count      int
+		result = []models.LikeReceived{}
+		count  int
 	)
 
-	rowCount, err := r.Db.QueryContext(ctx, GetTotalTodayActivity+where)
+	paging.OrderBy = ""
+	pagination := paging.PaginationQuery()
+
+	rowCount, err := r.Conn(ctx).QueryContext(ctx, CountLikesReceived, userId, userId)
 	if err != nil {
-		return count, err
+		return result, count, err
 	}
+	defer rowCount.Close()
 	for rowCount.Next() {
-		err = rowCount.Scan(&count)
+		if err := rowCount.Scan(&count); err != nil {
+			return result, count, err
+		}
+	}
+
+	rows, err := r.Conn(ctx).QueryContext(ctx, GetLikesReceived+pagination, userId, userId)
+	if err != nil {
+		return result, count, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var model models.LikeReceived
+
+		s := reflect.ValueOf(&model).Elem()
+		numCols := s.NumField()
+		columns := make([]interface{}, numCols)
+		for i := 0; i < numCols; i++ {
+			field := s.Field(i)
+			columns[i] = field.Addr().Interface()
+		}
+
+		if err := rows.Scan(columns...); err != nil {
+			return result, count, err
+		}
+		result = append(result, model)
 	}
-	return count, err
+	return result, count, nil
 }
@@ -1,10 +1,57 @@
 package useractivity
 
 const (
-	GetTotalTodayActivity = `
+	CountActivity = `
+		SELECT 
+			COUNT(*), MIN(created_at)
+		FROM 
+			user_activities 
+		WHERE 
+			user_id = ? AND created_at >= ?
+	`
+	CountRewind = `
+		SELECT 
+			COUNT(*), MIN(rewound_at)
+		FROM 
+			user_activities 
+		WHERE 
+			user_id = ? AND rewound_at >= ?
+	`
+	activityCondition = ` AND activity = ?`
+	HasLiked          = `
 		SELECT 
 			COUNT(*)
 		FROM 
 			user_activities 
+		WHERE 
+			user_id = ? AND liked_user_id = ? AND status = 1
+		FOR UPDATE
 	`
+	likesReceivedCondition = `
+		WHERE 
+			ua.liked_user_id = ? 
+			AND ua.status = 1 
+			AND u.status = 1
+			AND NOT EXISTS 
+				(SELECT 1 FROM user_activities mine 
+					WHERE mine.user_id = ? AND (mine.liked_user_id = ua.user_id OR mine.passed_user_id = ua.user_id) AND mine.status = 1)
+			AND NOT EXISTS 
+				(SELECT 1 FROM blocks b 
+					WHERE ((b.user_id = ua.liked_user_id AND b.blocked_user_id = ua.user_id) 
+					OR (b.user_id = ua.user_id AND b.blocked_user_id = ua.liked_user_id)) AND b.status = 1)
+	`
+	GetLikesReceived = `
+		SELECT 
+			u.id, u.user_name, u.image, ua.activity = 'superlike' AS super_like, ua.created_at AS liked_at
+		FROM 
+			user_activities ua
+			JOIN users u ON u.id = ua.user_id` + likesReceivedCondition + `
+		ORDER BY super_like DESC, ua.created_at DESC
+	`
+	CountLikesReceived = `
+		SELECT 
+			COUNT(*)
+		FROM 
+			user_activities ua
+			JOIN users u ON u.id = ua.user_id` + likesReceivedCondition
 )
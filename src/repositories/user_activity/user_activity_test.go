@@ -8,7 +8,6 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"errors"
-	"fmt"
 	"regexp"
 	"testing"
 	"time"
@@ -159,7 +158,7 @@ func TestUpdate(t *testing.T) {
 			prepSqlMock: func() (*sql.DB, error) {
 				sqlServer, sqlMock, err := sqlmock.New()
 				sqlMock.ExpectBegin()
-				sqlMock.ExpectExec(query).WillReturnError(errors.New(""))
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnError(errors.New(""))
 				return sqlServer, err
 			},
 			wantErr: true,
@@ -174,7 +173,7 @@ func TestUpdate(t *testing.T) {
 			prepSqlMock: func() (*sql.DB, error) {
 				sqlServer, sqlMock, err := sqlmock.New()
 				sqlMock.ExpectBegin()
-				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(0))
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(0))
 				return sqlServer, err
 			},
 			wantErr: true,
@@ -189,7 +188,7 @@ func TestUpdate(t *testing.T) {
 			prepSqlMock: func() (*sql.DB, error) {
 				sqlServer, sqlMock, err := sqlmock.New()
 				sqlMock.ExpectBegin()
-				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(1))
 				sqlMock.ExpectCommit().WillReturnError(errors.New(""))
 				return sqlServer, err
 			},
@@ -205,7 +204,7 @@ func TestUpdate(t *testing.T) {
 			prepSqlMock: func() (*sql.DB, error) {
 				sqlServer, sqlMock, err := sqlmock.New()
 				sqlMock.ExpectBegin()
-				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(1))
+				sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(driver.RowsAffected(1))
 				sqlMock.ExpectCommit()
 				return sqlServer, err
 			},
@@ -291,8 +290,8 @@ func TestGet(t *testing.T) {
 				sqlServer, sqlMock, err := sqlmock.New()
 				rowCount := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(1)
 				sqlMock.ExpectQuery(queryCount).WillReturnRows(rowCount)
-				row := sqlMock.NewRows([]string{"id", "user_id", "passed_user_id", "liked_user_id", "status", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by"})
-				row.AddRow(1, 1, formatter.NullableDataType[int]{Valid: false, Data: 0}, formatter.NullableDataType[int]{Valid: false, Data: 0}, 1, formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}, 1, formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}, 1, formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}, 1)
+				row := sqlMock.NewRows([]string{"id", "user_id", "passed_user_id", "liked_user_id", "activity", "rewound_at", "status", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by"})
+				row.AddRow(1, 1, formatter.NullableDataType[int]{Valid: false, Data: 0}, formatter.NullableDataType[int]{Valid: false, Data: 0}, "", nil, 1, formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}, 1, formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}, 1, formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}, 1)
 				sqlMock.ExpectQuery(query).WillReturnRows(row)
 				return sqlServer, err
 			},
@@ -354,50 +353,274 @@ func TestGet(t *testing.T) {
 	}
 }
 
-func TestGetTotalTodayActivity(t *testing.T) {
-	timeNowMin := time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), 0, 0, 0, 0, time.UTC)
-	timeNowMax := time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), 23, 59, 59, 1e9, time.UTC)
-	where := fmt.Sprintf(" WHERE user_id = %d AND created_at > '%s' AND created_at < '%s'", 1, timeNowMin, timeNowMax)
-	query := regexp.QuoteMeta(GetTotalTodayActivity + where)
+func TestCountActivity(t *testing.T) {
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		ctx      context.Context
+		userId   int
+		activity string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		prepSqlMock func() (*sql.DB, error)
+		want        models.ActivityCount
+		wantErr     bool
+	}{
+		{
+			name: "sql query failed",
+			args: args{
+				ctx:      context.Background(),
+				userId:   1,
+				activity: models.ActivityAny,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectQuery(regexp.QuoteMeta(CountActivity)).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "count any activity without any",
+			args: args{
+				ctx:      context.Background(),
+				userId:   1,
+				activity: models.ActivityAny,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				rowCount := sqlMock.NewRows([]string{"COUNT(*)", "MIN(created_at)"}).AddRow(0, nil)
+				sqlMock.ExpectQuery(regexp.QuoteMeta(CountActivity)).WithArgs(1, mockTime).WillReturnRows(rowCount)
+				return sqlServer, err
+			},
+			want: models.ActivityCount{},
+		},
+		{
+			name: "count like",
+			args: args{
+				ctx:      context.Background(),
+				userId:   1,
+				activity: models.ActivityLike,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				rowCount := sqlMock.NewRows([]string{"COUNT(*)", "MIN(created_at)"}).AddRow(2, mockTime)
+				sqlMock.ExpectQuery(regexp.QuoteMeta(CountActivity+activityCondition)).WithArgs(1, mockTime, models.ActivityLike).WillReturnRows(rowCount)
+				return sqlServer, err
+			},
+			want: models.ActivityCount{Count: 2, Oldest: formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}},
+		},
+		{
+			name: "count rewind",
+			args: args{
+				ctx:      context.Background(),
+				userId:   1,
+				activity: models.ActivityRewind,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				rowCount := sqlMock.NewRows([]string{"COUNT(*)", "MIN(rewound_at)"}).AddRow(1, mockTime)
+				sqlMock.ExpectQuery(regexp.QuoteMeta(CountRewind)).WithArgs(1, mockTime).WillReturnRows(rowCount)
+				return sqlServer, err
+			},
+			want: models.ActivityCount{Count: 1, Oldest: formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}},
+		},
+		{
+			name: "count pass",
+			args: args{
+				ctx:      context.Background(),
+				userId:   1,
+				activity: models.ActivityPass,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				rowCount := sqlMock.NewRows([]string{"COUNT(*)", "MIN(created_at)"}).AddRow(3, mockTime)
+				sqlMock.ExpectQuery(regexp.QuoteMeta(CountActivity+activityCondition)).WithArgs(1, mockTime, models.ActivityPass).WillReturnRows(rowCount)
+				return sqlServer, err
+			},
+			want: models.ActivityCount{Count: 3, Oldest: formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "user_activity",
+			})
+			count, err := init.CountActivity(tt.args.ctx, tt.args.userId, tt.args.activity, mockTime)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("user_activity.CountActivity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, count)
+		})
+	}
+}
+
+func TestHasLiked(t *testing.T) {
+	query := regexp.QuoteMeta(HasLiked)
+
+	type args struct {
+		ctx         context.Context
+		userId      int
+		likedUserId int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		prepSqlMock func() (*sql.DB, error)
+		want        bool
+		wantErr     bool
+	}{
+		{
+			name: "sql query failed",
+			args: args{
+				ctx:         context.Background(),
+				userId:      2,
+				likedUserId: 1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectQuery(query).WithArgs(2, 1).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql not liked",
+			args: args{
+				ctx:         context.Background(),
+				userId:      2,
+				likedUserId: 1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				rowCount := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(0)
+				sqlMock.ExpectQuery(query).WithArgs(2, 1).WillReturnRows(rowCount)
+				return sqlServer, err
+			},
+			want: false,
+		},
+		{
+			name: "sql liked",
+			args: args{
+				ctx:         context.Background(),
+				userId:      2,
+				likedUserId: 1,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				rowCount := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(1)
+				sqlMock.ExpectQuery(query).WithArgs(2, 1).WillReturnRows(rowCount)
+				return sqlServer, err
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			init := Init(Param{
+				Db:        sqlServer,
+				TableName: "user_activity",
+			})
+			isLiked, err := init.HasLiked(tt.args.ctx, tt.args.userId, tt.args.likedUserId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("user_activity.HasLiked() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, isLiked)
+		})
+	}
+}
+
+func TestGetLikesReceived(t *testing.T) {
+	query := regexp.QuoteMeta(GetLikesReceived + " LIMIT 10 OFFSET 0")
+	queryCount := regexp.QuoteMeta(CountLikesReceived)
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	mockImage := "image"
 
 	type args struct {
 		ctx    context.Context
 		userId int
+		paging filter.Paging[filter.UserActivityFilter]
 	}
 	tests := []struct {
 		name        string
 		args        args
 		prepSqlMock func() (*sql.DB, error)
-		want        int
+		wantLikes   []models.LikeReceived
+		wantCount   int
 		wantErr     bool
 	}{
+		{
+			name: "sql count query failed",
+			args: args{
+				ctx:    context.Background(),
+				userId: 1,
+				paging: filter.Paging[filter.UserActivityFilter]{Page: 1, Take: 10},
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectQuery(queryCount).WithArgs(1, 1).WillReturnError(errors.New(""))
+				return sqlServer, err
+			},
+			wantLikes: []models.LikeReceived{},
+			wantErr:   true,
+		},
 		{
 			name: "sql query failed",
 			args: args{
 				ctx:    context.Background(),
 				userId: 1,
+				paging: filter.Paging[filter.UserActivityFilter]{Page: 1, Take: 10},
 			},
 			prepSqlMock: func() (*sql.DB, error) {
 				sqlServer, sqlMock, err := sqlmock.New()
-				sqlMock.ExpectQuery(query).WillReturnError(errors.New(""))
+				rowCount := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(1)
+				sqlMock.ExpectQuery(queryCount).WithArgs(1, 1).WillReturnRows(rowCount)
+				sqlMock.ExpectQuery(query).WithArgs(1, 1).WillReturnError(errors.New(""))
 				return sqlServer, err
 			},
-			wantErr: true,
-			want:    0,
+			wantLikes: []models.LikeReceived{},
+			wantCount: 1,
+			wantErr:   true,
 		},
 		{
 			name: "sql success",
 			args: args{
 				ctx:    context.Background(),
 				userId: 1,
+				paging: filter.Paging[filter.UserActivityFilter]{Page: 1, Take: 10, OrderBy: "id"},
 			},
 			prepSqlMock: func() (*sql.DB, error) {
 				sqlServer, sqlMock, err := sqlmock.New()
 				rowCount := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(1)
-				sqlMock.ExpectQuery(query).WillReturnRows(rowCount)
+				sqlMock.ExpectQuery(queryCount).WithArgs(1, 1).WillReturnRows(rowCount)
+				row := sqlMock.NewRows([]string{"id", "user_name", "image", "super_like", "liked_at"})
+				row.AddRow(2, "test", mockImage, true, mockTime)
+				sqlMock.ExpectQuery(query).WithArgs(1, 1).WillReturnRows(row)
 				return sqlServer, err
 			},
-			want: 1,
+			wantLikes: []models.LikeReceived{
+				{
+					Id:        2,
+					UserName:  "test",
+					Image:     &mockImage,
+					SuperLike: true,
+					LikedAt:   mockTime,
+				},
+			},
+			wantCount: 1,
 		},
 	}
 	for _, tt := range tests {
@@ -411,11 +634,12 @@ func TestGetTotalTodayActivity(t *testing.T) {
 				Db:        sqlServer,
 				TableName: "user_activity",
 			})
-			count, err := init.GetTotalTodayActivity(tt.args.ctx, tt.args.userId)
+			likes, count, err := init.GetLikesReceived(tt.args.ctx, tt.args.userId, tt.args.paging)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("user_activity.GetTotalTodayActivity() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("user_activity.GetLikesReceived() error = %v, wantErr %v", err, tt.wantErr)
 			}
-			assert.Equal(t, tt.want, count)
+			assert.Equal(t, tt.wantLikes, likes)
+			assert.Equal(t, tt.wantCount, count)
 		})
 	}
 }
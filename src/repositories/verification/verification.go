@@ -0,0 +1,47 @@
+package verification
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/base"
+	"context"
+	"database/sql"
+)
+
+// Interface stores the verification requests of the review queue, the status of a request is its state in the queue.
+type Interface interface {
+	base.BaseInterface[models.VerificationInput, models.Verification, filter.VerificationFilter]
+	Close(ctx context.Context, id int, input models.VerificationInput) (bool, error)
+}
+
+type verificationRepository struct {
+	base.BaseRepository[models.VerificationInput, models.Verification, filter.VerificationFilter]
+}
+type Param struct {
+	Db        *sql.DB
+	TableName string
+}
+
+func Init(param Param) Interface {
+	return &verificationRepository{
+		BaseRepository: base.BaseRepository[models.VerificationInput, models.Verification, filter.VerificationFilter]{
+			Db:        param.Db,
+			TableName: param.TableName,
+		},
+	}
+}
+
+// Close moves a pending request to the status of input and reports whether it did,
+// so a request reviewed by two admins at once is only closed by one of them.
+func (r *verificationRepository) Close(ctx context.Context, id int, input models.VerificationInput) (bool, error) {
+	result, err := r.Conn(ctx).ExecContext(ctx, Close, input.Status, input.Reason, input.Selfie, input.UpdatedAt, input.UpdatedBy, id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
@@ -0,0 +1,13 @@
+package verification
+
+const (
+	Close = `
+	UPDATE 
+		verifications 
+	SET 
+		status = ?, reason = NULLIF(?, ''), selfie = ?, updated_at = ?, updated_by = ?
+	WHERE 
+		id = ? 
+		AND status = 1
+	`
+)
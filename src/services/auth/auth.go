@@ -4,31 +4,68 @@ import (
 	"DatingApp/src/filter"
 	"DatingApp/src/models"
 	"DatingApp/src/repositories/auth"
+	"DatingApp/src/repositories/mailer"
+	passwordreset "DatingApp/src/repositories/password_reset"
+	"DatingApp/src/repositories/session"
 	"DatingApp/src/repositories/user"
 	"context"
 	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	refreshTokenDuration = time.Hour * 24 * 30
 )
 
 type Interface interface {
 	Register(ctx context.Context, input models.Query[models.UserInput]) error
-	Login(ctx context.Context, input models.Login) ([]models.User, string, error)
+	Login(ctx context.Context, input models.Login) ([]models.User, models.Token, error)
+	Refresh(ctx context.Context, input models.RefreshToken) (models.Token, error)
+	Logout(ctx context.Context) error
+	LogoutAll(ctx context.Context) error
+	ValidateSession(ctx context.Context, userId, sessionId int) error
+	ForgotPassword(ctx context.Context, input models.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, input models.ResetPasswordRequest) error
+	ChangePassword(ctx context.Context, input models.ChangePasswordRequest) error
 }
 
 type authService struct {
-	authRepository auth.Interface
-	userRepository user.Interface
+	authRepository          auth.Interface
+	userRepository          user.Interface
+	sessionRepository       session.Interface
+	passwordResetRepository passwordreset.Interface
+	mailerRepository        mailer.Interface
 }
 
 type Param struct {
-	AuthRepository auth.Interface
-	UserRepository user.Interface
+	AuthRepository          auth.Interface
+	UserRepository          user.Interface
+	SessionRepository       session.Interface
+	PasswordResetRepository passwordreset.Interface
+	MailerRepository        mailer.Interface
 }
 
 func Init(param Param) *authService {
-	return &authService{userRepository: param.UserRepository, authRepository: param.AuthRepository}
+	return &authService{
+		userRepository:          param.UserRepository,
+		authRepository:          param.AuthRepository,
+		sessionRepository:       param.SessionRepository,
+		passwordResetRepository: param.PasswordResetRepository,
+		mailerRepository:        param.MailerRepository,
+	}
 }
 
+var Now = time.Now
+
 func (s *authService) Register(ctx context.Context, input models.Query[models.UserInput]) error {
+	if input.Model.Timezone != "" {
+		if _, err := time.LoadLocation(input.Model.Timezone); err != nil {
+			return errors.New("invalid timezone")
+		}
+	}
+
 	_, count, err := s.userRepository.Get(ctx, filter.Paging[filter.UserFilter]{
 		Page: 1,
 		Take: 1,
@@ -43,6 +80,22 @@ func (s *authService) Register(ctx context.Context, input models.Query[models.Us
 		return errors.New("username already taken by another user")
 	}
 
+	if input.Model.Email != "" {
+		_, count, err := s.userRepository.Get(ctx, filter.Paging[filter.UserFilter]{
+			Page: 1,
+			Take: 1,
+			Filter: filter.UserFilter{
+				Email: input.Model.Email,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.New("email already taken by another user")
+		}
+	}
+
 	password, err := s.authRepository.HashPassword([]byte(input.Model.Password))
 	if err != nil {
 		return err
@@ -56,31 +109,272 @@ func (s *authService) Register(ctx context.Context, input models.Query[models.Us
 	return nil
 }
 
-func (s *authService) Login(ctx context.Context, input models.Login) ([]models.User, string, error) {
+func (s *authService) Login(ctx context.Context, input models.Login) ([]models.User, models.Token, error) {
 
+	// suspended users can't log in
 	users, _, err := s.userRepository.Get(ctx, filter.Paging[filter.UserFilter]{
-		Page: 1,
-		Take: 1,
+		Page:     1,
+		Take:     1,
+		IsActive: true,
 		Filter: filter.UserFilter{
 			UserName: input.UserName,
 		},
 	})
 	if err != nil {
-		return []models.User{}, "", err
+		return []models.User{}, models.Token{}, err
 	}
 	if len(users) == 0 {
-		return []models.User{}, "", errors.New("login failed")
+		return []models.User{}, models.Token{}, errors.New("login failed")
 	}
 
 	err = s.authRepository.ComparePassword([]byte(users[0].Password), []byte(input.Password))
 	if err != nil {
-		return []models.User{}, "", errors.New("login failed")
+		return []models.User{}, models.Token{}, errors.New("login failed")
+	}
+
+	refreshToken, err := s.authRepository.GenerateRefreshToken()
+	if err != nil {
+		return []models.User{}, models.Token{}, err
+	}
+
+	sessionId, err := s.sessionRepository.CreateSession(ctx, models.Query[models.SessionInput]{
+		Model: models.SessionInput{
+			UserId:           int(users[0].Id),
+			RefreshTokenHash: s.authRepository.HashToken(refreshToken),
+			ExpiresAt:        Now().Add(refreshTokenDuration),
+			CreatedAt:        Now(),
+			CreatedBy:        users[0].Id,
+		},
+	})
+	if err != nil {
+		return []models.User{}, models.Token{}, err
+	}
+
+	token, err := s.authRepository.GenerateToken(int(users[0].Id), sessionId, users[0].Role)
+	if err != nil {
+		return []models.User{}, models.Token{}, err
 	}
 
-	token, err := s.authRepository.GenerateToken(int(users[0].Id), users[0].UserName)
+	return users, models.Token{AccessToken: token, RefreshToken: refreshToken}, nil
+}
+
+func (s *authService) Refresh(ctx context.Context, input models.RefreshToken) (models.Token, error) {
+	hash := s.authRepository.HashToken(input.RefreshToken)
+
+	sessions, _, err := s.sessionRepository.Get(ctx, filter.Paging[filter.SessionFilter]{
+		IsActive: true,
+		Filter: filter.SessionFilter{
+			RefreshTokenHash: hash,
+		},
+	})
 	if err != nil {
-		return []models.User{}, "", err
+		return models.Token{}, err
+	}
+	if len(sessions) == 0 {
+		return models.Token{}, errors.New("invalid refresh token")
+	}
+	session := sessions[0]
+	if session.ExpiresAt.Before(Now()) {
+		return models.Token{}, errors.New("session end")
 	}
 
-	return users, token, nil
+	users, _, err := s.userRepository.Get(ctx, filter.Paging[filter.UserFilter]{
+		IsActive: true,
+		Filter: filter.UserFilter{
+			Id: session.UserId,
+		},
+	})
+	if err != nil {
+		return models.Token{}, err
+	}
+	if len(users) == 0 {
+		return models.Token{}, errors.New("user doesnt exists")
+	}
+
+	refreshToken, err := s.authRepository.GenerateRefreshToken()
+	if err != nil {
+		return models.Token{}, err
+	}
+
+	err = s.sessionRepository.RotateRefreshToken(ctx, int(session.Id), hash, s.authRepository.HashToken(refreshToken), Now().Add(refreshTokenDuration))
+	if err != nil {
+		return models.Token{}, err
+	}
+
+	token, err := s.authRepository.GenerateToken(session.UserId, int(session.Id), users[0].Role)
+	if err != nil {
+		return models.Token{}, err
+	}
+
+	return models.Token{AccessToken: token, RefreshToken: refreshToken}, nil
+}
+
+func (s *authService) Logout(ctx context.Context) error {
+	userId := ctx.Value(models.UserKey).(models.User).Id
+	sessionId := ctx.Value(models.SessionKey).(int)
+
+	return s.sessionRepository.Update(ctx, models.Query[models.SessionInput]{
+		Model: models.SessionInput{
+			Status:    -1,
+			DeletedAt: Now(),
+			DeletedBy: userId,
+		},
+	}, sessionId)
+}
+
+func (s *authService) LogoutAll(ctx context.Context) error {
+	userId := ctx.Value(models.UserKey).(models.User).Id
+
+	return s.sessionRepository.RevokeUserSessions(ctx, int(userId), 0, Now())
+}
+
+func (s *authService) ValidateSession(ctx context.Context, userId, sessionId int) error {
+	sessions, _, err := s.sessionRepository.Get(ctx, filter.Paging[filter.SessionFilter]{
+		IsActive: true,
+		Filter: filter.SessionFilter{
+			Id: sessionId,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if len(sessions) == 0 || sessions[0].UserId != userId {
+		return errors.New("session revoked")
+	}
+	if sessions[0].ExpiresAt.Before(Now()) {
+		return errors.New("session end")
+	}
+	return nil
+}
+
+// ForgotPassword mails a reset link to the active user owning the email. It doesn't tell
+// whether the email is registered, an unknown one succeeds without sending anything.
+func (s *authService) ForgotPassword(ctx context.Context, input models.ForgotPasswordRequest) error {
+	users, _, err := s.userRepository.Get(ctx, filter.Paging[filter.UserFilter]{
+		Page:     1,
+		Take:     1,
+		IsActive: true,
+		Filter: filter.UserFilter{
+			Email: input.Email,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if len(users) == 0 {
+		return nil
+	}
+	user := users[0]
+
+	// a reset token needs the same randomness as a refresh token
+	token, err := s.authRepository.GenerateRefreshToken()
+	if err != nil {
+		return err
+	}
+
+	now := Now()
+	err = s.passwordResetRepository.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.passwordResetRepository.RevokeUserResets(ctx, int(user.Id), now); err != nil {
+			return err
+		}
+		return s.passwordResetRepository.Create(ctx, models.Query[models.PasswordResetInput]{
+			Model: models.PasswordResetInput{
+				UserId:    int(user.Id),
+				TokenHash: s.authRepository.HashToken(token),
+				ExpiresAt: now.Add(models.PasswordResetTTL),
+				CreatedAt: now,
+				CreatedBy: user.Id,
+			},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	link := models.GetPasswordResetUrl() + "?token=" + url.QueryEscape(token)
+	return s.mailerRepository.Send(ctx, models.Mail{
+		To:      input.Email,
+		Subject: models.PasswordResetSubject,
+		Body:    fmt.Sprintf(models.PasswordResetBody, link),
+	})
+}
+
+// ResetPassword sets the password of the user the reset token was mailed to. The token works once,
+// and every session of the user is revoked since whoever had the old password may still be logged in.
+func (s *authService) ResetPassword(ctx context.Context, input models.ResetPasswordRequest) error {
+	resets, _, err := s.passwordResetRepository.Get(ctx, filter.Paging[filter.PasswordResetFilter]{
+		IsActive: true,
+		Filter: filter.PasswordResetFilter{
+			TokenHash: s.authRepository.HashToken(input.Token),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	now := Now()
+	if len(resets) == 0 || !resets[0].ExpiresAt.After(now) {
+		return models.ErrInvalidResetToken
+	}
+	reset := resets[0]
+
+	password, err := s.authRepository.HashPassword([]byte(input.Password))
+	if err != nil {
+		return err
+	}
+
+	return s.passwordResetRepository.Transaction(ctx, func(ctx context.Context) error {
+		// consuming guards against the same token being used twice concurrently
+		consumed, err := s.passwordResetRepository.Consume(ctx, int(reset.Id), now)
+		if err != nil {
+			return err
+		}
+		if !consumed {
+			return models.ErrInvalidResetToken
+		}
+		if err := s.passwordResetRepository.RevokeUserResets(ctx, reset.UserId, now); err != nil {
+			return err
+		}
+		err = s.userRepository.Update(ctx, models.Query[models.UserInput]{
+			Model: models.UserInput{
+				Password:  password,
+				UpdatedAt: now,
+				UpdatedBy: int64(reset.UserId),
+			},
+		}, reset.UserId)
+		if err != nil {
+			return err
+		}
+		return s.sessionRepository.RevokeUserSessions(ctx, reset.UserId, 0, now)
+	})
+}
+
+// ChangePassword sets a new password once the old one is confirmed, the other sessions
+// of the user are revoked and the current one stays logged in.
+func (s *authService) ChangePassword(ctx context.Context, input models.ChangePasswordRequest) error {
+	user := ctx.Value(models.UserKey).(models.User)
+	sessionId := ctx.Value(models.SessionKey).(int)
+
+	if err := s.authRepository.ComparePassword([]byte(user.Password), []byte(input.OldPassword)); err != nil {
+		return models.ErrWrongPassword
+	}
+
+	password, err := s.authRepository.HashPassword([]byte(input.NewPassword))
+	if err != nil {
+		return err
+	}
+
+	now := Now()
+	return s.userRepository.Transaction(ctx, func(ctx context.Context) error {
+		err := s.userRepository.Update(ctx, models.Query[models.UserInput]{
+			Model: models.UserInput{
+				Password:  password,
+				UpdatedAt: now,
+				UpdatedBy: user.Id,
+			},
+		}, int(user.Id))
+		if err != nil {
+			return err
+		}
+		return s.sessionRepository.RevokeUserSessions(ctx, int(user.Id), sessionId, now)
+	})
 }
@@ -5,6 +5,9 @@ import (
 	"DatingApp/src/filter"
 	"DatingApp/src/models"
 	mock_auth "DatingApp/src/repositories/mock/auth"
+	mock_mailer "DatingApp/src/repositories/mock/mailer"
+	mock_password_reset "DatingApp/src/repositories/mock/password_reset"
+	mock_session "DatingApp/src/repositories/mock/session"
 	mock_user "DatingApp/src/repositories/mock/user"
 	"DatingApp/src/services/auth"
 	"DatingApp/src/services/user"
@@ -15,6 +18,10 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func runTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 func Test_authService_Register(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -49,6 +56,16 @@ func Test_authService_Register(t *testing.T) {
 		mockfunc func(a args, mock mockfields)
 		wantErr  bool
 	}{
+		{
+			name: "invalid timezone",
+			args: args{
+				Input: models.Query[models.UserInput]{
+					Model: models.UserInput{Timezone: "Mars/Olympus"},
+				},
+			},
+			mockfunc: func(a args, mock mockfields) {},
+			wantErr:  true,
+		},
 		{
 			name: "get user error",
 			args: args{
@@ -114,6 +131,55 @@ func Test_authService_Register(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "email taken",
+			args: args{
+				Input: models.Query[models.UserInput]{
+					Model: models.UserInput{UserName: "test", Email: "test@mail.com"},
+				},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.user.EXPECT().Get(context.Background(), filter.Paging[filter.UserFilter]{
+					Page:   1,
+					Take:   1,
+					Filter: filter.UserFilter{UserName: "test"},
+				}).Return([]models.User{}, 0, nil)
+				mock.user.EXPECT().Get(context.Background(), filter.Paging[filter.UserFilter]{
+					Page:   1,
+					Take:   1,
+					Filter: filter.UserFilter{Email: "test@mail.com"},
+				}).Return([]models.User{{}}, 1, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "register user with email success",
+			args: args{
+				Input: models.Query[models.UserInput]{
+					Model: models.UserInput{UserName: "test", Email: "test@mail.com"},
+				},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.user.EXPECT().Get(context.Background(), filter.Paging[filter.UserFilter]{
+					Page:   1,
+					Take:   1,
+					Filter: filter.UserFilter{UserName: "test"},
+				}).Return([]models.User{}, 0, nil)
+				mock.user.EXPECT().Get(context.Background(), filter.Paging[filter.UserFilter]{
+					Page:   1,
+					Take:   1,
+					Filter: filter.UserFilter{Email: "test@mail.com"},
+				}).Return([]models.User{}, 0, nil)
+				mock.auth.EXPECT().HashPassword([]byte("")).Return("password", nil)
+				mock.user.EXPECT().Create(context.Background(), models.Query[models.UserInput]{
+					Model: models.UserInput{
+						UserName: "test",
+						Email:    "test@mail.com",
+						Password: "password",
+					},
+				}).Return(nil)
+			},
+		},
 		{
 			name: "register user success",
 			args: args{
@@ -157,35 +223,54 @@ func Test_authService_Login(t *testing.T) {
 
 	userRepo := mock_user.NewMockInterface(ctrl)
 	authRepo := mock_auth.NewMockInterface(ctrl)
+	sessionRepo := mock_session.NewMockInterface(ctrl)
 	type mockfields struct {
-		user *mock_user.MockInterface
-		auth *mock_auth.MockInterface
+		user    *mock_user.MockInterface
+		auth    *mock_auth.MockInterface
+		session *mock_session.MockInterface
 	}
 	mocks := mockfields{
-		user: userRepo,
-		auth: authRepo,
+		user:    userRepo,
+		auth:    authRepo,
+		session: sessionRepo,
 	}
 	params := auth.Param{
-		UserRepository: userRepo,
-		AuthRepository: authRepo,
+		UserRepository:    userRepo,
+		AuthRepository:    authRepo,
+		SessionRepository: sessionRepo,
 	}
 	service := auth.Init(params)
 	type args struct {
 		Input models.Login
 	}
 
+	mockTime := time.Date(2023, 12, 21, 0, 0, 0, 0, time.Local)
+	auth.Now = func() time.Time {
+		return mockTime
+	}
 	restoreAll := func() {
 		user.Now = time.Now
+		auth.Now = time.Now
 	}
 	defer restoreAll()
 
+	sessionInput := models.Query[models.SessionInput]{
+		Model: models.SessionInput{
+			UserId:           1,
+			RefreshTokenHash: "hash",
+			ExpiresAt:        mockTime.Add(time.Hour * 24 * 30),
+			CreatedAt:        mockTime,
+			CreatedBy:        1,
+		},
+	}
+
 	tests := []struct {
 		name      string
 		args      args
 		mockfunc  func(a args, mock mockfields)
 		wantErr   bool
 		wantUser  []models.User
-		wantToken string
+		wantToken models.Token
 	}{
 		{
 			name: "get user error",
@@ -216,8 +301,9 @@ func Test_authService_Login(t *testing.T) {
 			},
 			mockfunc: func(a args, mock mockfields) {
 				mock.user.EXPECT().Get(context.Background(), filter.Paging[filter.UserFilter]{
-					Page:   1,
-					Take:   1,
+					Page:     1,
+					Take:     1,
+					IsActive: true,
 					Filter: filter.UserFilter{},
 				}).Return([]models.User{
 					{},
@@ -227,6 +313,44 @@ func Test_authService_Login(t *testing.T) {
 			wantUser: []models.User{},
 			wantErr:  true,
 		},
+		{
+			name: "generate refresh token error",
+			args: args{
+				Input: models.Login{},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.user.EXPECT().Get(context.Background(), gomock.Any()).Return([]models.User{
+					{
+						Id:       1,
+						UserName: "test",
+					},
+				}, 1, nil)
+				mock.auth.EXPECT().ComparePassword([]byte(""), []byte("")).Return(nil)
+				mock.auth.EXPECT().GenerateRefreshToken().Return("", assert.AnError)
+			},
+			wantUser: []models.User{},
+			wantErr:  true,
+		},
+		{
+			name: "create session error",
+			args: args{
+				Input: models.Login{},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.user.EXPECT().Get(context.Background(), gomock.Any()).Return([]models.User{
+					{
+						Id:       1,
+						UserName: "test",
+					},
+				}, 1, nil)
+				mock.auth.EXPECT().ComparePassword([]byte(""), []byte("")).Return(nil)
+				mock.auth.EXPECT().GenerateRefreshToken().Return("refresh", nil)
+				mock.auth.EXPECT().HashToken("refresh").Return("hash")
+				mock.session.EXPECT().CreateSession(context.Background(), sessionInput).Return(0, assert.AnError)
+			},
+			wantUser: []models.User{},
+			wantErr:  true,
+		},
 		{
 			name: "generate token error",
 			args: args{
@@ -234,17 +358,22 @@ func Test_authService_Login(t *testing.T) {
 			},
 			mockfunc: func(a args, mock mockfields) {
 				mock.user.EXPECT().Get(context.Background(), filter.Paging[filter.UserFilter]{
-					Page:   1,
-					Take:   1,
+					Page:     1,
+					Take:     1,
+					IsActive: true,
 					Filter: filter.UserFilter{},
 				}).Return([]models.User{
 					{
 						Id:       1,
 						UserName: "test",
+						Role:     models.RoleUser,
 					},
 				}, 1, nil)
 				mock.auth.EXPECT().ComparePassword([]byte(""), []byte("")).Return(nil)
-				mock.auth.EXPECT().GenerateToken(1, "test").Return("", assert.AnError)
+				mock.auth.EXPECT().GenerateRefreshToken().Return("refresh", nil)
+				mock.auth.EXPECT().HashToken("refresh").Return("hash")
+				mock.session.EXPECT().CreateSession(context.Background(), sessionInput).Return(2, nil)
+				mock.auth.EXPECT().GenerateToken(1, 2, "user").Return("", assert.AnError)
 			},
 			wantUser: []models.User{},
 			wantErr:  true,
@@ -256,25 +385,31 @@ func Test_authService_Login(t *testing.T) {
 			},
 			mockfunc: func(a args, mock mockfields) {
 				mock.user.EXPECT().Get(context.Background(), filter.Paging[filter.UserFilter]{
-					Page:   1,
-					Take:   1,
+					Page:     1,
+					Take:     1,
+					IsActive: true,
 					Filter: filter.UserFilter{},
 				}).Return([]models.User{
 					{
 						Id:       1,
 						UserName: "test",
+						Role:     models.RoleUser,
 					},
 				}, 1, nil)
 				mock.auth.EXPECT().ComparePassword([]byte(""), []byte("")).Return(nil)
-				mock.auth.EXPECT().GenerateToken(1, "test").Return("token", nil)
+				mock.auth.EXPECT().GenerateRefreshToken().Return("refresh", nil)
+				mock.auth.EXPECT().HashToken("refresh").Return("hash")
+				mock.session.EXPECT().CreateSession(context.Background(), sessionInput).Return(2, nil)
+				mock.auth.EXPECT().GenerateToken(1, 2, "user").Return("token", nil)
 			},
 			wantUser: []models.User{
 				{
 					Id:       1,
 					UserName: "test",
+					Role:     models.RoleUser,
 				},
 			},
-			wantToken: "token",
+			wantToken: models.Token{AccessToken: "token", RefreshToken: "refresh"},
 		},
 	}
 	for _, tt := range tests {
@@ -283,7 +418,7 @@ func Test_authService_Login(t *testing.T) {
 
 			user, token, err := service.Login(context.Background(), tt.args.Input)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("auth.Register() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("auth.Login() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			assert.Equal(t, user, tt.wantUser)
@@ -291,3 +426,789 @@ func Test_authService_Login(t *testing.T) {
 		})
 	}
 }
+
+func Test_authService_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mock_user.NewMockInterface(ctrl)
+	authRepo := mock_auth.NewMockInterface(ctrl)
+	sessionRepo := mock_session.NewMockInterface(ctrl)
+	type mockfields struct {
+		user    *mock_user.MockInterface
+		auth    *mock_auth.MockInterface
+		session *mock_session.MockInterface
+	}
+	mocks := mockfields{
+		user:    userRepo,
+		auth:    authRepo,
+		session: sessionRepo,
+	}
+	params := auth.Param{
+		UserRepository:    userRepo,
+		AuthRepository:    authRepo,
+		SessionRepository: sessionRepo,
+	}
+	service := auth.Init(params)
+	type args struct {
+		Input models.RefreshToken
+	}
+
+	mockTime := time.Date(2023, 12, 21, 0, 0, 0, 0, time.Local)
+	auth.Now = func() time.Time {
+		return mockTime
+	}
+	restoreAll := func() {
+		auth.Now = time.Now
+	}
+	defer restoreAll()
+
+	paging := filter.Paging[filter.SessionFilter]{
+		IsActive: true,
+		Filter: filter.SessionFilter{
+			RefreshTokenHash: "oldhash",
+		},
+	}
+	userPaging := filter.Paging[filter.UserFilter]{
+		IsActive: true,
+		Filter: filter.UserFilter{
+			Id: 1,
+		},
+	}
+	session := models.Session{
+		Id:        2,
+		UserId:    1,
+		ExpiresAt: mockTime.Add(time.Hour),
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		mockfunc  func(a args, mock mockfields)
+		wantErr   bool
+		wantToken models.Token
+	}{
+		{
+			name: "get session error",
+			args: args{
+				Input: models.RefreshToken{RefreshToken: "old"},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.auth.EXPECT().HashToken("old").Return("oldhash")
+				mock.session.EXPECT().Get(context.Background(), paging).Return([]models.Session{}, 0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "session not found",
+			args: args{
+				Input: models.RefreshToken{RefreshToken: "old"},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.auth.EXPECT().HashToken("old").Return("oldhash")
+				mock.session.EXPECT().Get(context.Background(), paging).Return([]models.Session{}, 0, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "session expired",
+			args: args{
+				Input: models.RefreshToken{RefreshToken: "old"},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.auth.EXPECT().HashToken("old").Return("oldhash")
+				mock.session.EXPECT().Get(context.Background(), paging).Return([]models.Session{
+					{
+						Id:        2,
+						UserId:    1,
+						ExpiresAt: mockTime.Add(-time.Hour),
+					},
+				}, 1, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "user doesnt exists",
+			args: args{
+				Input: models.RefreshToken{RefreshToken: "old"},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.auth.EXPECT().HashToken("old").Return("oldhash")
+				mock.session.EXPECT().Get(context.Background(), paging).Return([]models.Session{session}, 1, nil)
+				mock.user.EXPECT().Get(context.Background(), userPaging).Return([]models.User{}, 0, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "refresh token already used",
+			args: args{
+				Input: models.RefreshToken{RefreshToken: "old"},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.auth.EXPECT().HashToken("old").Return("oldhash")
+				mock.session.EXPECT().Get(context.Background(), paging).Return([]models.Session{session}, 1, nil)
+				mock.user.EXPECT().Get(context.Background(), userPaging).Return([]models.User{{Id: 1, Role: models.RoleUser}}, 1, nil)
+				mock.auth.EXPECT().GenerateRefreshToken().Return("new", nil)
+				mock.auth.EXPECT().HashToken("new").Return("newhash")
+				mock.session.EXPECT().RotateRefreshToken(context.Background(), 2, "oldhash", "newhash", mockTime.Add(time.Hour*24*30)).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "refresh success",
+			args: args{
+				Input: models.RefreshToken{RefreshToken: "old"},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.auth.EXPECT().HashToken("old").Return("oldhash")
+				mock.session.EXPECT().Get(context.Background(), paging).Return([]models.Session{session}, 1, nil)
+				mock.user.EXPECT().Get(context.Background(), userPaging).Return([]models.User{{Id: 1, Role: models.RoleUser}}, 1, nil)
+				mock.auth.EXPECT().GenerateRefreshToken().Return("new", nil)
+				mock.auth.EXPECT().HashToken("new").Return("newhash")
+				mock.session.EXPECT().RotateRefreshToken(context.Background(), 2, "oldhash", "newhash", mockTime.Add(time.Hour*24*30)).Return(nil)
+				mock.auth.EXPECT().GenerateToken(1, 2, "user").Return("token", nil)
+			},
+			wantToken: models.Token{AccessToken: "token", RefreshToken: "new"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(tt.args, mocks)
+
+			token, err := service.Refresh(context.Background(), tt.args.Input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("auth.Refresh() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, token, tt.wantToken)
+		})
+	}
+}
+
+func Test_authService_Logout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sessionRepo := mock_session.NewMockInterface(ctrl)
+	type mockfields struct {
+		session *mock_session.MockInterface
+	}
+	mocks := mockfields{
+		session: sessionRepo,
+	}
+	params := auth.Param{
+		SessionRepository: sessionRepo,
+	}
+	service := auth.Init(params)
+
+	context := context.WithValue(context.WithValue(context.Background(), models.UserKey, models.User{Id: 1}), models.SessionKey, 2)
+
+	mockTime := time.Date(2023, 12, 21, 0, 0, 0, 0, time.Local)
+	auth.Now = func() time.Time {
+		return mockTime
+	}
+	restoreAll := func() {
+		auth.Now = time.Now
+	}
+	defer restoreAll()
+
+	tests := []struct {
+		name     string
+		mockfunc func(mock mockfields)
+		wantErr  bool
+	}{
+		{
+			name: "revoke session error",
+			mockfunc: func(mock mockfields) {
+				mock.session.EXPECT().Update(context, gomock.Any(), 2).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "logout success",
+			mockfunc: func(mock mockfields) {
+				mock.session.EXPECT().Update(context, models.Query[models.SessionInput]{
+					Model: models.SessionInput{
+						Status:    -1,
+						DeletedAt: mockTime,
+						DeletedBy: 1,
+					},
+				}, 2).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(mocks)
+
+			err := service.Logout(context)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("auth.Logout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_authService_LogoutAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sessionRepo := mock_session.NewMockInterface(ctrl)
+	type mockfields struct {
+		session *mock_session.MockInterface
+	}
+	mocks := mockfields{
+		session: sessionRepo,
+	}
+	params := auth.Param{
+		SessionRepository: sessionRepo,
+	}
+	service := auth.Init(params)
+
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	mockTime := time.Date(2023, 12, 21, 0, 0, 0, 0, time.Local)
+	auth.Now = func() time.Time {
+		return mockTime
+	}
+	restoreAll := func() {
+		auth.Now = time.Now
+	}
+	defer restoreAll()
+
+	tests := []struct {
+		name     string
+		mockfunc func(mock mockfields)
+		wantErr  bool
+	}{
+		{
+			name: "revoke sessions error",
+			mockfunc: func(mock mockfields) {
+				mock.session.EXPECT().RevokeUserSessions(context, 1, 0, mockTime).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "logout all success",
+			mockfunc: func(mock mockfields) {
+				mock.session.EXPECT().RevokeUserSessions(context, 1, 0, mockTime).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(mocks)
+
+			err := service.LogoutAll(context)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("auth.LogoutAll() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_authService_ValidateSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sessionRepo := mock_session.NewMockInterface(ctrl)
+	type mockfields struct {
+		session *mock_session.MockInterface
+	}
+	mocks := mockfields{
+		session: sessionRepo,
+	}
+	params := auth.Param{
+		SessionRepository: sessionRepo,
+	}
+	service := auth.Init(params)
+
+	mockTime := time.Date(2023, 12, 21, 0, 0, 0, 0, time.Local)
+	auth.Now = func() time.Time {
+		return mockTime
+	}
+	restoreAll := func() {
+		auth.Now = time.Now
+	}
+	defer restoreAll()
+
+	paging := filter.Paging[filter.SessionFilter]{
+		IsActive: true,
+		Filter: filter.SessionFilter{
+			Id: 2,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		mockfunc func(mock mockfields)
+		wantErr  bool
+	}{
+		{
+			name: "get session error",
+			mockfunc: func(mock mockfields) {
+				mock.session.EXPECT().Get(context.Background(), paging).Return([]models.Session{}, 0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "session revoked",
+			mockfunc: func(mock mockfields) {
+				mock.session.EXPECT().Get(context.Background(), paging).Return([]models.Session{}, 0, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "session belongs to another user",
+			mockfunc: func(mock mockfields) {
+				mock.session.EXPECT().Get(context.Background(), paging).Return([]models.Session{
+					{Id: 2, UserId: 3, ExpiresAt: mockTime.Add(time.Hour)},
+				}, 1, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "session expired",
+			mockfunc: func(mock mockfields) {
+				mock.session.EXPECT().Get(context.Background(), paging).Return([]models.Session{
+					{Id: 2, UserId: 1, ExpiresAt: mockTime.Add(-time.Hour)},
+				}, 1, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "session valid",
+			mockfunc: func(mock mockfields) {
+				mock.session.EXPECT().Get(context.Background(), paging).Return([]models.Session{
+					{Id: 2, UserId: 1, ExpiresAt: mockTime.Add(time.Hour)},
+				}, 1, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(mocks)
+
+			err := service.ValidateSession(context.Background(), 1, 2)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("auth.ValidateSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_authService_ForgotPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mock_user.NewMockInterface(ctrl)
+	authRepo := mock_auth.NewMockInterface(ctrl)
+	passwordResetRepo := mock_password_reset.NewMockInterface(ctrl)
+	mailerRepo := mock_mailer.NewMockInterface(ctrl)
+	type mockfields struct {
+		user          *mock_user.MockInterface
+		auth          *mock_auth.MockInterface
+		passwordReset *mock_password_reset.MockInterface
+		mailer        *mock_mailer.MockInterface
+	}
+	mocks := mockfields{
+		user:          userRepo,
+		auth:          authRepo,
+		passwordReset: passwordResetRepo,
+		mailer:        mailerRepo,
+	}
+	params := auth.Param{
+		UserRepository:          userRepo,
+		AuthRepository:          authRepo,
+		PasswordResetRepository: passwordResetRepo,
+		MailerRepository:        mailerRepo,
+	}
+	service := auth.Init(params)
+
+	context := context.Background()
+	input := models.ForgotPasswordRequest{Email: "test@mail.com"}
+	userFilter := filter.Paging[filter.UserFilter]{
+		Page:     1,
+		Take:     1,
+		IsActive: true,
+		Filter:   filter.UserFilter{Email: "test@mail.com"},
+	}
+	reset := models.Query[models.PasswordResetInput]{
+		Model: models.PasswordResetInput{
+			UserId:    1,
+			TokenHash: "hash",
+			ExpiresAt: time.Date(2023, 12, 21, 1, 0, 0, 0, time.Local),
+			CreatedAt: time.Date(2023, 12, 21, 0, 0, 0, 0, time.Local),
+			CreatedBy: 1,
+		},
+	}
+
+	mockTime := time.Date(2023, 12, 21, 0, 0, 0, 0, time.Local)
+	auth.Now = func() time.Time {
+		return mockTime
+	}
+	restoreAll := func() {
+		auth.Now = time.Now
+	}
+	defer restoreAll()
+
+	tests := []struct {
+		name     string
+		mockfunc func(mock mockfields)
+		wantErr  bool
+	}{
+		{
+			name: "get user error",
+			mockfunc: func(mock mockfields) {
+				mock.user.EXPECT().Get(context, userFilter).Return([]models.User{}, 0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown email sends nothing",
+			mockfunc: func(mock mockfields) {
+				mock.user.EXPECT().Get(context, userFilter).Return([]models.User{}, 0, nil)
+			},
+		},
+		{
+			name: "generate token error",
+			mockfunc: func(mock mockfields) {
+				mock.user.EXPECT().Get(context, userFilter).Return([]models.User{{Id: 1}}, 1, nil)
+				mock.auth.EXPECT().GenerateRefreshToken().Return("", assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "revoke resets error",
+			mockfunc: func(mock mockfields) {
+				mock.user.EXPECT().Get(context, userFilter).Return([]models.User{{Id: 1}}, 1, nil)
+				mock.auth.EXPECT().GenerateRefreshToken().Return("token", nil)
+				mock.passwordReset.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.passwordReset.EXPECT().RevokeUserResets(context, 1, mockTime).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "create reset error",
+			mockfunc: func(mock mockfields) {
+				mock.user.EXPECT().Get(context, userFilter).Return([]models.User{{Id: 1}}, 1, nil)
+				mock.auth.EXPECT().GenerateRefreshToken().Return("token", nil)
+				mock.passwordReset.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.passwordReset.EXPECT().RevokeUserResets(context, 1, mockTime).Return(nil)
+				mock.auth.EXPECT().HashToken("token").Return("hash")
+				mock.passwordReset.EXPECT().Create(context, reset).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "send mail error",
+			mockfunc: func(mock mockfields) {
+				mock.user.EXPECT().Get(context, userFilter).Return([]models.User{{Id: 1}}, 1, nil)
+				mock.auth.EXPECT().GenerateRefreshToken().Return("token", nil)
+				mock.passwordReset.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.passwordReset.EXPECT().RevokeUserResets(context, 1, mockTime).Return(nil)
+				mock.auth.EXPECT().HashToken("token").Return("hash")
+				mock.passwordReset.EXPECT().Create(context, reset).Return(nil)
+				mock.mailer.EXPECT().Send(context, gomock.Any()).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "forgot password success",
+			mockfunc: func(mock mockfields) {
+				mock.user.EXPECT().Get(context, userFilter).Return([]models.User{{Id: 1}}, 1, nil)
+				mock.auth.EXPECT().GenerateRefreshToken().Return("token", nil)
+				mock.passwordReset.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.passwordReset.EXPECT().RevokeUserResets(context, 1, mockTime).Return(nil)
+				mock.auth.EXPECT().HashToken("token").Return("hash")
+				mock.passwordReset.EXPECT().Create(context, reset).Return(nil)
+				mock.mailer.EXPECT().Send(context, gomock.Any()).DoAndReturn(func(ctx interface{}, mail models.Mail) error {
+					assert.Equal(t, "test@mail.com", mail.To)
+					assert.Equal(t, models.PasswordResetSubject, mail.Subject)
+					assert.Contains(t, mail.Body, models.GetPasswordResetUrl()+"?token=token")
+					return nil
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(mocks)
+
+			err := service.ForgotPassword(context, input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("auth.ForgotPassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_authService_ResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mock_user.NewMockInterface(ctrl)
+	authRepo := mock_auth.NewMockInterface(ctrl)
+	sessionRepo := mock_session.NewMockInterface(ctrl)
+	passwordResetRepo := mock_password_reset.NewMockInterface(ctrl)
+	type mockfields struct {
+		user          *mock_user.MockInterface
+		auth          *mock_auth.MockInterface
+		session       *mock_session.MockInterface
+		passwordReset *mock_password_reset.MockInterface
+	}
+	mocks := mockfields{
+		user:          userRepo,
+		auth:          authRepo,
+		session:       sessionRepo,
+		passwordReset: passwordResetRepo,
+	}
+	params := auth.Param{
+		UserRepository:          userRepo,
+		AuthRepository:          authRepo,
+		SessionRepository:       sessionRepo,
+		PasswordResetRepository: passwordResetRepo,
+	}
+	service := auth.Init(params)
+
+	context := context.Background()
+	input := models.ResetPasswordRequest{Token: "token", Password: "new password"}
+	resetFilter := filter.Paging[filter.PasswordResetFilter]{
+		IsActive: true,
+		Filter:   filter.PasswordResetFilter{TokenHash: "hash"},
+	}
+
+	mockTime := time.Date(2023, 12, 21, 0, 0, 0, 0, time.Local)
+	auth.Now = func() time.Time {
+		return mockTime
+	}
+	restoreAll := func() {
+		auth.Now = time.Now
+	}
+	defer restoreAll()
+
+	reset := models.PasswordReset{Id: 3, UserId: 1, ExpiresAt: mockTime.Add(time.Minute)}
+	userUpdate := models.Query[models.UserInput]{
+		Model: models.UserInput{
+			Password:  "hashed",
+			UpdatedAt: mockTime,
+			UpdatedBy: 1,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		mockfunc func(mock mockfields)
+		wantErr  error
+	}{
+		{
+			name: "get reset error",
+			mockfunc: func(mock mockfields) {
+				mock.auth.EXPECT().HashToken("token").Return("hash")
+				mock.passwordReset.EXPECT().Get(context, resetFilter).Return([]models.PasswordReset{}, 0, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "unknown token",
+			mockfunc: func(mock mockfields) {
+				mock.auth.EXPECT().HashToken("token").Return("hash")
+				mock.passwordReset.EXPECT().Get(context, resetFilter).Return([]models.PasswordReset{}, 0, nil)
+			},
+			wantErr: models.ErrInvalidResetToken,
+		},
+		{
+			name: "expired token",
+			mockfunc: func(mock mockfields) {
+				mock.auth.EXPECT().HashToken("token").Return("hash")
+				mock.passwordReset.EXPECT().Get(context, resetFilter).Return([]models.PasswordReset{{Id: 3, UserId: 1, ExpiresAt: mockTime}}, 1, nil)
+			},
+			wantErr: models.ErrInvalidResetToken,
+		},
+		{
+			name: "hash password error",
+			mockfunc: func(mock mockfields) {
+				mock.auth.EXPECT().HashToken("token").Return("hash")
+				mock.passwordReset.EXPECT().Get(context, resetFilter).Return([]models.PasswordReset{reset}, 1, nil)
+				mock.auth.EXPECT().HashPassword([]byte("new password")).Return("", assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "token already used",
+			mockfunc: func(mock mockfields) {
+				mock.auth.EXPECT().HashToken("token").Return("hash")
+				mock.passwordReset.EXPECT().Get(context, resetFilter).Return([]models.PasswordReset{reset}, 1, nil)
+				mock.auth.EXPECT().HashPassword([]byte("new password")).Return("hashed", nil)
+				mock.passwordReset.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.passwordReset.EXPECT().Consume(context, 3, mockTime).Return(false, nil)
+			},
+			wantErr: models.ErrInvalidResetToken,
+		},
+		{
+			name: "consume error",
+			mockfunc: func(mock mockfields) {
+				mock.auth.EXPECT().HashToken("token").Return("hash")
+				mock.passwordReset.EXPECT().Get(context, resetFilter).Return([]models.PasswordReset{reset}, 1, nil)
+				mock.auth.EXPECT().HashPassword([]byte("new password")).Return("hashed", nil)
+				mock.passwordReset.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.passwordReset.EXPECT().Consume(context, 3, mockTime).Return(false, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "update user error",
+			mockfunc: func(mock mockfields) {
+				mock.auth.EXPECT().HashToken("token").Return("hash")
+				mock.passwordReset.EXPECT().Get(context, resetFilter).Return([]models.PasswordReset{reset}, 1, nil)
+				mock.auth.EXPECT().HashPassword([]byte("new password")).Return("hashed", nil)
+				mock.passwordReset.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.passwordReset.EXPECT().Consume(context, 3, mockTime).Return(true, nil)
+				mock.passwordReset.EXPECT().RevokeUserResets(context, 1, mockTime).Return(nil)
+				mock.user.EXPECT().Update(context, userUpdate, 1).Return(assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "revoke sessions error",
+			mockfunc: func(mock mockfields) {
+				mock.auth.EXPECT().HashToken("token").Return("hash")
+				mock.passwordReset.EXPECT().Get(context, resetFilter).Return([]models.PasswordReset{reset}, 1, nil)
+				mock.auth.EXPECT().HashPassword([]byte("new password")).Return("hashed", nil)
+				mock.passwordReset.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.passwordReset.EXPECT().Consume(context, 3, mockTime).Return(true, nil)
+				mock.passwordReset.EXPECT().RevokeUserResets(context, 1, mockTime).Return(nil)
+				mock.user.EXPECT().Update(context, userUpdate, 1).Return(nil)
+				mock.session.EXPECT().RevokeUserSessions(context, 1, 0, mockTime).Return(assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "reset password success",
+			mockfunc: func(mock mockfields) {
+				mock.auth.EXPECT().HashToken("token").Return("hash")
+				mock.passwordReset.EXPECT().Get(context, resetFilter).Return([]models.PasswordReset{reset}, 1, nil)
+				mock.auth.EXPECT().HashPassword([]byte("new password")).Return("hashed", nil)
+				mock.passwordReset.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.passwordReset.EXPECT().Consume(context, 3, mockTime).Return(true, nil)
+				mock.passwordReset.EXPECT().RevokeUserResets(context, 1, mockTime).Return(nil)
+				mock.user.EXPECT().Update(context, userUpdate, 1).Return(nil)
+				mock.session.EXPECT().RevokeUserSessions(context, 1, 0, mockTime).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(mocks)
+
+			err := service.ResetPassword(context, input)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_authService_ChangePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mock_user.NewMockInterface(ctrl)
+	authRepo := mock_auth.NewMockInterface(ctrl)
+	sessionRepo := mock_session.NewMockInterface(ctrl)
+	type mockfields struct {
+		user    *mock_user.MockInterface
+		auth    *mock_auth.MockInterface
+		session *mock_session.MockInterface
+	}
+	mocks := mockfields{
+		user:    userRepo,
+		auth:    authRepo,
+		session: sessionRepo,
+	}
+	params := auth.Param{
+		UserRepository:    userRepo,
+		AuthRepository:    authRepo,
+		SessionRepository: sessionRepo,
+	}
+	service := auth.Init(params)
+
+	context := context.WithValue(context.WithValue(context.Background(), models.UserKey, models.User{Id: 1, Password: "old hash"}), models.SessionKey, 2)
+	input := models.ChangePasswordRequest{OldPassword: "old password", NewPassword: "new password"}
+
+	mockTime := time.Date(2023, 12, 21, 0, 0, 0, 0, time.Local)
+	auth.Now = func() time.Time {
+		return mockTime
+	}
+	restoreAll := func() {
+		auth.Now = time.Now
+	}
+	defer restoreAll()
+
+	userUpdate := models.Query[models.UserInput]{
+		Model: models.UserInput{
+			Password:  "hashed",
+			UpdatedAt: mockTime,
+			UpdatedBy: 1,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		mockfunc func(mock mockfields)
+		wantErr  error
+	}{
+		{
+			name: "wrong old password",
+			mockfunc: func(mock mockfields) {
+				mock.auth.EXPECT().ComparePassword([]byte("old hash"), []byte("old password")).Return(assert.AnError)
+			},
+			wantErr: models.ErrWrongPassword,
+		},
+		{
+			name: "hash password error",
+			mockfunc: func(mock mockfields) {
+				mock.auth.EXPECT().ComparePassword([]byte("old hash"), []byte("old password")).Return(nil)
+				mock.auth.EXPECT().HashPassword([]byte("new password")).Return("", assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "update user error",
+			mockfunc: func(mock mockfields) {
+				mock.auth.EXPECT().ComparePassword([]byte("old hash"), []byte("old password")).Return(nil)
+				mock.auth.EXPECT().HashPassword([]byte("new password")).Return("hashed", nil)
+				mock.user.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.user.EXPECT().Update(context, userUpdate, 1).Return(assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "revoke sessions error",
+			mockfunc: func(mock mockfields) {
+				mock.auth.EXPECT().ComparePassword([]byte("old hash"), []byte("old password")).Return(nil)
+				mock.auth.EXPECT().HashPassword([]byte("new password")).Return("hashed", nil)
+				mock.user.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.user.EXPECT().Update(context, userUpdate, 1).Return(nil)
+				mock.session.EXPECT().RevokeUserSessions(context, 1, 2, mockTime).Return(assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "change password success",
+			mockfunc: func(mock mockfields) {
+				mock.auth.EXPECT().ComparePassword([]byte("old hash"), []byte("old password")).Return(nil)
+				mock.auth.EXPECT().HashPassword([]byte("new password")).Return("hashed", nil)
+				mock.user.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.user.EXPECT().Update(context, userUpdate, 1).Return(nil)
+				mock.session.EXPECT().RevokeUserSessions(context, 1, 2, mockTime).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(mocks)
+
+			err := service.ChangePassword(context, input)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
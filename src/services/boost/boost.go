@@ -0,0 +1,143 @@
+package boost
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/boost"
+	"DatingApp/src/repositories/entitlement"
+	"DatingApp/src/repositories/user"
+	"context"
+	"time"
+)
+
+const (
+	boostFlag = "boost"
+)
+
+type Interface interface {
+	Boost(ctx context.Context) (models.Boost, error)
+	GetStats(ctx context.Context, id int) (models.BoostStats, error)
+}
+
+type boostService struct {
+	boostRepository       boost.Interface
+	entitlementRepository entitlement.Interface
+	userRepository        user.Interface
+}
+
+type Param struct {
+	BoostRepository       boost.Interface
+	EntitlementRepository entitlement.Interface
+	UserRepository        user.Interface
+}
+
+func Init(param Param) Interface {
+	return &boostService{
+		boostRepository:       param.BoostRepository,
+		entitlementRepository: param.EntitlementRepository,
+		userRepository:        param.UserRepository,
+	}
+}
+
+var Now = time.Now
+
+// Boost starts a boost of the user for models.BoostDuration, only one can run at a time
+// and only models.MaxBoostsPerDay can start in a day. The user is locked while checking
+// so two requests at once can't both start one.
+func (s *boostService) Boost(ctx context.Context) (models.Boost, error) {
+	user := ctx.Value(models.UserKey).(models.User)
+
+	now := Now()
+	canBoost, err := s.entitlementRepository.HasFeature(ctx, int(user.Id), boostFlag, now)
+	if err != nil {
+		return models.Boost{}, err
+	}
+	if !canBoost {
+		return models.Boost{}, models.ErrPremiumRequired
+	}
+
+	var id int
+	err = s.boostRepository.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.Lock(ctx, int(user.Id)); err != nil {
+			return err
+		}
+
+		active, err := s.boostRepository.HasActive(ctx, int(user.Id), now)
+		if err != nil {
+			return err
+		}
+		if active {
+			return models.ErrBoostActive
+		}
+
+		dayStart, _ := models.DayWindow(now, user.Location())
+		started, err := s.boostRepository.CountStarted(ctx, int(user.Id), dayStart)
+		if err != nil {
+			return err
+		}
+		if started >= models.MaxBoostsPerDay {
+			return models.ErrQuotaExceeded
+		}
+
+		id, err = s.boostRepository.CreateBoost(ctx, models.Query[models.BoostInput]{
+			Model: models.BoostInput{
+				UserId:    int(user.Id),
+				StartedAt: now,
+				EndsAt:    now.Add(models.BoostDuration),
+				CreatedAt: now,
+				CreatedBy: user.Id,
+			},
+		})
+		return err
+	})
+	if err != nil {
+		return models.Boost{}, err
+	}
+	return s.get(ctx, id, int(user.Id))
+}
+
+// GetStats tells how a boost of the user did, likes are the ones gained while it ran.
+func (s *boostService) GetStats(ctx context.Context, id int) (models.BoostStats, error) {
+	userId := int(ctx.Value(models.UserKey).(models.User).Id)
+
+	boost, err := s.get(ctx, id, userId)
+	if err != nil {
+		return models.BoostStats{}, err
+	}
+
+	likes, err := s.boostRepository.CountLikesGained(ctx, userId, boost.StartedAt, boost.EndsAt)
+	if err != nil {
+		return models.BoostStats{}, err
+	}
+
+	now := Now()
+	return models.BoostStats{
+		BoostId:     boost.Id,
+		StartedAt:   boost.StartedAt,
+		EndsAt:      boost.EndsAt,
+		Active:      !now.Before(boost.StartedAt) && now.Before(boost.EndsAt),
+		Impressions: boost.Impressions,
+		Likes:       likes.Likes,
+		SuperLikes:  likes.SuperLikes,
+	}, nil
+}
+
+// get returns the boost of id, models.ErrForbidden when it belongs to someone else than userId.
+func (s *boostService) get(ctx context.Context, id, userId int) (models.Boost, error) {
+	boosts, _, err := s.boostRepository.Get(ctx, filter.Paging[filter.BoostFilter]{
+		IsActive: true,
+		Filter: filter.BoostFilter{
+			Id: id,
+		},
+	})
+	if err != nil {
+		return models.Boost{}, err
+	}
+	if len(boosts) == 0 {
+		return models.Boost{}, models.ErrBoostNotFound
+	}
+	if boosts[0].UserId != userId {
+		return models.Boost{}, models.ErrForbidden
+	}
+	return boosts[0], nil
+}
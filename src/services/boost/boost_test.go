@@ -0,0 +1,289 @@
+package boost_test
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	mock_boost "DatingApp/src/repositories/mock/boost"
+	mock_entitlement "DatingApp/src/repositories/mock/entitlement"
+	mock_user "DatingApp/src/repositories/mock/user"
+	"DatingApp/src/services/boost"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func runTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func Test_boostService_Boost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	boostRepo := mock_boost.NewMockInterface(ctrl)
+	entitlementRepo := mock_entitlement.NewMockInterface(ctrl)
+	userRepo := mock_user.NewMockInterface(ctrl)
+	service := boost.Init(boost.Param{
+		BoostRepository:       boostRepo,
+		EntitlementRepository: entitlementRepo,
+		UserRepository:        userRepo,
+	})
+
+	mockTime := time.Date(2022, 5, 11, 10, 0, 0, 0, time.UTC)
+	dayStart := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	boost.Now = func() time.Time {
+		return mockTime
+	}
+	defer func() {
+		boost.Now = time.Now
+	}()
+
+	input := models.Query[models.BoostInput]{
+		Model: models.BoostInput{
+			UserId:    1,
+			StartedAt: mockTime,
+			EndsAt:    mockTime.Add(models.BoostDuration),
+			CreatedAt: mockTime,
+			CreatedBy: 1,
+		},
+	}
+	boostPaging := filter.Paging[filter.BoostFilter]{
+		IsActive: true,
+		Filter: filter.BoostFilter{
+			Id: 5,
+		},
+	}
+	mockLocked := func() {
+		boostRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+		userRepo.EXPECT().Lock(context, 1).Return(nil)
+	}
+	created := models.Boost{Id: 5, UserId: 1, StartedAt: mockTime, EndsAt: mockTime.Add(models.BoostDuration), Status: 1}
+
+	tests := []struct {
+		name     string
+		mockfunc func()
+		want     models.Boost
+		wantErr  error
+	}{
+		{
+			name: "has feature error",
+			mockfunc: func() {
+				entitlementRepo.EXPECT().HasFeature(context, 1, "boost", mockTime).Return(false, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "not premium",
+			mockfunc: func() {
+				entitlementRepo.EXPECT().HasFeature(context, 1, "boost", mockTime).Return(false, nil)
+			},
+			wantErr: models.ErrPremiumRequired,
+		},
+		{
+			name: "lock user error",
+			mockfunc: func() {
+				entitlementRepo.EXPECT().HasFeature(context, 1, "boost", mockTime).Return(true, nil)
+				boostRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				userRepo.EXPECT().Lock(context, 1).Return(assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "has active error",
+			mockfunc: func() {
+				entitlementRepo.EXPECT().HasFeature(context, 1, "boost", mockTime).Return(true, nil)
+				mockLocked()
+				boostRepo.EXPECT().HasActive(context, 1, mockTime).Return(false, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "boost already active",
+			mockfunc: func() {
+				entitlementRepo.EXPECT().HasFeature(context, 1, "boost", mockTime).Return(true, nil)
+				mockLocked()
+				boostRepo.EXPECT().HasActive(context, 1, mockTime).Return(true, nil)
+			},
+			wantErr: models.ErrBoostActive,
+		},
+		{
+			name: "count started error",
+			mockfunc: func() {
+				entitlementRepo.EXPECT().HasFeature(context, 1, "boost", mockTime).Return(true, nil)
+				mockLocked()
+				boostRepo.EXPECT().HasActive(context, 1, mockTime).Return(false, nil)
+				boostRepo.EXPECT().CountStarted(context, 1, dayStart).Return(0, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "boosted enough today",
+			mockfunc: func() {
+				entitlementRepo.EXPECT().HasFeature(context, 1, "boost", mockTime).Return(true, nil)
+				mockLocked()
+				boostRepo.EXPECT().HasActive(context, 1, mockTime).Return(false, nil)
+				boostRepo.EXPECT().CountStarted(context, 1, dayStart).Return(models.MaxBoostsPerDay, nil)
+			},
+			wantErr: models.ErrQuotaExceeded,
+		},
+		{
+			name: "create boost error",
+			mockfunc: func() {
+				entitlementRepo.EXPECT().HasFeature(context, 1, "boost", mockTime).Return(true, nil)
+				mockLocked()
+				boostRepo.EXPECT().HasActive(context, 1, mockTime).Return(false, nil)
+				boostRepo.EXPECT().CountStarted(context, 1, dayStart).Return(0, nil)
+				boostRepo.EXPECT().CreateBoost(context, input).Return(0, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "get boost error",
+			mockfunc: func() {
+				entitlementRepo.EXPECT().HasFeature(context, 1, "boost", mockTime).Return(true, nil)
+				mockLocked()
+				boostRepo.EXPECT().HasActive(context, 1, mockTime).Return(false, nil)
+				boostRepo.EXPECT().CountStarted(context, 1, dayStart).Return(0, nil)
+				boostRepo.EXPECT().CreateBoost(context, input).Return(5, nil)
+				boostRepo.EXPECT().Get(context, boostPaging).Return([]models.Boost{}, 0, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "boost success",
+			mockfunc: func() {
+				entitlementRepo.EXPECT().HasFeature(context, 1, "boost", mockTime).Return(true, nil)
+				mockLocked()
+				boostRepo.EXPECT().HasActive(context, 1, mockTime).Return(false, nil)
+				boostRepo.EXPECT().CountStarted(context, 1, dayStart).Return(0, nil)
+				boostRepo.EXPECT().CreateBoost(context, input).Return(5, nil)
+				boostRepo.EXPECT().Get(context, boostPaging).Return([]models.Boost{created}, 1, nil)
+			},
+			want: created,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc()
+
+			result, err := service.Boost(context)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func Test_boostService_GetStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	boostRepo := mock_boost.NewMockInterface(ctrl)
+	service := boost.Init(boost.Param{
+		BoostRepository: boostRepo,
+	})
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	endsAt := mockTime.Add(models.BoostDuration)
+	defer func() {
+		boost.Now = time.Now
+	}()
+
+	boostPaging := filter.Paging[filter.BoostFilter]{
+		IsActive: true,
+		Filter: filter.BoostFilter{
+			Id: 5,
+		},
+	}
+	found := models.Boost{Id: 5, UserId: 1, StartedAt: mockTime, EndsAt: endsAt, Impressions: 12, Status: 1}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		mockfunc func()
+		want     models.BoostStats
+		wantErr  error
+	}{
+		{
+			name: "get boost error",
+			now:  mockTime,
+			mockfunc: func() {
+				boostRepo.EXPECT().Get(context, boostPaging).Return([]models.Boost{}, 0, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "missing boost",
+			now:  mockTime,
+			mockfunc: func() {
+				boostRepo.EXPECT().Get(context, boostPaging).Return([]models.Boost{}, 0, nil)
+			},
+			wantErr: models.ErrBoostNotFound,
+		},
+		{
+			name: "boost of someone else",
+			now:  mockTime,
+			mockfunc: func() {
+				boostRepo.EXPECT().Get(context, boostPaging).Return([]models.Boost{{Id: 5, UserId: 2}}, 1, nil)
+			},
+			wantErr: models.ErrForbidden,
+		},
+		{
+			name: "count likes gained error",
+			now:  mockTime,
+			mockfunc: func() {
+				boostRepo.EXPECT().Get(context, boostPaging).Return([]models.Boost{found}, 1, nil)
+				boostRepo.EXPECT().CountLikesGained(context, 1, mockTime, endsAt).Return(models.LikesGained{}, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "stats of a running boost",
+			now:  mockTime.Add(10 * time.Minute),
+			mockfunc: func() {
+				boostRepo.EXPECT().Get(context, boostPaging).Return([]models.Boost{found}, 1, nil)
+				boostRepo.EXPECT().CountLikesGained(context, 1, mockTime, endsAt).Return(models.LikesGained{Likes: 3, SuperLikes: 1}, nil)
+			},
+			want: models.BoostStats{
+				BoostId:     5,
+				StartedAt:   mockTime,
+				EndsAt:      endsAt,
+				Active:      true,
+				Impressions: 12,
+				Likes:       3,
+				SuperLikes:  1,
+			},
+		},
+		{
+			name: "stats of an ended boost",
+			now:  endsAt,
+			mockfunc: func() {
+				boostRepo.EXPECT().Get(context, boostPaging).Return([]models.Boost{found}, 1, nil)
+				boostRepo.EXPECT().CountLikesGained(context, 1, mockTime, endsAt).Return(models.LikesGained{Likes: 3}, nil)
+			},
+			want: models.BoostStats{
+				BoostId:     5,
+				StartedAt:   mockTime,
+				EndsAt:      endsAt,
+				Impressions: 12,
+				Likes:       3,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			boost.Now = func() time.Time {
+				return tt.now
+			}
+			tt.mockfunc()
+
+			stats, err := service.GetStats(context, 5)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, stats)
+		})
+	}
+}
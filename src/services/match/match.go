@@ -0,0 +1,74 @@
+package match
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	match "DatingApp/src/repositories/match"
+	"DatingApp/src/services/photo"
+	"context"
+	"errors"
+	"time"
+)
+
+type Interface interface {
+	Delete(ctx context.Context, id int) error
+	Get(ctx context.Context, paging filter.Paging[filter.MatchFilter]) ([]models.MatchedUser, int, error)
+}
+
+type matchService struct {
+	matchRepository match.Interface
+	photoService    photo.Interface
+}
+
+type Param struct {
+	MatchRepository match.Interface
+	PhotoService    photo.Interface
+}
+
+func Init(param Param) Interface {
+	return &matchService{
+		matchRepository: param.MatchRepository,
+		photoService:    param.PhotoService,
+	}
+}
+
+var Now = time.Now
+
+func (s *matchService) Delete(ctx context.Context, id int) error {
+	userId := ctx.Value(models.UserKey).(models.User).Id
+
+	matches, _, err := s.matchRepository.Get(ctx, filter.Paging[filter.MatchFilter]{
+		IsActive: true,
+		Filter: filter.MatchFilter{
+			Id: id,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if len(matches) == 0 || (matches[0].UserId != int(userId) && matches[0].MatchedUserId != int(userId)) {
+		return errors.New("match doesnt exists")
+	}
+
+	input := models.Query[models.MatchInput]{
+		Model: models.MatchInput{
+			Status:    -1,
+			DeletedAt: Now(),
+			DeletedBy: userId,
+		},
+	}
+
+	return s.matchRepository.Update(ctx, input, id)
+}
+
+func (s *matchService) Get(ctx context.Context, paging filter.Paging[filter.MatchFilter]) ([]models.MatchedUser, int, error) {
+	userId := ctx.Value(models.UserKey).(models.User).Id
+	matches, count, err := s.matchRepository.GetUserMatches(ctx, int(userId), paging)
+	if err != nil {
+		return matches, count, err
+	}
+	for i := range matches {
+		matches[i].Image = s.photoService.ImageUrl(matches[i].Image)
+	}
+	return matches, count, nil
+}
@@ -0,0 +1,224 @@
+package match_test
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	mock_match "DatingApp/src/repositories/mock/match"
+	mock_storage "DatingApp/src/repositories/mock/storage"
+	match "DatingApp/src/services/match"
+	"DatingApp/src/services/photo"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_matchService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	matchRepo := mock_match.NewMockInterface(ctrl)
+	type mockfields struct {
+		match *mock_match.MockInterface
+	}
+	mocks := mockfields{
+		match: matchRepo,
+	}
+	params := match.Param{
+		MatchRepository: matchRepo,
+	}
+	service := match.Init(params)
+	type args struct {
+		Id int
+	}
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.Local)
+	match.Now = func() time.Time {
+		return mockTime
+	}
+
+	restoreAll := func() {
+		match.Now = time.Now
+	}
+	defer restoreAll()
+
+	getPaging := filter.Paging[filter.MatchFilter]{
+		IsActive: true,
+		Filter: filter.MatchFilter{
+			Id: 1,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		mockfunc func(a args, mock mockfields)
+		wantErr  bool
+	}{
+		{
+			name: "get match error",
+			args: args{
+				Id: 1,
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.match.EXPECT().Get(context, getPaging).Return([]models.Match{}, 0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "match not found",
+			args: args{
+				Id: 1,
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.match.EXPECT().Get(context, getPaging).Return([]models.Match{}, 0, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "match belongs to other users",
+			args: args{
+				Id: 1,
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.match.EXPECT().Get(context, getPaging).Return([]models.Match{{Id: 1, UserId: 2, MatchedUserId: 3}}, 1, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "delete match error",
+			args: args{
+				Id: 1,
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.match.EXPECT().Get(context, getPaging).Return([]models.Match{{Id: 1, UserId: 1, MatchedUserId: 2}}, 1, nil)
+				mock.match.EXPECT().Update(context, models.Query[models.MatchInput]{
+					Model: models.MatchInput{
+						DeletedBy: context.Value(models.UserKey).(models.User).Id,
+						DeletedAt: mockTime,
+						Status:    -1,
+					},
+				}, 1).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "delete match success",
+			args: args{
+				Id: 1,
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.match.EXPECT().Get(context, getPaging).Return([]models.Match{{Id: 1, UserId: 2, MatchedUserId: 1}}, 1, nil)
+				mock.match.EXPECT().Update(context, models.Query[models.MatchInput]{
+					Model: models.MatchInput{
+						DeletedBy: context.Value(models.UserKey).(models.User).Id,
+						DeletedAt: mockTime,
+						Status:    -1,
+					},
+				}, 1).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(tt.args, mocks)
+
+			err := service.Delete(context, tt.args.Id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("match.Delete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func Test_matchService_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	matchRepo := mock_match.NewMockInterface(ctrl)
+	type mockfields struct {
+		match *mock_match.MockInterface
+	}
+	mocks := mockfields{
+		match: matchRepo,
+	}
+	storageRepo := mock_storage.NewMockInterface(ctrl)
+	params := match.Param{
+		MatchRepository: matchRepo,
+		PhotoService:    photo.Init(photo.Param{StorageRepository: storageRepo}),
+	}
+	service := match.Init(params)
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	photo.Now = func() time.Time {
+		return mockTime
+	}
+
+	restoreAll := func() {
+		photo.Now = time.Now
+	}
+	defer restoreAll()
+
+	stored, signed := "storage://photos/2/abc", "https://app.example.com/photos/2/abc/medium.jpg"
+	type args struct {
+		Paging filter.Paging[filter.MatchFilter]
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		mockfunc  func(a args, mock mockfields)
+		want      []models.MatchedUser
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name: "get match error",
+			args: args{
+				filter.Paging[filter.MatchFilter]{},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.match.EXPECT().GetUserMatches(context, 1, filter.Paging[filter.MatchFilter]{}).Return([]models.MatchedUser{}, 0, assert.AnError)
+			},
+			want:      []models.MatchedUser{},
+			wantCount: 0,
+			wantErr:   true,
+		},
+		{
+			name: "get match success",
+			args: args{
+				filter.Paging[filter.MatchFilter]{},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.match.EXPECT().GetUserMatches(context, 1, filter.Paging[filter.MatchFilter]{}).Return([]models.MatchedUser{
+					{Image: &stored},
+					{},
+				}, 2, nil)
+				storageRepo.EXPECT().SignedUrl("photos/2/abc/medium.jpg", mockTime.Add(2*models.PhotoUrlTTL)).Return(signed)
+			},
+			want: []models.MatchedUser{
+				{Image: &signed},
+				{},
+			},
+			wantCount: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(tt.args, mocks)
+
+			matches, count, err := service.Get(context, tt.args.Paging)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("match.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, matches)
+			assert.Equal(t, tt.wantCount, count)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package message
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/broker"
+	"DatingApp/src/repositories/match"
+	"DatingApp/src/repositories/message"
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	maxMessageTake = 100
+)
+
+type Interface interface {
+	GetConversations(ctx context.Context, paging filter.Paging[filter.MatchFilter]) ([]models.Conversation, int, error)
+	GetMessages(ctx context.Context, matchId int, cursor filter.Cursor) ([]models.Message, int64, error)
+	Send(ctx context.Context, matchId int, input models.Query[models.MessageInput]) error
+	Read(ctx context.Context, matchId int) error
+}
+
+type messageService struct {
+	messageRepository message.Interface
+	matchRepository   match.Interface
+	brokerRepository  broker.Interface
+}
+
+type Param struct {
+	MessageRepository message.Interface
+	MatchRepository   match.Interface
+	BrokerRepository  broker.Interface
+}
+
+func Init(param Param) Interface {
+	return &messageService{
+		messageRepository: param.MessageRepository,
+		matchRepository:   param.MatchRepository,
+		brokerRepository:  param.BrokerRepository,
+	}
+}
+
+var Now = time.Now
+
+func (s *messageService) GetConversations(ctx context.Context, paging filter.Paging[filter.MatchFilter]) ([]models.Conversation, int, error) {
+	userId := int(ctx.Value(models.UserKey).(models.User).Id)
+	conversations := []models.Conversation{}
+
+	matches, count, err := s.matchRepository.GetUserMatches(ctx, userId, paging)
+	if err != nil {
+		return conversations, count, err
+	}
+
+	unreadCounts, err := s.messageRepository.GetUnreadCounts(ctx, userId)
+	if err != nil {
+		return conversations, count, err
+	}
+
+	for _, match := range matches {
+		conversations = append(conversations, models.Conversation{
+			MatchedUser: match,
+			UnreadCount: unreadCounts[int(match.MatchId)],
+		})
+	}
+	return conversations, count, nil
+}
+
+func (s *messageService) GetMessages(ctx context.Context, matchId int, cursor filter.Cursor) ([]models.Message, int64, error) {
+	userId := int(ctx.Value(models.UserKey).(models.User).Id)
+	if _, err := s.getPartnerId(ctx, matchId, userId); err != nil {
+		return []models.Message{}, 0, err
+	}
+
+	if cursor.Take <= 0 || cursor.Take > maxMessageTake {
+		cursor.Take = maxMessageTake
+	}
+
+	messages, err := s.messageRepository.GetConversation(ctx, matchId, cursor)
+	if err != nil {
+		return messages, 0, err
+	}
+
+	var nextCursor int64
+	if len(messages) > 0 && len(messages) == cursor.Take {
+		nextCursor = messages[len(messages)-1].Id
+	}
+	return messages, nextCursor, nil
+}
+
+func (s *messageService) Send(ctx context.Context, matchId int, input models.Query[models.MessageInput]) error {
+	userId := int(ctx.Value(models.UserKey).(models.User).Id)
+	if input.Model.Content == "" {
+		return errors.New("message content is empty")
+	}
+
+	partnerId, err := s.getPartnerId(ctx, matchId, userId)
+	if err != nil {
+		return err
+	}
+
+	input.Model.MatchId = matchId
+	input.Model.SenderId = userId
+	input.Model.ReceiverId = partnerId
+	input.Model.CreatedAt = Now()
+	input.Model.CreatedBy = int64(userId)
+
+	if err := s.messageRepository.Create(ctx, input); err != nil {
+		return err
+	}
+
+	// notification is best effort, the message is already stored
+	s.brokerRepository.Publish(ctx, models.Event{
+		UserId: partnerId,
+		Type:   models.EventNewMessage,
+		Data: models.EventMessage{
+			MatchId:  matchId,
+			SenderId: userId,
+			Content:  input.Model.Content,
+		},
+		CreatedAt: input.Model.CreatedAt,
+	})
+	return nil
+}
+
+func (s *messageService) Read(ctx context.Context, matchId int) error {
+	userId := int(ctx.Value(models.UserKey).(models.User).Id)
+	if _, err := s.getPartnerId(ctx, matchId, userId); err != nil {
+		return err
+	}
+
+	return s.messageRepository.MarkAsRead(ctx, matchId, userId, Now())
+}
+
+// getPartnerId returns the other user of an active match the user is part of.
+func (s *messageService) getPartnerId(ctx context.Context, matchId, userId int) (int, error) {
+	matches, _, err := s.matchRepository.Get(ctx, filter.Paging[filter.MatchFilter]{
+		IsActive: true,
+		Filter: filter.MatchFilter{
+			Id: matchId,
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(matches) == 0 {
+		return 0, errors.New("match doesnt exists")
+	}
+
+	match := matches[0]
+	switch userId {
+	case match.UserId:
+		return match.MatchedUserId, nil
+	case match.MatchedUserId:
+		return match.UserId, nil
+	}
+	return 0, errors.New("match doesnt exists")
+}
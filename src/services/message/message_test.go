@@ -0,0 +1,416 @@
+package message_test
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	mock_broker "DatingApp/src/repositories/mock/broker"
+	mock_match "DatingApp/src/repositories/mock/match"
+	mock_message "DatingApp/src/repositories/mock/message"
+	message "DatingApp/src/services/message"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockfields struct {
+	message *mock_message.MockInterface
+	match   *mock_match.MockInterface
+	broker  *mock_broker.MockInterface
+}
+
+func matchPaging(id int) filter.Paging[filter.MatchFilter] {
+	return filter.Paging[filter.MatchFilter]{
+		IsActive: true,
+		Filter: filter.MatchFilter{
+			Id: id,
+		},
+	}
+}
+
+func Test_messageService_Send(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	mocks := mockfields{
+		message: mock_message.NewMockInterface(ctrl),
+		match:   mock_match.NewMockInterface(ctrl),
+		broker:  mock_broker.NewMockInterface(ctrl),
+	}
+	service := message.Init(message.Param{
+		MessageRepository: mocks.message,
+		MatchRepository:   mocks.match,
+		BrokerRepository:  mocks.broker,
+	})
+	type args struct {
+		MatchId int
+		Input   models.Query[models.MessageInput]
+	}
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.Local)
+	message.Now = func() time.Time {
+		return mockTime
+	}
+
+	restoreAll := func() {
+		message.Now = time.Now
+	}
+	defer restoreAll()
+
+	tests := []struct {
+		name     string
+		args     args
+		mockfunc func(a args, mock mockfields)
+		wantErr  bool
+	}{
+		{
+			name: "empty content",
+			args: args{
+				MatchId: 1,
+			},
+			mockfunc: func(a args, mock mockfields) {},
+			wantErr:  true,
+		},
+		{
+			name: "get match error",
+			args: args{
+				MatchId: 1,
+				Input:   models.Query[models.MessageInput]{Model: models.MessageInput{Content: "hi"}},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.match.EXPECT().Get(context, matchPaging(1)).Return([]models.Match{}, 0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "no active match",
+			args: args{
+				MatchId: 1,
+				Input:   models.Query[models.MessageInput]{Model: models.MessageInput{Content: "hi"}},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.match.EXPECT().Get(context, matchPaging(1)).Return([]models.Match{}, 0, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "not part of match",
+			args: args{
+				MatchId: 1,
+				Input:   models.Query[models.MessageInput]{Model: models.MessageInput{Content: "hi"}},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.match.EXPECT().Get(context, matchPaging(1)).Return([]models.Match{{Id: 1, UserId: 2, MatchedUserId: 3}}, 1, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "create message error",
+			args: args{
+				MatchId: 1,
+				Input:   models.Query[models.MessageInput]{Model: models.MessageInput{Content: "hi"}},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.match.EXPECT().Get(context, matchPaging(1)).Return([]models.Match{{Id: 1, UserId: 1, MatchedUserId: 3}}, 1, nil)
+				mock.message.EXPECT().Create(context, models.Query[models.MessageInput]{
+					Model: models.MessageInput{
+						MatchId:    1,
+						SenderId:   1,
+						ReceiverId: 3,
+						Content:    "hi",
+						CreatedAt:  mockTime,
+						CreatedBy:  1,
+					},
+				}).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "create message success",
+			args: args{
+				MatchId: 1,
+				Input:   models.Query[models.MessageInput]{Model: models.MessageInput{Content: "hi"}},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.match.EXPECT().Get(context, matchPaging(1)).Return([]models.Match{{Id: 1, UserId: 3, MatchedUserId: 1}}, 1, nil)
+				mock.message.EXPECT().Create(context, models.Query[models.MessageInput]{
+					Model: models.MessageInput{
+						MatchId:    1,
+						SenderId:   1,
+						ReceiverId: 3,
+						Content:    "hi",
+						CreatedAt:  mockTime,
+						CreatedBy:  1,
+					},
+				}).Return(nil)
+				mock.broker.EXPECT().Publish(context, models.Event{
+					UserId: 3,
+					Type:   models.EventNewMessage,
+					Data: models.EventMessage{
+						MatchId:  1,
+						SenderId: 1,
+						Content:  "hi",
+					},
+					CreatedAt: mockTime,
+				}).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(tt.args, mocks)
+
+			err := service.Send(context, tt.args.MatchId, tt.args.Input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("message.Send() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func Test_messageService_GetMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	mocks := mockfields{
+		message: mock_message.NewMockInterface(ctrl),
+		match:   mock_match.NewMockInterface(ctrl),
+		broker:  mock_broker.NewMockInterface(ctrl),
+	}
+	service := message.Init(message.Param{
+		MessageRepository: mocks.message,
+		MatchRepository:   mocks.match,
+		BrokerRepository:  mocks.broker,
+	})
+	type args struct {
+		MatchId int
+		Cursor  filter.Cursor
+	}
+
+	tests := []struct {
+		name           string
+		args           args
+		mockfunc       func(a args, mock mockfields)
+		want           []models.Message
+		wantNextCursor int64
+		wantErr        bool
+	}{
+		{
+			name: "not part of match",
+			args: args{
+				MatchId: 1,
+				Cursor:  filter.Cursor{Take: 2},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.match.EXPECT().Get(context, matchPaging(1)).Return([]models.Match{{Id: 1, UserId: 2, MatchedUserId: 3}}, 1, nil)
+			},
+			want:    []models.Message{},
+			wantErr: true,
+		},
+		{
+			name: "get conversation error",
+			args: args{
+				MatchId: 1,
+				Cursor:  filter.Cursor{Take: 2},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.match.EXPECT().Get(context, matchPaging(1)).Return([]models.Match{{Id: 1, UserId: 1, MatchedUserId: 3}}, 1, nil)
+				mock.message.EXPECT().GetConversation(context, 1, filter.Cursor{Take: 2}).Return([]models.Message{}, assert.AnError)
+			},
+			want:    []models.Message{},
+			wantErr: true,
+		},
+		{
+			name: "get conversation last page",
+			args: args{
+				MatchId: 1,
+				Cursor:  filter.Cursor{Take: 2},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.match.EXPECT().Get(context, matchPaging(1)).Return([]models.Match{{Id: 1, UserId: 1, MatchedUserId: 3}}, 1, nil)
+				mock.message.EXPECT().GetConversation(context, 1, filter.Cursor{Take: 2}).Return([]models.Message{{Id: 5}}, nil)
+			},
+			want: []models.Message{{Id: 5}},
+		},
+		{
+			name: "get conversation with next cursor",
+			args: args{
+				MatchId: 1,
+				Cursor:  filter.Cursor{Take: 2},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.match.EXPECT().Get(context, matchPaging(1)).Return([]models.Match{{Id: 1, UserId: 1, MatchedUserId: 3}}, 1, nil)
+				mock.message.EXPECT().GetConversation(context, 1, filter.Cursor{Take: 2}).Return([]models.Message{{Id: 5}, {Id: 4}}, nil)
+			},
+			want:           []models.Message{{Id: 5}, {Id: 4}},
+			wantNextCursor: 4,
+		},
+		{
+			name: "take over limit",
+			args: args{
+				MatchId: 1,
+				Cursor:  filter.Cursor{Take: 1000},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.match.EXPECT().Get(context, matchPaging(1)).Return([]models.Match{{Id: 1, UserId: 1, MatchedUserId: 3}}, 1, nil)
+				mock.message.EXPECT().GetConversation(context, 1, filter.Cursor{Take: 100}).Return([]models.Message{}, nil)
+			},
+			want: []models.Message{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(tt.args, mocks)
+
+			messages, nextCursor, err := service.GetMessages(context, tt.args.MatchId, tt.args.Cursor)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("message.GetMessages() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, messages)
+			assert.Equal(t, tt.wantNextCursor, nextCursor)
+		})
+	}
+}
+
+func Test_messageService_GetConversations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	mocks := mockfields{
+		message: mock_message.NewMockInterface(ctrl),
+		match:   mock_match.NewMockInterface(ctrl),
+		broker:  mock_broker.NewMockInterface(ctrl),
+	}
+	service := message.Init(message.Param{
+		MessageRepository: mocks.message,
+		MatchRepository:   mocks.match,
+		BrokerRepository:  mocks.broker,
+	})
+	paging := filter.Paging[filter.MatchFilter]{Page: 1, Take: 10}
+
+	tests := []struct {
+		name      string
+		mockfunc  func(mock mockfields)
+		want      []models.Conversation
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name: "get matches error",
+			mockfunc: func(mock mockfields) {
+				mock.match.EXPECT().GetUserMatches(context, 1, paging).Return([]models.MatchedUser{}, 0, assert.AnError)
+			},
+			want:    []models.Conversation{},
+			wantErr: true,
+		},
+		{
+			name: "get unread counts error",
+			mockfunc: func(mock mockfields) {
+				mock.match.EXPECT().GetUserMatches(context, 1, paging).Return([]models.MatchedUser{{MatchId: 3}}, 1, nil)
+				mock.message.EXPECT().GetUnreadCounts(context, 1).Return(map[int]int{}, assert.AnError)
+			},
+			want:      []models.Conversation{},
+			wantCount: 1,
+			wantErr:   true,
+		},
+		{
+			name: "get conversations success",
+			mockfunc: func(mock mockfields) {
+				mock.match.EXPECT().GetUserMatches(context, 1, paging).Return([]models.MatchedUser{{MatchId: 3}, {MatchId: 4}}, 2, nil)
+				mock.message.EXPECT().GetUnreadCounts(context, 1).Return(map[int]int{3: 5}, nil)
+			},
+			want: []models.Conversation{
+				{MatchedUser: models.MatchedUser{MatchId: 3}, UnreadCount: 5},
+				{MatchedUser: models.MatchedUser{MatchId: 4}},
+			},
+			wantCount: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(mocks)
+
+			conversations, count, err := service.GetConversations(context, paging)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("message.GetConversations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, conversations)
+			assert.Equal(t, tt.wantCount, count)
+		})
+	}
+}
+
+func Test_messageService_Read(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	mocks := mockfields{
+		message: mock_message.NewMockInterface(ctrl),
+		match:   mock_match.NewMockInterface(ctrl),
+		broker:  mock_broker.NewMockInterface(ctrl),
+	}
+	service := message.Init(message.Param{
+		MessageRepository: mocks.message,
+		MatchRepository:   mocks.match,
+		BrokerRepository:  mocks.broker,
+	})
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.Local)
+	message.Now = func() time.Time {
+		return mockTime
+	}
+
+	restoreAll := func() {
+		message.Now = time.Now
+	}
+	defer restoreAll()
+
+	tests := []struct {
+		name     string
+		mockfunc func(mock mockfields)
+		wantErr  bool
+	}{
+		{
+			name: "no active match",
+			mockfunc: func(mock mockfields) {
+				mock.match.EXPECT().Get(context, matchPaging(1)).Return([]models.Match{}, 0, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "mark as read error",
+			mockfunc: func(mock mockfields) {
+				mock.match.EXPECT().Get(context, matchPaging(1)).Return([]models.Match{{Id: 1, UserId: 1, MatchedUserId: 3}}, 1, nil)
+				mock.message.EXPECT().MarkAsRead(context, 1, 1, mockTime).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "mark as read success",
+			mockfunc: func(mock mockfields) {
+				mock.match.EXPECT().Get(context, matchPaging(1)).Return([]models.Match{{Id: 1, UserId: 1, MatchedUserId: 3}}, 1, nil)
+				mock.message.EXPECT().MarkAsRead(context, 1, 1, mockTime).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(mocks)
+
+			err := service.Read(context, 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("message.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
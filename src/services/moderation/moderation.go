@@ -0,0 +1,180 @@
+package moderation
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/block"
+	"DatingApp/src/repositories/match"
+	"DatingApp/src/repositories/report"
+	"DatingApp/src/repositories/session"
+	"DatingApp/src/repositories/user"
+	"context"
+	"errors"
+	"time"
+)
+
+type Interface interface {
+	Block(ctx context.Context, blockedUserId int) error
+	Report(ctx context.Context, reportedUserId int, input models.ReportRequest) error
+	GetReports(ctx context.Context, paging filter.Paging[filter.ReportFilter]) ([]models.Report, int, error)
+	ResolveReport(ctx context.Context, id int, input models.ReportResolution) error
+}
+
+type moderationService struct {
+	blockRepository   block.Interface
+	reportRepository  report.Interface
+	userRepository    user.Interface
+	matchRepository   match.Interface
+	sessionRepository session.Interface
+}
+
+type Param struct {
+	BlockRepository   block.Interface
+	ReportRepository  report.Interface
+	UserRepository    user.Interface
+	MatchRepository   match.Interface
+	SessionRepository session.Interface
+}
+
+func Init(param Param) Interface {
+	return &moderationService{
+		blockRepository:   param.BlockRepository,
+		reportRepository:  param.ReportRepository,
+		userRepository:    param.UserRepository,
+		matchRepository:   param.MatchRepository,
+		sessionRepository: param.SessionRepository,
+	}
+}
+
+var Now = time.Now
+
+// Block blocks the user and ends their match, without a match there is no chat left between them.
+func (s *moderationService) Block(ctx context.Context, blockedUserId int) error {
+	userId := ctx.Value(models.UserKey).(models.User).Id
+	if err := s.checkTarget(ctx, int(userId), blockedUserId); err != nil {
+		return err
+	}
+
+	now := Now()
+	return s.blockRepository.Transaction(ctx, func(ctx context.Context) error {
+		err := s.blockRepository.Block(ctx, models.BlockInput{
+			UserId:        int(userId),
+			BlockedUserId: blockedUserId,
+			CreatedAt:     now,
+			CreatedBy:     userId,
+			UpdatedAt:     now,
+			UpdatedBy:     userId,
+		})
+		if err != nil {
+			return err
+		}
+		return s.matchRepository.Unmatch(ctx, int(userId), blockedUserId, now, userId)
+	})
+}
+
+// Report puts the user in the moderation queue.
+func (s *moderationService) Report(ctx context.Context, reportedUserId int, input models.ReportRequest) error {
+	userId := ctx.Value(models.UserKey).(models.User).Id
+	if err := s.checkTarget(ctx, int(userId), reportedUserId); err != nil {
+		return err
+	}
+
+	return s.reportRepository.Create(ctx, models.Query[models.ReportInput]{
+		Model: models.ReportInput{
+			ReporterId:     int(userId),
+			ReportedUserId: reportedUserId,
+			Reason:         input.Reason,
+			Description:    input.Description,
+			Status:         models.ReportOpen,
+			CreatedAt:      Now(),
+			CreatedBy:      userId,
+		},
+	})
+}
+
+// checkTarget makes sure the user targets someone else who exists.
+func (s *moderationService) checkTarget(ctx context.Context, userId, targetId int) error {
+	if userId == targetId {
+		return models.ErrSelfTarget
+	}
+
+	_, count, err := s.userRepository.Get(ctx, filter.Paging[filter.UserFilter]{
+		Filter: filter.UserFilter{
+			Id: targetId,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.New("user doesnt exists")
+	}
+	return nil
+}
+
+// GetReports lists the moderation queue, open reports oldest first unless asked otherwise.
+func (s *moderationService) GetReports(ctx context.Context, paging filter.Paging[filter.ReportFilter]) ([]models.Report, int, error) {
+	if paging.Filter.Status == 0 {
+		paging.Filter.Status = models.ReportOpen
+	}
+	if paging.OrderBy == "" {
+		paging.OrderBy = "id asc"
+	}
+	return s.reportRepository.Get(ctx, paging)
+}
+
+// ResolveReport closes an open report, a resolved report can also suspend the reported user
+// which logs them out everywhere and keeps them from logging in and from being recomended.
+func (s *moderationService) ResolveReport(ctx context.Context, id int, input models.ReportResolution) error {
+	adminId := ctx.Value(models.UserKey).(models.User).Id
+
+	reports, _, err := s.reportRepository.Get(ctx, filter.Paging[filter.ReportFilter]{
+		Filter: filter.ReportFilter{
+			Id:     id,
+			Status: models.ReportOpen,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if len(reports) == 0 {
+		return errors.New("open report doesnt exists")
+	}
+	report := reports[0]
+
+	status := models.ReportDismissed
+	if input.Action == models.ReportActionResolve {
+		status = models.ReportResolved
+	}
+	now := Now()
+
+	return s.reportRepository.Transaction(ctx, func(ctx context.Context) error {
+		// another admin may have closed the report since it was read
+		resolved, err := s.reportRepository.Resolve(ctx, id, models.ReportInput{
+			Status:    int64(status),
+			UpdatedAt: now,
+			UpdatedBy: adminId,
+		})
+		if err != nil {
+			return err
+		}
+		if !resolved {
+			return models.ErrReportClosed
+		}
+		if status != models.ReportResolved || !input.SuspendUser {
+			return nil
+		}
+
+		err = s.userRepository.Update(ctx, models.Query[models.UserInput]{
+			Model: models.UserInput{
+				Status:    models.UserSuspended,
+				UpdatedAt: now,
+				UpdatedBy: adminId,
+			},
+		}, report.ReportedUserId)
+		if err != nil {
+			return err
+		}
+		return s.sessionRepository.RevokeUserSessions(ctx, report.ReportedUserId, 0, now)
+	})
+}
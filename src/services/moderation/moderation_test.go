@@ -0,0 +1,396 @@
+package moderation_test
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	mock_block "DatingApp/src/repositories/mock/block"
+	mock_match "DatingApp/src/repositories/mock/match"
+	mock_report "DatingApp/src/repositories/mock/report"
+	mock_session "DatingApp/src/repositories/mock/session"
+	mock_user "DatingApp/src/repositories/mock/user"
+	"DatingApp/src/services/moderation"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func runTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func Test_moderationService_Block(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	blockRepo := mock_block.NewMockInterface(ctrl)
+	userRepo := mock_user.NewMockInterface(ctrl)
+	matchRepo := mock_match.NewMockInterface(ctrl)
+	service := moderation.Init(moderation.Param{
+		BlockRepository: blockRepo,
+		UserRepository:  userRepo,
+		MatchRepository: matchRepo,
+	})
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	moderation.Now = func() time.Time {
+		return mockTime
+	}
+	defer func() {
+		moderation.Now = time.Now
+	}()
+
+	userPaging := filter.Paging[filter.UserFilter]{
+		Filter: filter.UserFilter{
+			Id: 2,
+		},
+	}
+	input := models.BlockInput{
+		UserId:        1,
+		BlockedUserId: 2,
+		CreatedAt:     mockTime,
+		CreatedBy:     1,
+		UpdatedAt:     mockTime,
+		UpdatedBy:     1,
+	}
+
+	tests := []struct {
+		name          string
+		blockedUserId int
+		mockfunc      func()
+		wantErr       bool
+	}{
+		{
+			name:          "block yourself",
+			blockedUserId: 1,
+			mockfunc:      func() {},
+			wantErr:       true,
+		},
+		{
+			name:          "get user error",
+			blockedUserId: 2,
+			mockfunc: func() {
+				userRepo.EXPECT().Get(context, userPaging).Return([]models.User{}, 0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name:          "user doesnt exists",
+			blockedUserId: 2,
+			mockfunc: func() {
+				userRepo.EXPECT().Get(context, userPaging).Return([]models.User{}, 0, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:          "block error",
+			blockedUserId: 2,
+			mockfunc: func() {
+				userRepo.EXPECT().Get(context, userPaging).Return([]models.User{{Id: 2}}, 1, nil)
+				blockRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				blockRepo.EXPECT().Block(context, input).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name:          "unmatch error",
+			blockedUserId: 2,
+			mockfunc: func() {
+				userRepo.EXPECT().Get(context, userPaging).Return([]models.User{{Id: 2}}, 1, nil)
+				blockRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				blockRepo.EXPECT().Block(context, input).Return(nil)
+				matchRepo.EXPECT().Unmatch(context, 1, 2, mockTime, int64(1)).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name:          "block success",
+			blockedUserId: 2,
+			mockfunc: func() {
+				userRepo.EXPECT().Get(context, userPaging).Return([]models.User{{Id: 2}}, 1, nil)
+				blockRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				blockRepo.EXPECT().Block(context, input).Return(nil)
+				matchRepo.EXPECT().Unmatch(context, 1, 2, mockTime, int64(1)).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc()
+
+			err := service.Block(context, tt.blockedUserId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("moderation.Block() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_moderationService_Report(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	reportRepo := mock_report.NewMockInterface(ctrl)
+	userRepo := mock_user.NewMockInterface(ctrl)
+	service := moderation.Init(moderation.Param{
+		ReportRepository: reportRepo,
+		UserRepository:   userRepo,
+	})
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	moderation.Now = func() time.Time {
+		return mockTime
+	}
+	defer func() {
+		moderation.Now = time.Now
+	}()
+
+	request := models.ReportRequest{Reason: "spam", Description: "sends links"}
+	input := models.Query[models.ReportInput]{
+		Model: models.ReportInput{
+			ReporterId:     1,
+			ReportedUserId: 2,
+			Reason:         "spam",
+			Description:    "sends links",
+			Status:         models.ReportOpen,
+			CreatedAt:      mockTime,
+			CreatedBy:      1,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		reportedUserId int
+		mockfunc       func()
+		wantErr        bool
+	}{
+		{
+			name:           "report yourself",
+			reportedUserId: 1,
+			mockfunc:       func() {},
+			wantErr:        true,
+		},
+		{
+			name:           "user doesnt exists",
+			reportedUserId: 2,
+			mockfunc: func() {
+				userRepo.EXPECT().Get(context, gomock.Any()).Return([]models.User{}, 0, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:           "create report error",
+			reportedUserId: 2,
+			mockfunc: func() {
+				userRepo.EXPECT().Get(context, gomock.Any()).Return([]models.User{{Id: 2}}, 1, nil)
+				reportRepo.EXPECT().Create(context, input).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name:           "create report success",
+			reportedUserId: 2,
+			mockfunc: func() {
+				userRepo.EXPECT().Get(context, gomock.Any()).Return([]models.User{{Id: 2}}, 1, nil)
+				reportRepo.EXPECT().Create(context, input).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc()
+
+			err := service.Report(context, tt.reportedUserId, request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("moderation.Report() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_moderationService_GetReports(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reportRepo := mock_report.NewMockInterface(ctrl)
+	service := moderation.Init(moderation.Param{
+		ReportRepository: reportRepo,
+	})
+
+	tests := []struct {
+		name   string
+		paging filter.Paging[filter.ReportFilter]
+		want   filter.Paging[filter.ReportFilter]
+	}{
+		{
+			name:   "open reports oldest first by default",
+			paging: filter.Paging[filter.ReportFilter]{Page: 1, Take: 10},
+			want: filter.Paging[filter.ReportFilter]{
+				Page:    1,
+				Take:    10,
+				OrderBy: "id asc",
+				Filter:  filter.ReportFilter{Status: models.ReportOpen},
+			},
+		},
+		{
+			name: "asked status and order are kept",
+			paging: filter.Paging[filter.ReportFilter]{
+				Page:    1,
+				Take:    10,
+				OrderBy: "id desc",
+				Filter:  filter.ReportFilter{Status: models.ReportResolved},
+			},
+			want: filter.Paging[filter.ReportFilter]{
+				Page:    1,
+				Take:    10,
+				OrderBy: "id desc",
+				Filter:  filter.ReportFilter{Status: models.ReportResolved},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reportRepo.EXPECT().Get(context.Background(), tt.want).Return([]models.Report{{Id: 1}}, 1, nil)
+
+			reports, count, err := service.GetReports(context.Background(), tt.paging)
+			assert.NoError(t, err)
+			assert.Equal(t, []models.Report{{Id: 1}}, reports)
+			assert.Equal(t, 1, count)
+		})
+	}
+}
+
+func Test_moderationService_ResolveReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 9, Role: models.RoleAdmin})
+
+	reportRepo := mock_report.NewMockInterface(ctrl)
+	userRepo := mock_user.NewMockInterface(ctrl)
+	sessionRepo := mock_session.NewMockInterface(ctrl)
+	service := moderation.Init(moderation.Param{
+		ReportRepository:  reportRepo,
+		UserRepository:    userRepo,
+		SessionRepository: sessionRepo,
+	})
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	moderation.Now = func() time.Time {
+		return mockTime
+	}
+	defer func() {
+		moderation.Now = time.Now
+	}()
+
+	paging := filter.Paging[filter.ReportFilter]{
+		Filter: filter.ReportFilter{
+			Id:     1,
+			Status: models.ReportOpen,
+		},
+	}
+	openReport := []models.Report{{Id: 1, ReporterId: 1, ReportedUserId: 2, Status: models.ReportOpen}}
+	reportUpdate := func(status int64) models.ReportInput {
+		return models.ReportInput{Status: status, UpdatedAt: mockTime, UpdatedBy: 9}
+	}
+	suspend := models.Query[models.UserInput]{
+		Model: models.UserInput{Status: models.UserSuspended, UpdatedAt: mockTime, UpdatedBy: 9},
+	}
+
+	tests := []struct {
+		name     string
+		input    models.ReportResolution
+		mockfunc func()
+		wantErr  bool
+	}{
+		{
+			name:  "get report error",
+			input: models.ReportResolution{Action: models.ReportActionDismiss},
+			mockfunc: func() {
+				reportRepo.EXPECT().Get(context, paging).Return([]models.Report{}, 0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "report already closed",
+			input: models.ReportResolution{Action: models.ReportActionDismiss},
+			mockfunc: func() {
+				reportRepo.EXPECT().Get(context, paging).Return([]models.Report{}, 0, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "dismiss never suspends",
+			input: models.ReportResolution{Action: models.ReportActionDismiss, SuspendUser: true},
+			mockfunc: func() {
+				reportRepo.EXPECT().Get(context, paging).Return(openReport, 1, nil)
+				reportRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				reportRepo.EXPECT().Resolve(context, 1, reportUpdate(models.ReportDismissed)).Return(true, nil)
+			},
+		},
+		{
+			name:  "resolve without suspending",
+			input: models.ReportResolution{Action: models.ReportActionResolve},
+			mockfunc: func() {
+				reportRepo.EXPECT().Get(context, paging).Return(openReport, 1, nil)
+				reportRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				reportRepo.EXPECT().Resolve(context, 1, reportUpdate(models.ReportResolved)).Return(true, nil)
+			},
+		},
+		{
+			name:  "resolve report error",
+			input: models.ReportResolution{Action: models.ReportActionResolve, SuspendUser: true},
+			mockfunc: func() {
+				reportRepo.EXPECT().Get(context, paging).Return(openReport, 1, nil)
+				reportRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				reportRepo.EXPECT().Resolve(context, 1, reportUpdate(models.ReportResolved)).Return(false, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "report closed by another admin meanwhile",
+			input: models.ReportResolution{Action: models.ReportActionResolve, SuspendUser: true},
+			mockfunc: func() {
+				reportRepo.EXPECT().Get(context, paging).Return(openReport, 1, nil)
+				reportRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				reportRepo.EXPECT().Resolve(context, 1, reportUpdate(models.ReportResolved)).Return(false, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "suspend user error",
+			input: models.ReportResolution{Action: models.ReportActionResolve, SuspendUser: true},
+			mockfunc: func() {
+				reportRepo.EXPECT().Get(context, paging).Return(openReport, 1, nil)
+				reportRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				reportRepo.EXPECT().Resolve(context, 1, reportUpdate(models.ReportResolved)).Return(true, nil)
+				userRepo.EXPECT().Update(context, suspend, 2).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "resolve and suspend user",
+			input: models.ReportResolution{Action: models.ReportActionResolve, SuspendUser: true},
+			mockfunc: func() {
+				reportRepo.EXPECT().Get(context, paging).Return(openReport, 1, nil)
+				reportRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				reportRepo.EXPECT().Resolve(context, 1, reportUpdate(models.ReportResolved)).Return(true, nil)
+				userRepo.EXPECT().Update(context, suspend, 2).Return(nil)
+				sessionRepo.EXPECT().RevokeUserSessions(context, 2, 0, mockTime).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc()
+
+			err := service.ResolveReport(context, 1, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("moderation.ResolveReport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
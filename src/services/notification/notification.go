@@ -0,0 +1,30 @@
+package notification
+
+import (
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/broker"
+	"context"
+)
+
+type Interface interface {
+	Subscribe(ctx context.Context) (<-chan models.Event, func())
+}
+
+type notificationService struct {
+	brokerRepository broker.Interface
+}
+
+type Param struct {
+	BrokerRepository broker.Interface
+}
+
+func Init(param Param) Interface {
+	return &notificationService{
+		brokerRepository: param.BrokerRepository,
+	}
+}
+
+func (s *notificationService) Subscribe(ctx context.Context) (<-chan models.Event, func()) {
+	userId := ctx.Value(models.UserKey).(models.User).Id
+	return s.brokerRepository.Subscribe(int(userId))
+}
@@ -0,0 +1,33 @@
+package notification_test
+
+import (
+	"DatingApp/src/models"
+	mock_broker "DatingApp/src/repositories/mock/broker"
+	notification "DatingApp/src/services/notification"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_notificationService_Subscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	brokerRepo := mock_broker.NewMockInterface(ctrl)
+	service := notification.Init(notification.Param{
+		BrokerRepository: brokerRepo,
+	})
+
+	events := make(chan models.Event)
+	isUnsubscribed := false
+	brokerRepo.EXPECT().Subscribe(1).Return((<-chan models.Event)(events), func() { isUnsubscribed = true })
+
+	gotEvents, unsubscribe := service.Subscribe(context)
+	unsubscribe()
+
+	assert.Equal(t, (<-chan models.Event)(events), gotEvents)
+	assert.True(t, isUnsubscribed)
+}
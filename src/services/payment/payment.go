@@ -0,0 +1,175 @@
+package payment
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/order"
+	"DatingApp/src/repositories/payment"
+	premiumfeature "DatingApp/src/repositories/premium_feature"
+	"DatingApp/src/repositories/subscription"
+	"context"
+	"errors"
+	"time"
+)
+
+type Interface interface {
+	Checkout(ctx context.Context, input models.Checkout) (models.CheckoutSession, error)
+	HandleWebhook(ctx context.Context, payload []byte, signature string) error
+}
+
+type paymentService struct {
+	paymentRepository        payment.Interface
+	orderRepository          order.Interface
+	premiumFeatureRepository premiumfeature.Interface
+	subscriptionRepository   subscription.Interface
+}
+
+type Param struct {
+	PaymentRepository        payment.Interface
+	OrderRepository          order.Interface
+	PremiumFeatureRepository premiumfeature.Interface
+	SubscriptionRepository   subscription.Interface
+}
+
+func Init(param Param) Interface {
+	return &paymentService{
+		paymentRepository:        param.PaymentRepository,
+		orderRepository:          param.OrderRepository,
+		premiumFeatureRepository: param.PremiumFeatureRepository,
+		subscriptionRepository:   param.SubscriptionRepository,
+	}
+}
+
+var Now = time.Now
+
+func (s *paymentService) Checkout(ctx context.Context, input models.Checkout) (models.CheckoutSession, error) {
+	userId := ctx.Value(models.UserKey).(models.User).Id
+
+	feature, err := s.getPremiumFeature(ctx, filter.Paging[filter.PremiumFeatureFilter]{
+		IsActive: true,
+		Filter: filter.PremiumFeatureFilter{
+			Id: input.PremiumFeatureId,
+		},
+	})
+	if err != nil {
+		return models.CheckoutSession{}, err
+	}
+	if !feature.Purchasable() {
+		return models.CheckoutSession{}, models.ErrNotPurchasable
+	}
+
+	reference, checkoutUrl, err := s.paymentRepository.CreateCheckout(ctx, models.CheckoutRequest{
+		UserId:      int(userId),
+		Amount:      feature.Price,
+		Description: feature.Name,
+	})
+	if err != nil {
+		return models.CheckoutSession{}, err
+	}
+
+	orderId, err := s.orderRepository.CreateOrder(ctx, models.Query[models.OrderInput]{
+		Model: models.OrderInput{
+			UserId:           int(userId),
+			PremiumFeatureId: int(feature.Id),
+			Amount:           feature.Price,
+			Provider:         s.paymentRepository.Name(),
+			Reference:        reference,
+			Status:           models.OrderPending,
+			CreatedAt:        Now(),
+			CreatedBy:        userId,
+		},
+	})
+	if err != nil {
+		return models.CheckoutSession{}, err
+	}
+
+	return models.CheckoutSession{
+		OrderId:     int64(orderId),
+		Reference:   reference,
+		CheckoutUrl: checkoutUrl,
+	}, nil
+}
+
+// HandleWebhook activates the premium feature of a paid order. Providers retry deliveries,
+// so an event for an order which is already paid is acknowledged without doing anything.
+func (s *paymentService) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+	if err := s.paymentRepository.VerifySignature(payload, signature); err != nil {
+		return err
+	}
+
+	event, err := s.paymentRepository.ParseEvent(payload)
+	if err != nil {
+		return err
+	}
+	if event.Type != models.PaymentEventCheckoutCompleted {
+		return nil
+	}
+
+	orders, _, err := s.orderRepository.Get(ctx, filter.Paging[filter.OrderFilter]{
+		Filter: filter.OrderFilter{
+			Provider:  s.paymentRepository.Name(),
+			Reference: event.Reference,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if len(orders) == 0 {
+		return errors.New("order doesnt exists")
+	}
+	paidOrder := orders[0]
+	if paidOrder.Status == models.OrderPaid {
+		return nil
+	}
+	if paidOrder.Amount != event.Amount {
+		return errors.New("paid amount doesnt match the order")
+	}
+
+	feature, err := s.getPremiumFeature(ctx, filter.Paging[filter.PremiumFeatureFilter]{
+		Filter: filter.PremiumFeatureFilter{
+			Id: paidOrder.PremiumFeatureId,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	now := Now()
+	return s.orderRepository.Transaction(ctx, func(ctx context.Context) error {
+		paid, err := s.orderRepository.MarkPaid(ctx, int(paidOrder.Id), now)
+		if err != nil {
+			return err
+		}
+		if !paid {
+			// a concurrent delivery of the same event got here first
+			return nil
+		}
+
+		if err := s.subscriptionRepository.ExpireUserSubscriptions(ctx, paidOrder.UserId, int(feature.Id), now); err != nil {
+			return err
+		}
+
+		return s.subscriptionRepository.Create(ctx, models.Query[models.SubscriptionInput]{
+			Model: models.SubscriptionInput{
+				UserId:           paidOrder.UserId,
+				PremiumFeatureId: int(feature.Id),
+				StartedAt:        now,
+				ExpiresAt:        now.AddDate(0, 0, feature.DurationDays),
+				Source:           models.SubscriptionSourcePayment,
+				CreatedAt:        now,
+				CreatedBy:        int64(paidOrder.UserId),
+			},
+		})
+	})
+}
+
+func (s *paymentService) getPremiumFeature(ctx context.Context, paging filter.Paging[filter.PremiumFeatureFilter]) (models.PremiumFeature, error) {
+	features, _, err := s.premiumFeatureRepository.Get(ctx, paging)
+	if err != nil {
+		return models.PremiumFeature{}, err
+	}
+	if len(features) == 0 {
+		return models.PremiumFeature{}, errors.New("premium feature doesnt exists")
+	}
+	return features[0], nil
+}
@@ -0,0 +1,404 @@
+package payment_test
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	mock_order "DatingApp/src/repositories/mock/order"
+	mock_payment "DatingApp/src/repositories/mock/payment"
+	mock_premium_feature "DatingApp/src/repositories/mock/premium_feature"
+	mock_subscription "DatingApp/src/repositories/mock/subscription"
+	"DatingApp/src/services/payment"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func runTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func Test_paymentService_Checkout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	paymentRepo := mock_payment.NewMockInterface(ctrl)
+	orderRepo := mock_order.NewMockInterface(ctrl)
+	premiumFeatureRepo := mock_premium_feature.NewMockInterface(ctrl)
+	type mockfields struct {
+		payment        *mock_payment.MockInterface
+		order          *mock_order.MockInterface
+		premiumFeature *mock_premium_feature.MockInterface
+	}
+	mocks := mockfields{
+		payment:        paymentRepo,
+		order:          orderRepo,
+		premiumFeature: premiumFeatureRepo,
+	}
+	params := payment.Param{
+		PaymentRepository:        paymentRepo,
+		OrderRepository:          orderRepo,
+		PremiumFeatureRepository: premiumFeatureRepo,
+	}
+	service := payment.Init(params)
+	type args struct {
+		Input models.Checkout
+	}
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.Local)
+	payment.Now = func() time.Time {
+		return mockTime
+	}
+
+	restoreAll := func() {
+		payment.Now = time.Now
+	}
+	defer restoreAll()
+
+	featurePaging := filter.Paging[filter.PremiumFeatureFilter]{
+		IsActive: true,
+		Filter: filter.PremiumFeatureFilter{
+			Id: 2,
+		},
+	}
+	feature := models.PremiumFeature{Id: 2, Name: "No Swipe Quota", Price: 10000}
+	checkoutRequest := models.CheckoutRequest{UserId: 1, Amount: 10000, Description: "No Swipe Quota"}
+	orderInput := models.Query[models.OrderInput]{
+		Model: models.OrderInput{
+			UserId:           1,
+			PremiumFeatureId: 2,
+			Amount:           10000,
+			Provider:         "fake",
+			Reference:        "fake_1",
+			Status:           models.OrderPending,
+			CreatedAt:        mockTime,
+			CreatedBy:        1,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		mockfunc func(a args, mock mockfields)
+		want     models.CheckoutSession
+		wantErr  bool
+	}{
+		{
+			name: "get premium feature error",
+			args: args{
+				Input: models.Checkout{PremiumFeatureId: 2},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.premiumFeature.EXPECT().Get(context, featurePaging).Return([]models.PremiumFeature{}, 0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "premium feature doesnt exists",
+			args: args{
+				Input: models.Checkout{PremiumFeatureId: 2},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.premiumFeature.EXPECT().Get(context, featurePaging).Return([]models.PremiumFeature{}, 0, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "premium feature is not for sale",
+			args: args{
+				Input: models.Checkout{PremiumFeatureId: 2},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.premiumFeature.EXPECT().Get(context, featurePaging).Return([]models.PremiumFeature{{Id: 2}}, 1, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "verified badge is not for sale",
+			args: args{
+				Input: models.Checkout{PremiumFeatureId: 2},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.premiumFeature.EXPECT().Get(context, featurePaging).Return([]models.PremiumFeature{{Id: 2, Flag: models.VerifiedFlag, Price: 10000}}, 1, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "create checkout error",
+			args: args{
+				Input: models.Checkout{PremiumFeatureId: 2},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.premiumFeature.EXPECT().Get(context, featurePaging).Return([]models.PremiumFeature{feature}, 1, nil)
+				mock.payment.EXPECT().CreateCheckout(context, checkoutRequest).Return("", "", assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "create order error",
+			args: args{
+				Input: models.Checkout{PremiumFeatureId: 2},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.premiumFeature.EXPECT().Get(context, featurePaging).Return([]models.PremiumFeature{feature}, 1, nil)
+				mock.payment.EXPECT().CreateCheckout(context, checkoutRequest).Return("fake_1", "https://fake/fake_1", nil)
+				mock.payment.EXPECT().Name().Return("fake")
+				mock.order.EXPECT().CreateOrder(context, orderInput).Return(0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "checkout success",
+			args: args{
+				Input: models.Checkout{PremiumFeatureId: 2},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.premiumFeature.EXPECT().Get(context, featurePaging).Return([]models.PremiumFeature{feature}, 1, nil)
+				mock.payment.EXPECT().CreateCheckout(context, checkoutRequest).Return("fake_1", "https://fake/fake_1", nil)
+				mock.payment.EXPECT().Name().Return("fake")
+				mock.order.EXPECT().CreateOrder(context, orderInput).Return(7, nil)
+			},
+			want: models.CheckoutSession{OrderId: 7, Reference: "fake_1", CheckoutUrl: "https://fake/fake_1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(tt.args, mocks)
+
+			checkout, err := service.Checkout(context, tt.args.Input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("payment.Checkout() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, checkout)
+		})
+	}
+}
+
+func Test_paymentService_HandleWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.Background()
+
+	paymentRepo := mock_payment.NewMockInterface(ctrl)
+	orderRepo := mock_order.NewMockInterface(ctrl)
+	premiumFeatureRepo := mock_premium_feature.NewMockInterface(ctrl)
+	subscriptionRepo := mock_subscription.NewMockInterface(ctrl)
+	type mockfields struct {
+		payment        *mock_payment.MockInterface
+		order          *mock_order.MockInterface
+		premiumFeature *mock_premium_feature.MockInterface
+		subscription   *mock_subscription.MockInterface
+	}
+	mocks := mockfields{
+		payment:        paymentRepo,
+		order:          orderRepo,
+		premiumFeature: premiumFeatureRepo,
+		subscription:   subscriptionRepo,
+	}
+	params := payment.Param{
+		PaymentRepository:        paymentRepo,
+		OrderRepository:          orderRepo,
+		PremiumFeatureRepository: premiumFeatureRepo,
+		SubscriptionRepository:   subscriptionRepo,
+	}
+	service := payment.Init(params)
+	type args struct {
+		Payload   []byte
+		Signature string
+	}
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.Local)
+	payment.Now = func() time.Time {
+		return mockTime
+	}
+
+	restoreAll := func() {
+		payment.Now = time.Now
+	}
+	defer restoreAll()
+
+	payload := []byte(`{"id":"evt_1"}`)
+	event := models.PaymentEvent{Id: "evt_1", Type: models.PaymentEventCheckoutCompleted, Reference: "fake_1", Amount: 10000}
+	orderPaging := filter.Paging[filter.OrderFilter]{
+		Filter: filter.OrderFilter{
+			Provider:  "fake",
+			Reference: "fake_1",
+		},
+	}
+	pendingOrder := models.Order{Id: 7, UserId: 1, PremiumFeatureId: 2, Amount: 10000, Status: models.OrderPending}
+	featurePaging := filter.Paging[filter.PremiumFeatureFilter]{
+		Filter: filter.PremiumFeatureFilter{
+			Id: 2,
+		},
+	}
+	feature := models.PremiumFeature{Id: 2, DurationDays: 30, Price: 10000}
+	subscriptionInput := models.Query[models.SubscriptionInput]{
+		Model: models.SubscriptionInput{
+			UserId:           1,
+			PremiumFeatureId: 2,
+			StartedAt:        mockTime,
+			ExpiresAt:        mockTime.AddDate(0, 0, 30),
+			Source:           models.SubscriptionSourcePayment,
+			CreatedAt:        mockTime,
+			CreatedBy:        1,
+		},
+	}
+	paidOrderFound := func(mock mockfields) {
+		mock.payment.EXPECT().VerifySignature(payload, "signature").Return(nil)
+		mock.payment.EXPECT().ParseEvent(payload).Return(event, nil)
+		mock.payment.EXPECT().Name().Return("fake")
+		mock.order.EXPECT().Get(context, orderPaging).Return([]models.Order{pendingOrder}, 1, nil)
+		mock.premiumFeature.EXPECT().Get(context, featurePaging).Return([]models.PremiumFeature{feature}, 1, nil)
+		mock.order.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		mockfunc func(a args, mock mockfields)
+		wantErr  bool
+	}{
+		{
+			name: "invalid signature",
+			args: args{Payload: payload, Signature: "signature"},
+			mockfunc: func(a args, mock mockfields) {
+				mock.payment.EXPECT().VerifySignature(payload, "signature").Return(models.ErrInvalidSignature)
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse event error",
+			args: args{Payload: payload, Signature: "signature"},
+			mockfunc: func(a args, mock mockfields) {
+				mock.payment.EXPECT().VerifySignature(payload, "signature").Return(nil)
+				mock.payment.EXPECT().ParseEvent(payload).Return(models.PaymentEvent{}, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "other event type is ignored",
+			args: args{Payload: payload, Signature: "signature"},
+			mockfunc: func(a args, mock mockfields) {
+				mock.payment.EXPECT().VerifySignature(payload, "signature").Return(nil)
+				mock.payment.EXPECT().ParseEvent(payload).Return(models.PaymentEvent{Id: "evt_1", Type: "checkout.expired", Reference: "fake_1"}, nil)
+			},
+		},
+		{
+			name: "get order error",
+			args: args{Payload: payload, Signature: "signature"},
+			mockfunc: func(a args, mock mockfields) {
+				mock.payment.EXPECT().VerifySignature(payload, "signature").Return(nil)
+				mock.payment.EXPECT().ParseEvent(payload).Return(event, nil)
+				mock.payment.EXPECT().Name().Return("fake")
+				mock.order.EXPECT().Get(context, orderPaging).Return([]models.Order{}, 0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "order doesnt exists",
+			args: args{Payload: payload, Signature: "signature"},
+			mockfunc: func(a args, mock mockfields) {
+				mock.payment.EXPECT().VerifySignature(payload, "signature").Return(nil)
+				mock.payment.EXPECT().ParseEvent(payload).Return(event, nil)
+				mock.payment.EXPECT().Name().Return("fake")
+				mock.order.EXPECT().Get(context, orderPaging).Return([]models.Order{}, 0, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "order already paid",
+			args: args{Payload: payload, Signature: "signature"},
+			mockfunc: func(a args, mock mockfields) {
+				mock.payment.EXPECT().VerifySignature(payload, "signature").Return(nil)
+				mock.payment.EXPECT().ParseEvent(payload).Return(event, nil)
+				mock.payment.EXPECT().Name().Return("fake")
+				mock.order.EXPECT().Get(context, orderPaging).Return([]models.Order{{Id: 7, Amount: 10000, Status: models.OrderPaid}}, 1, nil)
+			},
+		},
+		{
+			name: "paid amount doesnt match",
+			args: args{Payload: payload, Signature: "signature"},
+			mockfunc: func(a args, mock mockfields) {
+				mock.payment.EXPECT().VerifySignature(payload, "signature").Return(nil)
+				mock.payment.EXPECT().ParseEvent(payload).Return(models.PaymentEvent{Id: "evt_1", Type: models.PaymentEventCheckoutCompleted, Reference: "fake_1", Amount: 1}, nil)
+				mock.payment.EXPECT().Name().Return("fake")
+				mock.order.EXPECT().Get(context, orderPaging).Return([]models.Order{pendingOrder}, 1, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "get premium feature error",
+			args: args{Payload: payload, Signature: "signature"},
+			mockfunc: func(a args, mock mockfields) {
+				mock.payment.EXPECT().VerifySignature(payload, "signature").Return(nil)
+				mock.payment.EXPECT().ParseEvent(payload).Return(event, nil)
+				mock.payment.EXPECT().Name().Return("fake")
+				mock.order.EXPECT().Get(context, orderPaging).Return([]models.Order{pendingOrder}, 1, nil)
+				mock.premiumFeature.EXPECT().Get(context, featurePaging).Return([]models.PremiumFeature{}, 0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "mark paid error",
+			args: args{Payload: payload, Signature: "signature"},
+			mockfunc: func(a args, mock mockfields) {
+				paidOrderFound(mock)
+				mock.order.EXPECT().MarkPaid(context, 7, mockTime).Return(false, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "concurrent delivery already marked it paid",
+			args: args{Payload: payload, Signature: "signature"},
+			mockfunc: func(a args, mock mockfields) {
+				paidOrderFound(mock)
+				mock.order.EXPECT().MarkPaid(context, 7, mockTime).Return(false, nil)
+			},
+		},
+		{
+			name: "expire current subscription error",
+			args: args{Payload: payload, Signature: "signature"},
+			mockfunc: func(a args, mock mockfields) {
+				paidOrderFound(mock)
+				mock.order.EXPECT().MarkPaid(context, 7, mockTime).Return(true, nil)
+				mock.subscription.EXPECT().ExpireUserSubscriptions(context, 1, 2, mockTime).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "create subscription error",
+			args: args{Payload: payload, Signature: "signature"},
+			mockfunc: func(a args, mock mockfields) {
+				paidOrderFound(mock)
+				mock.order.EXPECT().MarkPaid(context, 7, mockTime).Return(true, nil)
+				mock.subscription.EXPECT().ExpireUserSubscriptions(context, 1, 2, mockTime).Return(nil)
+				mock.subscription.EXPECT().Create(context, subscriptionInput).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "handle webhook success",
+			args: args{Payload: payload, Signature: "signature"},
+			mockfunc: func(a args, mock mockfields) {
+				paidOrderFound(mock)
+				mock.order.EXPECT().MarkPaid(context, 7, mockTime).Return(true, nil)
+				mock.subscription.EXPECT().ExpireUserSubscriptions(context, 1, 2, mockTime).Return(nil)
+				mock.subscription.EXPECT().Create(context, subscriptionInput).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(tt.args, mocks)
+
+			err := service.HandleWebhook(context, tt.args.Payload, tt.args.Signature)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("payment.HandleWebhook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
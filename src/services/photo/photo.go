@@ -0,0 +1,478 @@
+package photo
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/photo"
+	"DatingApp/src/repositories/storage"
+	"DatingApp/src/repositories/user"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const keyLength = 16
+
+type Interface interface {
+	Get(ctx context.Context) ([]models.ProfilePhoto, error)
+	Upload(ctx context.Context, data []byte) (models.ProfilePhoto, error)
+	Reorder(ctx context.Context, input models.PhotoOrderRequest) ([]models.ProfilePhoto, error)
+	Delete(ctx context.Context, id int) error
+	Open(ctx context.Context, key string, query models.PhotoQuery) ([]byte, error)
+	Store(ctx context.Context, data []byte) (string, error)
+	Remove(ctx context.Context, userId int, image string) error
+	Replace(ctx context.Context, urls []string) ([]string, error)
+	Url(image string, size string) string
+	ImageUrl(image *string) *string
+	Resolve(photo models.ProfilePhoto) models.ProfilePhoto
+}
+
+type photoService struct {
+	photoRepository   photo.Interface
+	userRepository    user.Interface
+	storageRepository storage.Interface
+}
+
+type Param struct {
+	PhotoRepository   photo.Interface
+	UserRepository    user.Interface
+	StorageRepository storage.Interface
+}
+
+func Init(param Param) Interface {
+	return &photoService{
+		photoRepository:   param.PhotoRepository,
+		userRepository:    param.UserRepository,
+		storageRepository: param.StorageRepository,
+	}
+}
+
+var Now = time.Now
+
+// Get lists the photos of the user first to last.
+func (s *photoService) Get(ctx context.Context) ([]models.ProfilePhoto, error) {
+	userId := int(ctx.Value(models.UserKey).(models.User).Id)
+
+	photos, err := s.list(ctx, userId)
+	if err != nil {
+		return []models.ProfilePhoto{}, err
+	}
+	return s.resolveAll(photos), nil
+}
+
+// Upload stores the photo with its thumbnails after the other photos of the user,
+// the first photo of the user also becomes their image.
+func (s *photoService) Upload(ctx context.Context, data []byte) (models.ProfilePhoto, error) {
+	user := ctx.Value(models.UserKey).(models.User)
+
+	photos, err := s.list(ctx, int(user.Id))
+	if err != nil {
+		return models.ProfilePhoto{}, err
+	}
+	if len(photos) >= models.MaxPhotos {
+		return models.ProfilePhoto{}, models.ErrTooManyPhotos
+	}
+
+	key, err := s.store(ctx, int(user.Id), data)
+	if err != nil {
+		return models.ProfilePhoto{}, err
+	}
+
+	now := Now()
+	result := models.ProfilePhoto{
+		Url: models.StorageScheme + key,
+	}
+	err = s.photoRepository.Transaction(ctx, func(ctx context.Context) error {
+		// uploads of the same user wait for each other, so they can't go past the limit together
+		if err := s.userRepository.Lock(ctx, int(user.Id)); err != nil {
+			return err
+		}
+
+		photos, err := s.list(ctx, int(user.Id))
+		if err != nil {
+			return err
+		}
+		if len(photos) >= models.MaxPhotos {
+			return models.ErrTooManyPhotos
+		}
+		result.Position = len(photos)
+
+		id, err := s.photoRepository.CreatePhoto(ctx, models.PhotoInput{
+			UserId:    int(user.Id),
+			Url:       result.Url,
+			Position:  result.Position,
+			CreatedAt: now,
+			CreatedBy: user.Id,
+		})
+		if err != nil {
+			return err
+		}
+		result.Id = int64(id)
+
+		if result.Position == 0 {
+			return s.userRepository.UpdateImage(ctx, int(user.Id), result.Url, now)
+		}
+		return nil
+	})
+	if err != nil {
+		// nothing points at the files anymore
+		s.removeKey(ctx, key)
+		return models.ProfilePhoto{}, err
+	}
+
+	return s.Resolve(result), nil
+}
+
+// Reorder moves the photos of the user in the order of input, the first one becomes their image.
+func (s *photoService) Reorder(ctx context.Context, input models.PhotoOrderRequest) ([]models.ProfilePhoto, error) {
+	userId := int(ctx.Value(models.UserKey).(models.User).Id)
+
+	photos, err := s.list(ctx, userId)
+	if err != nil {
+		return []models.ProfilePhoto{}, err
+	}
+	if len(input.Ids) != len(photos) {
+		return []models.ProfilePhoto{}, models.ErrInvalidPhotoOrder
+	}
+	byId := map[int]models.ProfilePhoto{}
+	for _, photo := range photos {
+		byId[int(photo.Id)] = photo
+	}
+	result := []models.ProfilePhoto{}
+	for position, id := range input.Ids {
+		photo, ok := byId[id]
+		if !ok {
+			return []models.ProfilePhoto{}, models.ErrInvalidPhotoOrder
+		}
+		photo.Position = position
+		result = append(result, photo)
+	}
+
+	now := Now()
+	err = s.photoRepository.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.photoRepository.Reorder(ctx, userId, input.Ids, now); err != nil {
+			return err
+		}
+		return s.userRepository.UpdateImage(ctx, userId, result[0].Url, now)
+	})
+	if err != nil {
+		return []models.ProfilePhoto{}, err
+	}
+
+	return s.resolveAll(result), nil
+}
+
+// Delete removes the photo and closes the gap it leaves, the image of the user follows the first photo left.
+func (s *photoService) Delete(ctx context.Context, id int) error {
+	userId := int(ctx.Value(models.UserKey).(models.User).Id)
+
+	photos, err := s.list(ctx, userId)
+	if err != nil {
+		return err
+	}
+	var (
+		deleted *models.ProfilePhoto
+		left    = []int{}
+		image   = ""
+	)
+	for i, photo := range photos {
+		if int(photo.Id) == id {
+			deleted = &photos[i]
+			continue
+		}
+		if len(left) == 0 {
+			image = photo.Url
+		}
+		left = append(left, int(photo.Id))
+	}
+	if deleted == nil {
+		return errors.New("photo doesnt exists")
+	}
+
+	now := Now()
+	err = s.photoRepository.Transaction(ctx, func(ctx context.Context) error {
+		err := s.photoRepository.Update(ctx, models.Query[models.PhotoInput]{
+			Model: models.PhotoInput{
+				Status:    -1,
+				DeletedAt: now,
+				DeletedBy: int64(userId),
+			},
+		}, id)
+		if err != nil {
+			return err
+		}
+		if err := s.photoRepository.Reorder(ctx, userId, left, now); err != nil {
+			return err
+		}
+		return s.userRepository.UpdateImage(ctx, userId, image, now)
+	})
+	if err != nil {
+		return err
+	}
+
+	// removing the files is best effort, the photo is already gone
+	s.Remove(ctx, userId, deleted.Url)
+	return nil
+}
+
+// Replace swaps every photo of the user for urls in their order, the first one becomes their image.
+// The signed urls Get hands out are turned back into the uploads they sign, so saving what was read
+// keeps the uploads. It returns the uploads no longer used, they are left to the caller to Remove
+// once the change is committed.
+func (s *photoService) Replace(ctx context.Context, urls []string) ([]string, error) {
+	user := ctx.Value(models.UserKey).(models.User)
+
+	photos, err := s.list(ctx, int(user.Id))
+	if err != nil {
+		return []string{}, err
+	}
+
+	images := []string{}
+	kept := map[string]bool{}
+	for _, url := range urls {
+		image, err := s.image(url, photos)
+		if err != nil {
+			return []string{}, err
+		}
+		if kept[image] && strings.HasPrefix(image, models.StorageScheme) {
+			// two photos on the same files would lose them both once one is deleted
+			return []string{}, models.ErrInvalidPhotoOrder
+		}
+		kept[image] = true
+		images = append(images, image)
+	}
+
+	now := Now()
+	err = s.photoRepository.Transaction(ctx, func(ctx context.Context) error {
+		for _, photo := range photos {
+			err := s.photoRepository.Update(ctx, models.Query[models.PhotoInput]{
+				Model: models.PhotoInput{
+					Status:    -1,
+					DeletedAt: now,
+					DeletedBy: user.Id,
+				},
+			}, int(photo.Id))
+			if err != nil {
+				return err
+			}
+		}
+		image := ""
+		for position, url := range images {
+			_, err := s.photoRepository.CreatePhoto(ctx, models.PhotoInput{
+				UserId:    int(user.Id),
+				Url:       url,
+				Position:  position,
+				CreatedAt: now,
+				CreatedBy: user.Id,
+			})
+			if err != nil {
+				return err
+			}
+			if position == 0 {
+				image = url
+			}
+		}
+		return s.userRepository.UpdateImage(ctx, int(user.Id), image, now)
+	})
+	if err != nil {
+		return []string{}, err
+	}
+
+	dropped := []string{}
+	for _, photo := range photos {
+		if !kept[photo.Url] {
+			dropped = append(dropped, photo.Url)
+		}
+	}
+	return dropped, nil
+}
+
+// image returns the upload of photos a signed url of any size was made for, any other url is returned as is.
+// A signed url of none of them is refused, it would point at files the user can't keep.
+func (s *photoService) image(rawUrl string, photos []models.ProfilePhoto) (string, error) {
+	parsed, err := url.Parse(rawUrl)
+	if err != nil {
+		return "", err
+	}
+	if !parsed.Query().Has("signature") {
+		return rawUrl, nil
+	}
+
+	sizes := []string{models.PhotoSizeOriginal}
+	for size := range models.PhotoSizes {
+		sizes = append(sizes, size)
+	}
+	for _, photo := range photos {
+		if !strings.HasPrefix(photo.Url, models.StorageScheme) {
+			continue
+		}
+		for _, size := range sizes {
+			signed, err := url.Parse(s.Url(photo.Url, size))
+			if err != nil {
+				continue
+			}
+			if signed.Scheme == parsed.Scheme && signed.Host == parsed.Host && signed.Path == parsed.Path {
+				return photo.Url, nil
+			}
+		}
+	}
+	return "", models.ErrUnknownPhotoUrl
+}
+
+// Open returns the file behind a signed url.
+func (s *photoService) Open(ctx context.Context, key string, query models.PhotoQuery) ([]byte, error) {
+	if err := s.storageRepository.VerifyUrl(key, query.Expires, query.Signature, Now()); err != nil {
+		return nil, err
+	}
+	return s.storageRepository.Get(ctx, key)
+}
+
+// Store keeps an image of the user that isnt one of their photos, like a verification selfie,
+// the same way as their photos and returns the image to save.
+func (s *photoService) Store(ctx context.Context, data []byte) (string, error) {
+	userId := int(ctx.Value(models.UserKey).(models.User).Id)
+
+	key, err := s.store(ctx, userId, data)
+	if err != nil {
+		return "", err
+	}
+	return models.StorageScheme + key, nil
+}
+
+// Remove deletes the files of an image kept in the storage for userId, any other image is left alone.
+// An image under another user or not made by store is refused so a stored url can never reach other files.
+func (s *photoService) Remove(ctx context.Context, userId int, image string) error {
+	if !strings.HasPrefix(image, models.StorageScheme) {
+		return nil
+	}
+	key := strings.TrimPrefix(image, models.StorageScheme)
+	if !s.ownKey(userId, key) {
+		return storage.ErrInvalidKey
+	}
+	return s.removeKey(ctx, key)
+}
+
+// Url turns an image kept in the storage into a signed url of the given size,
+// any other image is an url already and returned as is.
+func (s *photoService) Url(image string, size string) string {
+	if !strings.HasPrefix(image, models.StorageScheme) {
+		return image
+	}
+	key := strings.TrimPrefix(image, models.StorageScheme) + "/" + size + ".jpg"
+	return s.storageRepository.SignedUrl(key, s.expiresAt())
+}
+
+// ImageUrl is Url of an optional image at the size shown in lists.
+func (s *photoService) ImageUrl(image *string) *string {
+	if image == nil {
+		return nil
+	}
+	url := s.Url(*image, models.PhotoSizeMedium)
+	return &url
+}
+
+// Resolve signs the urls of a photo, an uploaded one gets its thumbnails too.
+func (s *photoService) Resolve(photo models.ProfilePhoto) models.ProfilePhoto {
+	if !strings.HasPrefix(photo.Url, models.StorageScheme) {
+		return photo
+	}
+	photo.Thumbnails = map[string]string{}
+	for size := range models.PhotoSizes {
+		photo.Thumbnails[size] = s.Url(photo.Url, size)
+	}
+	photo.Url = s.Url(photo.Url, models.PhotoSizeOriginal)
+	return photo
+}
+
+func (s *photoService) resolveAll(photos []models.ProfilePhoto) []models.ProfilePhoto {
+	result := []models.ProfilePhoto{}
+	for _, photo := range photos {
+		result = append(result, s.Resolve(photo))
+	}
+	return result
+}
+
+// expiresAt only moves once per models.PhotoUrlTTL so the urls stay the same long enough to be cached,
+// each of them is still valid for at least models.PhotoUrlTTL.
+func (s *photoService) expiresAt() time.Time {
+	return Now().Truncate(models.PhotoUrlTTL).Add(2 * models.PhotoUrlTTL)
+}
+
+func (s *photoService) list(ctx context.Context, userId int) ([]models.ProfilePhoto, error) {
+	photos, _, err := s.photoRepository.Get(ctx, filter.Paging[filter.PhotoFilter]{
+		OrderBy:  "position asc",
+		IsActive: true,
+		Filter: filter.PhotoFilter{
+			UserId: userId,
+		},
+	})
+	if err != nil {
+		return []models.ProfilePhoto{}, err
+	}
+
+	result := []models.ProfilePhoto{}
+	for _, photo := range photos {
+		result = append(result, models.ProfilePhoto{
+			Id:       photo.Id,
+			Url:      photo.Url,
+			Position: photo.Position,
+		})
+	}
+	return result, nil
+}
+
+// store keeps every rendition of the photo under a new random key of the user and returns the key.
+func (s *photoService) store(ctx context.Context, userId int, data []byte) (string, error) {
+	img, err := decode(data)
+	if err != nil {
+		return "", err
+	}
+	files, err := renditions(img)
+	if err != nil {
+		return "", err
+	}
+
+	random := make([]byte, keyLength)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	key := fmt.Sprintf("photos/%d/%s", userId, hex.EncodeToString(random))
+
+	for size, file := range files {
+		if err := s.storageRepository.Put(ctx, key+"/"+size+".jpg", file); err != nil {
+			s.removeKey(ctx, key)
+			return "", err
+		}
+	}
+	return key, nil
+}
+
+// ownKey tells whether key is one store made for userId.
+func (s *photoService) ownKey(userId int, key string) bool {
+	prefix := fmt.Sprintf("photos/%d/", userId)
+	if !strings.HasPrefix(key, prefix) {
+		return false
+	}
+	random, err := hex.DecodeString(strings.TrimPrefix(key, prefix))
+	return err == nil && len(random) == keyLength
+}
+
+// removeKey deletes every rendition store may have written under key, one file at a time.
+func (s *photoService) removeKey(ctx context.Context, key string) error {
+	sizes := []string{models.PhotoSizeOriginal}
+	for size := range models.PhotoSizes {
+		sizes = append(sizes, size)
+	}
+	var result error
+	for _, size := range sizes {
+		if err := s.storageRepository.Delete(ctx, key+"/"+size+".jpg"); err != nil && result == nil {
+			result = err
+		}
+	}
+	return result
+}
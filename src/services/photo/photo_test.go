@@ -0,0 +1,715 @@
+package photo_test
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	mock_photo "DatingApp/src/repositories/mock/photo"
+	mock_storage "DatingApp/src/repositories/mock/storage"
+	mock_user "DatingApp/src/repositories/mock/user"
+	"DatingApp/src/repositories/storage"
+	"DatingApp/src/services/photo"
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func runTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func pngOf(width, height int) []byte {
+	var buf bytes.Buffer
+	png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)))
+	return buf.Bytes()
+}
+
+type mockfields struct {
+	photo   *mock_photo.MockInterface
+	user    *mock_user.MockInterface
+	storage *mock_storage.MockInterface
+}
+
+func initService(ctrl *gomock.Controller) (photo.Interface, mockfields) {
+	mocks := mockfields{
+		photo:   mock_photo.NewMockInterface(ctrl),
+		user:    mock_user.NewMockInterface(ctrl),
+		storage: mock_storage.NewMockInterface(ctrl),
+	}
+	service := photo.Init(photo.Param{
+		PhotoRepository:   mocks.photo,
+		UserRepository:    mocks.user,
+		StorageRepository: mocks.storage,
+	})
+	return service, mocks
+}
+
+var photoPaging = filter.Paging[filter.PhotoFilter]{
+	OrderBy:  "position asc",
+	IsActive: true,
+	Filter: filter.PhotoFilter{
+		UserId: 1,
+	},
+}
+
+func Test_photoService_Upload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+	service, mock := initService(ctrl)
+
+	mockTime := time.Date(2022, 5, 11, 0, 20, 0, 0, time.UTC)
+	photo.Now = func() time.Time {
+		return mockTime
+	}
+	defer func() {
+		photo.Now = time.Now
+	}()
+
+	full := []models.Photo{}
+	for i := 0; i < models.MaxPhotos; i++ {
+		full = append(full, models.Photo{Id: int64(i + 1), UserId: 1, Url: "https://cdn.example.com/a.jpg", Position: i})
+	}
+	signed := map[string]string{
+		models.PhotoSizeSmall:  "signed",
+		models.PhotoSizeMedium: "signed",
+		models.PhotoSizeLarge:  "signed",
+	}
+
+	tests := []struct {
+		name     string
+		data     []byte
+		mockfunc func()
+		want     models.ProfilePhoto
+		wantErr  error
+	}{
+		{
+			name: "get photos error",
+			data: pngOf(10, 10),
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return([]models.Photo{}, 0, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "too many photos",
+			data: pngOf(10, 10),
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return(full, len(full), nil)
+			},
+			wantErr: models.ErrTooManyPhotos,
+		},
+		{
+			name: "not an image",
+			data: []byte("hello, this is not an image"),
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return([]models.Photo{}, 0, nil)
+			},
+			wantErr: models.ErrUnsupportedPhoto,
+		},
+		{
+			name: "storage error",
+			data: pngOf(10, 10),
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return([]models.Photo{}, 0, nil)
+				mock.storage.EXPECT().Put(context, gomock.Any(), gomock.Any()).Return(assert.AnError)
+				mock.storage.EXPECT().Delete(context, gomock.Any()).Return(nil).Times(len(models.PhotoSizes) + 1)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "lock user error removes the files",
+			data: pngOf(10, 10),
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return([]models.Photo{}, 0, nil)
+				mock.storage.EXPECT().Put(context, gomock.Any(), gomock.Any()).Return(nil).Times(len(models.PhotoSizes) + 1)
+				mock.photo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.user.EXPECT().Lock(context, 1).Return(assert.AnError)
+				mock.storage.EXPECT().Delete(context, gomock.Any()).Return(nil).Times(len(models.PhotoSizes) + 1)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "another upload took the last slot meanwhile",
+			data: pngOf(10, 10),
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return(full[:models.MaxPhotos-1], models.MaxPhotos-1, nil)
+				mock.storage.EXPECT().Put(context, gomock.Any(), gomock.Any()).Return(nil).Times(len(models.PhotoSizes) + 1)
+				mock.photo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.user.EXPECT().Lock(context, 1).Return(nil)
+				mock.photo.EXPECT().Get(context, photoPaging).Return(full, len(full), nil)
+				mock.storage.EXPECT().Delete(context, gomock.Any()).Return(nil).Times(len(models.PhotoSizes) + 1)
+			},
+			wantErr: models.ErrTooManyPhotos,
+		},
+		{
+			name: "create photo error removes the files",
+			data: pngOf(10, 10),
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return([]models.Photo{}, 0, nil)
+				mock.storage.EXPECT().Put(context, gomock.Any(), gomock.Any()).Return(nil).Times(len(models.PhotoSizes) + 1)
+				mock.photo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.user.EXPECT().Lock(context, 1).Return(nil)
+				mock.photo.EXPECT().Get(context, photoPaging).Return([]models.Photo{}, 0, nil)
+				mock.photo.EXPECT().CreatePhoto(context, gomock.Any()).Return(0, assert.AnError)
+				mock.storage.EXPECT().Delete(context, gomock.Any()).Return(nil).Times(len(models.PhotoSizes) + 1)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "first photo becomes the image",
+			data: pngOf(10, 10),
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return([]models.Photo{}, 0, nil)
+				mock.storage.EXPECT().Put(context, gomock.Any(), gomock.Any()).Return(nil).Times(len(models.PhotoSizes) + 1)
+				mock.photo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.user.EXPECT().Lock(context, 1).Return(nil)
+				mock.photo.EXPECT().Get(context, photoPaging).Return([]models.Photo{}, 0, nil)
+				mock.photo.EXPECT().CreatePhoto(context, gomock.Any()).DoAndReturn(func(ctx interface{}, input models.PhotoInput) (int, error) {
+					assert.Equal(t, 1, input.UserId)
+					assert.Regexp(t, "^storage://photos/1/[0-9a-f]{32}$", input.Url)
+					assert.Equal(t, 0, input.Position)
+					assert.Equal(t, mockTime, input.CreatedAt)
+					return 5, nil
+				})
+				mock.user.EXPECT().UpdateImage(context, 1, gomock.Any(), mockTime).Return(nil)
+				mock.storage.EXPECT().SignedUrl(gomock.Any(), gomock.Any()).Return("signed").Times(len(models.PhotoSizes) + 1)
+			},
+			want: models.ProfilePhoto{Id: 5, Url: "signed", Position: 0, Thumbnails: signed},
+		},
+		{
+			name: "next photo goes last",
+			data: pngOf(10, 10),
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return(full[:2], 2, nil)
+				mock.storage.EXPECT().Put(context, gomock.Any(), gomock.Any()).Return(nil).Times(len(models.PhotoSizes) + 1)
+				mock.photo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.user.EXPECT().Lock(context, 1).Return(nil)
+				mock.photo.EXPECT().Get(context, photoPaging).Return(full[:2], 2, nil)
+				mock.photo.EXPECT().CreatePhoto(context, gomock.Any()).Return(7, nil)
+				mock.storage.EXPECT().SignedUrl(gomock.Any(), gomock.Any()).Return("signed").Times(len(models.PhotoSizes) + 1)
+			},
+			want: models.ProfilePhoto{Id: 7, Url: "signed", Position: 2, Thumbnails: signed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc()
+
+			result, err := service.Upload(context, tt.data)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func Test_photoService_Reorder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+	service, mock := initService(ctrl)
+
+	mockTime := time.Date(2022, 5, 11, 0, 20, 0, 0, time.UTC)
+	photo.Now = func() time.Time {
+		return mockTime
+	}
+	defer func() {
+		photo.Now = time.Now
+	}()
+
+	photos := []models.Photo{
+		{Id: 5, UserId: 1, Url: "https://cdn.example.com/a.jpg", Position: 0},
+		{Id: 7, UserId: 1, Url: "https://cdn.example.com/b.jpg", Position: 1},
+	}
+
+	tests := []struct {
+		name     string
+		input    models.PhotoOrderRequest
+		mockfunc func()
+		want     []models.ProfilePhoto
+		wantErr  error
+	}{
+		{
+			name:  "get photos error",
+			input: models.PhotoOrderRequest{Ids: []int{7, 5}},
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return([]models.Photo{}, 0, assert.AnError)
+			},
+			want:    []models.ProfilePhoto{},
+			wantErr: assert.AnError,
+		},
+		{
+			name:  "missing a photo",
+			input: models.PhotoOrderRequest{Ids: []int{7}},
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return(photos, 2, nil)
+			},
+			want:    []models.ProfilePhoto{},
+			wantErr: models.ErrInvalidPhotoOrder,
+		},
+		{
+			name:  "photo of someone else",
+			input: models.PhotoOrderRequest{Ids: []int{7, 9}},
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return(photos, 2, nil)
+			},
+			want:    []models.ProfilePhoto{},
+			wantErr: models.ErrInvalidPhotoOrder,
+		},
+		{
+			name:  "reorder error",
+			input: models.PhotoOrderRequest{Ids: []int{7, 5}},
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return(photos, 2, nil)
+				mock.photo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.photo.EXPECT().Reorder(context, 1, []int{7, 5}, mockTime).Return(assert.AnError)
+			},
+			want:    []models.ProfilePhoto{},
+			wantErr: assert.AnError,
+		},
+		{
+			name:  "update image error",
+			input: models.PhotoOrderRequest{Ids: []int{7, 5}},
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return(photos, 2, nil)
+				mock.photo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.photo.EXPECT().Reorder(context, 1, []int{7, 5}, mockTime).Return(nil)
+				mock.user.EXPECT().UpdateImage(context, 1, "https://cdn.example.com/b.jpg", mockTime).Return(assert.AnError)
+			},
+			want:    []models.ProfilePhoto{},
+			wantErr: assert.AnError,
+		},
+		{
+			name:  "reorder success",
+			input: models.PhotoOrderRequest{Ids: []int{7, 5}},
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return(photos, 2, nil)
+				mock.photo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.photo.EXPECT().Reorder(context, 1, []int{7, 5}, mockTime).Return(nil)
+				mock.user.EXPECT().UpdateImage(context, 1, "https://cdn.example.com/b.jpg", mockTime).Return(nil)
+			},
+			want: []models.ProfilePhoto{
+				{Id: 7, Url: "https://cdn.example.com/b.jpg", Position: 0},
+				{Id: 5, Url: "https://cdn.example.com/a.jpg", Position: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc()
+
+			result, err := service.Reorder(context, tt.input)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func Test_photoService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+	service, mock := initService(ctrl)
+
+	mockTime := time.Date(2022, 5, 11, 0, 20, 0, 0, time.UTC)
+	photo.Now = func() time.Time {
+		return mockTime
+	}
+	defer func() {
+		photo.Now = time.Now
+	}()
+
+	photos := []models.Photo{
+		{Id: 5, UserId: 1, Url: "storage://photos/1/00112233445566778899aabbccddeeff", Position: 0},
+		{Id: 7, UserId: 1, Url: "https://cdn.example.com/b.jpg", Position: 1},
+	}
+	deleteInput := func(id int) (models.Query[models.PhotoInput], int) {
+		return models.Query[models.PhotoInput]{
+			Model: models.PhotoInput{
+				Status:    -1,
+				DeletedAt: mockTime,
+				DeletedBy: 1,
+			},
+		}, id
+	}
+
+	tests := []struct {
+		name     string
+		id       int
+		mockfunc func()
+		wantErr  bool
+	}{
+		{
+			name: "get photos error",
+			id:   5,
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return([]models.Photo{}, 0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "photo of someone else",
+			id:   9,
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return(photos, 2, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "update photo error",
+			id:   5,
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return(photos, 2, nil)
+				mock.photo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				input, id := deleteInput(5)
+				mock.photo.EXPECT().Update(context, input, id).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "delete uploaded first photo",
+			id:   5,
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return(photos, 2, nil)
+				mock.photo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				input, id := deleteInput(5)
+				mock.photo.EXPECT().Update(context, input, id).Return(nil)
+				mock.photo.EXPECT().Reorder(context, 1, []int{7}, mockTime).Return(nil)
+				mock.user.EXPECT().UpdateImage(context, 1, "https://cdn.example.com/b.jpg", mockTime).Return(nil)
+				for _, size := range []string{"original", "small", "medium", "large"} {
+					mock.storage.EXPECT().Delete(context, "photos/1/00112233445566778899aabbccddeeff/"+size+".jpg").Return(nil)
+				}
+			},
+		},
+		{
+			name: "delete last photo clears the image",
+			id:   7,
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return(photos[1:], 1, nil)
+				mock.photo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				input, id := deleteInput(7)
+				mock.photo.EXPECT().Update(context, input, id).Return(nil)
+				mock.photo.EXPECT().Reorder(context, 1, []int{}, mockTime).Return(nil)
+				mock.user.EXPECT().UpdateImage(context, 1, "", mockTime).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc()
+
+			err := service.Delete(context, tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("photo.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_photoService_Open(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.Background()
+	service, mock := initService(ctrl)
+
+	mockTime := time.Date(2022, 5, 11, 0, 20, 0, 0, time.UTC)
+	photo.Now = func() time.Time {
+		return mockTime
+	}
+	defer func() {
+		photo.Now = time.Now
+	}()
+
+	query := models.PhotoQuery{Expires: 1652234400, Signature: "abc"}
+
+	tests := []struct {
+		name     string
+		mockfunc func()
+		want     []byte
+		wantErr  error
+	}{
+		{
+			name: "invalid signature",
+			mockfunc: func() {
+				mock.storage.EXPECT().VerifyUrl("photos/1/abc/small.jpg", query.Expires, query.Signature, mockTime).Return(models.ErrInvalidSignature)
+			},
+			wantErr: models.ErrInvalidSignature,
+		},
+		{
+			name: "open success",
+			mockfunc: func() {
+				mock.storage.EXPECT().VerifyUrl("photos/1/abc/small.jpg", query.Expires, query.Signature, mockTime).Return(nil)
+				mock.storage.EXPECT().Get(context, "photos/1/abc/small.jpg").Return([]byte("jpeg"), nil)
+			},
+			want: []byte("jpeg"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc()
+
+			data, err := service.Open(context, "photos/1/abc/small.jpg", query)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, data)
+		})
+	}
+}
+
+func Test_photoService_Url(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, mock := initService(ctrl)
+
+	mockTime := time.Date(2022, 5, 11, 0, 20, 0, 0, time.UTC)
+	photo.Now = func() time.Time {
+		return mockTime
+	}
+	defer func() {
+		photo.Now = time.Now
+	}()
+	expiresAt := time.Date(2022, 5, 11, 2, 0, 0, 0, time.UTC)
+
+	legacy := "https://cdn.example.com/a.jpg"
+	assert.Equal(t, legacy, service.Url(legacy, models.PhotoSizeSmall))
+	assert.Equal(t, &legacy, service.ImageUrl(&legacy))
+	assert.Nil(t, service.ImageUrl(nil))
+	assert.Equal(t, models.ProfilePhoto{Id: 1, Url: legacy}, service.Resolve(models.ProfilePhoto{Id: 1, Url: legacy}))
+
+	stored := "storage://photos/1/abc"
+	mock.storage.EXPECT().SignedUrl("photos/1/abc/medium.jpg", expiresAt).Return("signed-medium")
+	assert.Equal(t, "signed-medium", *service.ImageUrl(&stored))
+
+	mock.storage.EXPECT().SignedUrl("photos/1/abc/original.jpg", expiresAt).Return("signed-original")
+	mock.storage.EXPECT().SignedUrl("photos/1/abc/small.jpg", expiresAt).Return("signed-small")
+	mock.storage.EXPECT().SignedUrl("photos/1/abc/medium.jpg", expiresAt).Return("signed-medium")
+	mock.storage.EXPECT().SignedUrl("photos/1/abc/large.jpg", expiresAt).Return("signed-large")
+	assert.Equal(t, models.ProfilePhoto{
+		Id:       1,
+		Url:      "signed-original",
+		Position: 2,
+		Thumbnails: map[string]string{
+			models.PhotoSizeSmall:  "signed-small",
+			models.PhotoSizeMedium: "signed-medium",
+			models.PhotoSizeLarge:  "signed-large",
+		},
+	}, service.Resolve(models.ProfilePhoto{Id: 1, Url: stored, Position: 2}))
+}
+
+func Test_photoService_Store(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+	service, mock := initService(ctrl)
+
+	tests := []struct {
+		name     string
+		data     []byte
+		mockfunc func()
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "not an image",
+			data:     []byte("hello, this is not an image"),
+			mockfunc: func() {},
+			wantErr:  models.ErrUnsupportedPhoto,
+		},
+		{
+			name: "storage error",
+			data: pngOf(10, 10),
+			mockfunc: func() {
+				mock.storage.EXPECT().Put(context, gomock.Any(), gomock.Any()).Return(assert.AnError)
+				mock.storage.EXPECT().Delete(context, gomock.Any()).Return(nil).Times(len(models.PhotoSizes) + 1)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "store success",
+			data: pngOf(10, 10),
+			mockfunc: func() {
+				mock.storage.EXPECT().Put(context, gomock.Any(), gomock.Any()).Return(nil).Times(len(models.PhotoSizes) + 1)
+			},
+			want: "^storage://photos/1/[0-9a-f]{32}$",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc()
+
+			image, err := service.Store(context, tt.data)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Regexp(t, tt.want, image)
+			}
+		})
+	}
+}
+
+func Test_photoService_Remove(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.Background()
+	service, mock := initService(ctrl)
+
+	assert.NoError(t, service.Remove(context, 1, "https://cdn.example.com/a.jpg"))
+
+	for _, image := range []string{
+		"storage://.",
+		"storage://photos",
+		"storage://photos/1",
+		"storage://photos/1/abc",
+		"storage://photos/2/00112233445566778899aabbccddeeff",
+		"storage://photos/1/00112233445566778899aabbccddeeff/original.jpg",
+	} {
+		assert.ErrorIs(t, service.Remove(context, 1, image), storage.ErrInvalidKey, image)
+	}
+
+	mock.storage.EXPECT().Delete(context, "photos/1/00112233445566778899aabbccddeeff/original.jpg").Return(assert.AnError)
+	for _, size := range []string{"small", "medium", "large"} {
+		mock.storage.EXPECT().Delete(context, "photos/1/00112233445566778899aabbccddeeff/"+size+".jpg").Return(nil)
+	}
+	assert.ErrorIs(t, service.Remove(context, 1, "storage://photos/1/00112233445566778899aabbccddeeff"), assert.AnError)
+}
+
+func Test_photoService_Replace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+	service, mock := initService(ctrl)
+
+	mockTime := time.Date(2022, 5, 11, 0, 20, 0, 0, time.UTC)
+	photo.Now = func() time.Time {
+		return mockTime
+	}
+	defer func() {
+		photo.Now = time.Now
+	}()
+
+	uploaded := "storage://photos/1/00112233445566778899aabbccddeeff"
+	photos := []models.Photo{
+		{Id: 5, UserId: 1, Url: uploaded, Position: 0},
+		{Id: 7, UserId: 1, Url: "https://cdn.example.com/b.jpg", Position: 1},
+	}
+	deleted := models.Query[models.PhotoInput]{
+		Model: models.PhotoInput{
+			Status:    -1,
+			DeletedAt: mockTime,
+			DeletedBy: 1,
+		},
+	}
+	mockSigned := func() {
+		mock.storage.EXPECT().SignedUrl(gomock.Any(), gomock.Any()).DoAndReturn(func(key string, expiresAt time.Time) string {
+			return "https://app.example.com/" + key + "?expires=2&signature=def"
+		}).AnyTimes()
+	}
+
+	tests := []struct {
+		name     string
+		urls     []string
+		mockfunc func()
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name: "get photos error",
+			urls: []string{},
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return([]models.Photo{}, 0, assert.AnError)
+			},
+			want:    []string{},
+			wantErr: true,
+		},
+		{
+			name: "update image error",
+			urls: []string{"https://cdn.example.com/c.jpg"},
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return(photos, 2, nil)
+				mock.photo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.photo.EXPECT().Update(context, deleted, 5).Return(nil)
+				mock.photo.EXPECT().Update(context, deleted, 7).Return(nil)
+				mock.photo.EXPECT().CreatePhoto(context, models.PhotoInput{UserId: 1, Url: "https://cdn.example.com/c.jpg", CreatedAt: mockTime, CreatedBy: 1}).Return(9, nil)
+				mock.user.EXPECT().UpdateImage(context, 1, "https://cdn.example.com/c.jpg", mockTime).Return(assert.AnError)
+			},
+			want:    []string{},
+			wantErr: true,
+		},
+		{
+			name: "replace photos",
+			urls: []string{"https://cdn.example.com/c.jpg", "https://cdn.example.com/d.jpg"},
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return(photos, 2, nil)
+				mock.photo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.photo.EXPECT().Update(context, deleted, 5).Return(nil)
+				mock.photo.EXPECT().Update(context, deleted, 7).Return(nil)
+				mock.photo.EXPECT().CreatePhoto(context, models.PhotoInput{UserId: 1, Url: "https://cdn.example.com/c.jpg", CreatedAt: mockTime, CreatedBy: 1}).Return(9, nil)
+				mock.photo.EXPECT().CreatePhoto(context, models.PhotoInput{UserId: 1, Url: "https://cdn.example.com/d.jpg", Position: 1, CreatedAt: mockTime, CreatedBy: 1}).Return(10, nil)
+				mock.user.EXPECT().UpdateImage(context, 1, "https://cdn.example.com/c.jpg", mockTime).Return(nil)
+			},
+			want: []string{uploaded, "https://cdn.example.com/b.jpg"},
+		},
+		{
+			name: "signed url of an upload keeps it",
+			urls: []string{"https://cdn.example.com/c.jpg", "https://app.example.com/" + strings.TrimPrefix(uploaded, models.StorageScheme) + "/small.jpg?expires=1&signature=abc"},
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return(photos, 2, nil)
+				mockSigned()
+				mock.photo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.photo.EXPECT().Update(context, deleted, 5).Return(nil)
+				mock.photo.EXPECT().Update(context, deleted, 7).Return(nil)
+				mock.photo.EXPECT().CreatePhoto(context, models.PhotoInput{UserId: 1, Url: "https://cdn.example.com/c.jpg", CreatedAt: mockTime, CreatedBy: 1}).Return(9, nil)
+				mock.photo.EXPECT().CreatePhoto(context, models.PhotoInput{UserId: 1, Url: uploaded, Position: 1, CreatedAt: mockTime, CreatedBy: 1}).Return(10, nil)
+				mock.user.EXPECT().UpdateImage(context, 1, "https://cdn.example.com/c.jpg", mockTime).Return(nil)
+			},
+			want: []string{"https://cdn.example.com/b.jpg"},
+		},
+		{
+			name: "signed url of another upload",
+			urls: []string{"https://app.example.com/photos/2/ffeeddccbbaa99887766554433221100/original.jpg?expires=1&signature=abc"},
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return(photos, 2, nil)
+				mockSigned()
+			},
+			want:    []string{},
+			wantErr: true,
+		},
+		{
+			name: "same upload twice",
+			urls: []string{"https://app.example.com/" + strings.TrimPrefix(uploaded, models.StorageScheme) + "/original.jpg?expires=1&signature=abc", "https://app.example.com/" + strings.TrimPrefix(uploaded, models.StorageScheme) + "/large.jpg?expires=1&signature=abc"},
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return(photos, 2, nil)
+				mockSigned()
+			},
+			want:    []string{},
+			wantErr: true,
+		},
+		{
+			name: "no photos clears the image",
+			urls: []string{},
+			mockfunc: func() {
+				mock.photo.EXPECT().Get(context, photoPaging).Return(photos[1:], 1, nil)
+				mock.photo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.photo.EXPECT().Update(context, deleted, 7).Return(nil)
+				mock.user.EXPECT().UpdateImage(context, 1, "", mockTime).Return(nil)
+			},
+			want: []string{"https://cdn.example.com/b.jpg"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc()
+
+			dropped, err := service.Replace(context, tt.urls)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("photo.Replace() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, dropped)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package photo
+
+import (
+	"DatingApp/src/models"
+	"bytes"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+	"sort"
+)
+
+const (
+	jpegQuality = 85
+	// maxPixels keeps a small file claiming a huge size from being decoded
+	maxPixels = 40_000_000
+)
+
+var allowedTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// decode sniffs what data really is rather than trusting the client, then decodes it.
+func decode(data []byte) (image.Image, error) {
+	if len(data) > models.MaxPhotoBytes {
+		return nil, models.ErrPhotoTooLarge
+	}
+	if !allowedTypes[http.DetectContentType(data)] {
+		return nil, models.ErrUnsupportedPhoto
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, models.ErrUnsupportedPhoto
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, models.ErrPhotoTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, models.ErrUnsupportedPhoto
+	}
+	return img, nil
+}
+
+// renditions encodes img as a jpeg at its own size and at every models.PhotoSizes, re-encoding the
+// original drops whatever metadata came with it. Every thumbnail is scaled from the next bigger one.
+func renditions(img image.Image) (map[string][]byte, error) {
+	result := map[string][]byte{}
+
+	original, err := encode(img)
+	if err != nil {
+		return nil, err
+	}
+	result[models.PhotoSizeOriginal] = original
+
+	sizes := []string{}
+	for size := range models.PhotoSizes {
+		sizes = append(sizes, size)
+	}
+	sort.Slice(sizes, func(i, j int) bool {
+		return models.PhotoSizes[sizes[i]] > models.PhotoSizes[sizes[j]]
+	})
+
+	for _, size := range sizes {
+		img = thumbnail(img, models.PhotoSizes[size])
+		data, err := encode(img)
+		if err != nil {
+			return nil, err
+		}
+		result[size] = data
+	}
+	return result, nil
+}
+
+func encode(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// thumbnail scales img down so its longest side is at most size by averaging the pixels each one covers,
+// smaller images are kept as they are.
+func thumbnail(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	longest := width
+	if height > longest {
+		longest = height
+	}
+	if longest <= size {
+		return img
+	}
+
+	newWidth := max(1, width*size/longest)
+	newHeight := max(1, height*size/longest)
+	result := image.NewRGBA(image.Rect(0, 0, newWidth, newHeight))
+	for y := 0; y < newHeight; y++ {
+		y0 := bounds.Min.Y + y*height/newHeight
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*height/newHeight)
+		for x := 0; x < newWidth; x++ {
+			x0 := bounds.Min.X + x*width/newWidth
+			x1 := max(x0+1, bounds.Min.X+(x+1)*width/newWidth)
+
+			var r, g, b, a, count uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := img.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					count++
+				}
+			}
+			offset := result.PixOffset(x, y)
+			result.Pix[offset] = uint8(r / count >> 8)
+			result.Pix[offset+1] = uint8(g / count >> 8)
+			result.Pix[offset+2] = uint8(b / count >> 8)
+			result.Pix[offset+3] = uint8(a / count >> 8)
+		}
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+package photo
+
+import (
+	"DatingApp/src/models"
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func pngOf(width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{
+			name:    "not an image",
+			data:    []byte("<html><body>hello</body></html>"),
+			wantErr: models.ErrUnsupportedPhoto,
+		},
+		{
+			name:    "too large",
+			data:    make([]byte, models.MaxPhotoBytes+1),
+			wantErr: models.ErrPhotoTooLarge,
+		},
+		{
+			name:    "png header only",
+			data:    pngOf(4, 4)[:40],
+			wantErr: models.ErrUnsupportedPhoto,
+		},
+		{
+			name: "png",
+			data: pngOf(4, 4),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decode(tt.data)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRenditions(t *testing.T) {
+	img, err := decode(pngOf(1600, 800))
+	assert.NoError(t, err)
+
+	files, err := renditions(img)
+	assert.NoError(t, err)
+	assert.Len(t, files, len(models.PhotoSizes)+1)
+
+	want := map[string]image.Point{
+		models.PhotoSizeOriginal: {1600, 800},
+		models.PhotoSizeLarge:    {1080, 540},
+		models.PhotoSizeMedium:   {480, 240},
+		models.PhotoSizeSmall:    {160, 80},
+	}
+	for size, dimension := range want {
+		config, err := jpeg.DecodeConfig(bytes.NewReader(files[size]))
+		assert.NoError(t, err)
+		assert.Equal(t, dimension, image.Point{config.Width, config.Height}, size)
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	small := image.NewRGBA(image.Rect(0, 0, 100, 50))
+	assert.Equal(t, image.Image(small), thumbnail(small, 160))
+
+	tall := image.NewRGBA(image.Rect(0, 0, 30, 300))
+	for y := 0; y < 300; y++ {
+		for x := 0; x < 30; x++ {
+			tall.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	result := thumbnail(tall, 100)
+	assert.Equal(t, image.Rect(0, 0, 10, 100), result.Bounds())
+	assert.Equal(t, color.RGBA{R: 255, A: 255}, result.At(5, 50))
+}
@@ -0,0 +1,94 @@
+package preference
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/deck"
+	"DatingApp/src/repositories/preference"
+	"DatingApp/src/repositories/profile"
+	"context"
+	"time"
+)
+
+type Interface interface {
+	Get(ctx context.Context) (models.UserPreference, error)
+	Update(ctx context.Context, input models.PreferenceRequest) error
+}
+
+type preferenceService struct {
+	preferenceRepository preference.Interface
+	profileRepository    profile.Interface
+	deckRepository       deck.Interface
+}
+
+type Param struct {
+	PreferenceRepository preference.Interface
+	ProfileRepository    profile.Interface
+	DeckRepository       deck.Interface
+}
+
+func Init(param Param) Interface {
+	return &preferenceService{
+		preferenceRepository: param.PreferenceRepository,
+		profileRepository:    param.ProfileRepository,
+		deckRepository:       param.DeckRepository,
+	}
+}
+
+var Now = time.Now
+
+// Get returns the saved preferences of the user or the defaults when nothing is saved yet.
+func (s *preferenceService) Get(ctx context.Context) (models.UserPreference, error) {
+	userId := ctx.Value(models.UserKey).(models.User).Id
+
+	result := models.UserPreference{
+		MinAge:  models.DefaultMinAge,
+		MaxAge:  models.DefaultMaxAge,
+		Genders: []string{},
+	}
+
+	preferences, _, err := s.preferenceRepository.Get(ctx, filter.Paging[filter.PreferenceFilter]{
+		IsActive: true,
+		Filter: filter.PreferenceFilter{
+			UserId: int(userId),
+		},
+	})
+	if err != nil {
+		return result, err
+	}
+	if len(preferences) > 0 {
+		result.MinAge = preferences[0].MinAge
+		result.MaxAge = preferences[0].MaxAge
+		result.MaxDistanceKm = preferences[0].MaxDistanceKm
+	}
+
+	result.Genders, err = s.profileRepository.GetInterestedGenders(ctx, int(userId))
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// Update saves the preferences and drops the deck of the user since its cards were picked with the old ones.
+func (s *preferenceService) Update(ctx context.Context, input models.PreferenceRequest) error {
+	userId := ctx.Value(models.UserKey).(models.User).Id
+	now := Now()
+
+	return s.preferenceRepository.Transaction(ctx, func(ctx context.Context) error {
+		err := s.preferenceRepository.SavePreference(ctx, models.PreferenceInput{
+			UserId:        int(userId),
+			MinAge:        input.MinAge,
+			MaxAge:        input.MaxAge,
+			MaxDistanceKm: input.MaxDistanceKm,
+			CreatedAt:     now,
+			CreatedBy:     userId,
+			UpdatedAt:     now,
+			UpdatedBy:     userId,
+		})
+		if err != nil {
+			return err
+		}
+		return s.deckRepository.Clear(ctx, int(userId))
+	})
+}
@@ -0,0 +1,187 @@
+package preference_test
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	mock_deck "DatingApp/src/repositories/mock/deck"
+	mock_preference "DatingApp/src/repositories/mock/preference"
+	mock_profile "DatingApp/src/repositories/mock/profile"
+	"DatingApp/src/services/preference"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func runTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func Test_preferenceService_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	preferenceRepo := mock_preference.NewMockInterface(ctrl)
+	profileRepo := mock_profile.NewMockInterface(ctrl)
+	type mockfields struct {
+		preference *mock_preference.MockInterface
+		profile    *mock_profile.MockInterface
+	}
+	mocks := mockfields{
+		preference: preferenceRepo,
+		profile:    profileRepo,
+	}
+	params := preference.Param{
+		PreferenceRepository: preferenceRepo,
+		ProfileRepository:    profileRepo,
+	}
+	service := preference.Init(params)
+
+	paging := filter.Paging[filter.PreferenceFilter]{
+		IsActive: true,
+		Filter: filter.PreferenceFilter{
+			UserId: 1,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		mockfunc func(mock mockfields)
+		want     models.UserPreference
+		wantErr  bool
+	}{
+		{
+			name: "get preference error",
+			mockfunc: func(mock mockfields) {
+				mock.preference.EXPECT().Get(context, paging).Return([]models.Preference{}, 0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "nothing saved yet uses defaults",
+			mockfunc: func(mock mockfields) {
+				mock.preference.EXPECT().Get(context, paging).Return([]models.Preference{}, 0, nil)
+				mock.profile.EXPECT().GetInterestedGenders(context, 1).Return([]string{}, nil)
+			},
+			want: models.UserPreference{
+				MinAge:  models.DefaultMinAge,
+				MaxAge:  models.DefaultMaxAge,
+				Genders: []string{},
+			},
+		},
+		{
+			name: "get interested genders error",
+			mockfunc: func(mock mockfields) {
+				mock.preference.EXPECT().Get(context, paging).Return([]models.Preference{}, 0, nil)
+				mock.profile.EXPECT().GetInterestedGenders(context, 1).Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "get preference success",
+			mockfunc: func(mock mockfields) {
+				mock.preference.EXPECT().Get(context, paging).Return([]models.Preference{{UserId: 1, MinAge: 21, MaxAge: 30, MaxDistanceKm: 25}}, 1, nil)
+				mock.profile.EXPECT().GetInterestedGenders(context, 1).Return([]string{models.GenderFemale}, nil)
+			},
+			want: models.UserPreference{
+				MinAge:        21,
+				MaxAge:        30,
+				MaxDistanceKm: 25,
+				Genders:       []string{models.GenderFemale},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(mocks)
+
+			result, err := service.Get(context)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("preference.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, result)
+			}
+		})
+	}
+}
+
+func Test_preferenceService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	preferenceRepo := mock_preference.NewMockInterface(ctrl)
+	deckRepo := mock_deck.NewMockInterface(ctrl)
+	service := preference.Init(preference.Param{
+		PreferenceRepository: preferenceRepo,
+		DeckRepository:       deckRepo,
+	})
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	preference.Now = func() time.Time {
+		return mockTime
+	}
+
+	restoreAll := func() {
+		preference.Now = time.Now
+	}
+	defer restoreAll()
+
+	input := models.PreferenceInput{
+		UserId:        1,
+		MinAge:        21,
+		MaxAge:        30,
+		MaxDistanceKm: 25,
+		CreatedAt:     mockTime,
+		CreatedBy:     1,
+		UpdatedAt:     mockTime,
+		UpdatedBy:     1,
+	}
+
+	tests := []struct {
+		name     string
+		mockfunc func()
+		wantErr  bool
+	}{
+		{
+			name: "save preference error",
+			mockfunc: func() {
+				preferenceRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				preferenceRepo.EXPECT().SavePreference(context, input).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "clear deck error",
+			mockfunc: func() {
+				preferenceRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				preferenceRepo.EXPECT().SavePreference(context, input).Return(nil)
+				deckRepo.EXPECT().Clear(context, 1).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "save preference success",
+			mockfunc: func() {
+				preferenceRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				preferenceRepo.EXPECT().SavePreference(context, input).Return(nil)
+				deckRepo.EXPECT().Clear(context, 1).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc()
+
+			err := service.Update(context, models.PreferenceRequest{MinAge: 21, MaxAge: 30, MaxDistanceKm: 25})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("preference.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
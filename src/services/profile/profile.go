@@ -0,0 +1,146 @@
+package profile
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/deck"
+	"DatingApp/src/repositories/profile"
+	"DatingApp/src/services/photo"
+	"context"
+	"time"
+)
+
+type Interface interface {
+	Get(ctx context.Context) (models.UserProfile, error)
+	Update(ctx context.Context, input models.ProfileRequest) error
+}
+
+type profileService struct {
+	profileRepository profile.Interface
+	deckRepository    deck.Interface
+	photoService      photo.Interface
+}
+
+type Param struct {
+	ProfileRepository profile.Interface
+	DeckRepository    deck.Interface
+	PhotoService      photo.Interface
+}
+
+func Init(param Param) Interface {
+	return &profileService{
+		profileRepository: param.ProfileRepository,
+		deckRepository:    param.DeckRepository,
+		photoService:      param.PhotoService,
+	}
+}
+
+var Now = time.Now
+
+func (s *profileService) Get(ctx context.Context) (models.UserProfile, error) {
+	userId := ctx.Value(models.UserKey).(models.User).Id
+
+	result := models.UserProfile{
+		UserId:       userId,
+		InterestedIn: []string{},
+		Interests:    []string{},
+		Photos:       []models.ProfilePhoto{},
+	}
+
+	profiles, _, err := s.profileRepository.Get(ctx, filter.Paging[filter.ProfileFilter]{
+		IsActive: true,
+		Filter: filter.ProfileFilter{
+			UserId: int(userId),
+		},
+	})
+	if err != nil {
+		return result, err
+	}
+	if len(profiles) == 0 {
+		return result, nil
+	}
+
+	data := profiles[0]
+	result.DisplayName = data.DisplayName
+	result.Birthdate = data.Birthdate.Format(models.BirthdateLayout)
+	result.Age = models.Age(data.Birthdate, Now())
+	result.Gender = data.Gender
+	result.Bio = data.Bio
+	result.HeightCm = data.HeightCm
+	result.Job = data.Job
+
+	result.InterestedIn, err = s.profileRepository.GetInterestedGenders(ctx, int(userId))
+	if err != nil {
+		return result, err
+	}
+	result.Interests, err = s.profileRepository.GetInterests(ctx, int(userId))
+	if err != nil {
+		return result, err
+	}
+	photos, err := s.profileRepository.GetPhotos(ctx, int(userId))
+	if err != nil {
+		return result, err
+	}
+	for _, photo := range photos {
+		result.Photos = append(result.Photos, s.photoService.Resolve(photo))
+	}
+
+	return result, nil
+}
+
+// Update replaces the whole profile, the deck of the user is dropped as the wanted genders may have changed.
+// The photos are only replaced when input has them so uploaded photos survive an update without photos.
+func (s *profileService) Update(ctx context.Context, input models.ProfileRequest) error {
+	userId := ctx.Value(models.UserKey).(models.User).Id
+
+	birthdate, err := time.Parse(models.BirthdateLayout, input.Birthdate)
+	if err != nil {
+		return err
+	}
+	now := Now()
+	if models.Age(birthdate, now) < models.MinimumAge {
+		return models.ErrUnderage
+	}
+
+	dropped := []string{}
+	err = s.profileRepository.Transaction(ctx, func(ctx context.Context) error {
+		err := s.profileRepository.SaveProfile(ctx, models.ProfileInput{
+			UserId:      int(userId),
+			DisplayName: input.DisplayName,
+			Birthdate:   birthdate,
+			Gender:      input.Gender,
+			Bio:         input.Bio,
+			HeightCm:    input.HeightCm,
+			Job:         input.Job,
+			CreatedAt:   now,
+			CreatedBy:   userId,
+			UpdatedAt:   now,
+			UpdatedBy:   userId,
+		})
+		if err != nil {
+			return err
+		}
+		if err := s.profileRepository.ReplaceInterestedGenders(ctx, int(userId), input.InterestedIn); err != nil {
+			return err
+		}
+		if err := s.profileRepository.ReplaceInterests(ctx, int(userId), input.Interests); err != nil {
+			return err
+		}
+		if input.Photos != nil {
+			dropped, err = s.photoService.Replace(ctx, input.Photos)
+			if err != nil {
+				return err
+			}
+		}
+		return s.deckRepository.Clear(ctx, int(userId))
+	})
+	if err != nil {
+		return err
+	}
+
+	// removing the files of replaced uploads is best effort, the photos are already gone
+	for _, image := range dropped {
+		s.photoService.Remove(ctx, int(userId), image)
+	}
+	return nil
+}
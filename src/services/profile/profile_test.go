@@ -0,0 +1,467 @@
+package profile_test
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	mock_deck "DatingApp/src/repositories/mock/deck"
+	mock_photo "DatingApp/src/repositories/mock/photo"
+	mock_profile "DatingApp/src/repositories/mock/profile"
+	mock_storage "DatingApp/src/repositories/mock/storage"
+	mock_user "DatingApp/src/repositories/mock/user"
+	"DatingApp/src/services/photo"
+	"DatingApp/src/services/profile"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func runTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func Test_profileService_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	profileRepo := mock_profile.NewMockInterface(ctrl)
+	type mockfields struct {
+		profile *mock_profile.MockInterface
+	}
+	mocks := mockfields{
+		profile: profileRepo,
+	}
+	storageRepo := mock_storage.NewMockInterface(ctrl)
+	params := profile.Param{
+		ProfileRepository: profileRepo,
+		PhotoService:      photo.Init(photo.Param{StorageRepository: storageRepo}),
+	}
+	service := profile.Init(params)
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	profile.Now = func() time.Time {
+		return mockTime
+	}
+	photo.Now = func() time.Time {
+		return mockTime
+	}
+
+	restoreAll := func() {
+		profile.Now = time.Now
+		photo.Now = time.Now
+	}
+	defer restoreAll()
+
+	paging := filter.Paging[filter.ProfileFilter]{
+		IsActive: true,
+		Filter: filter.ProfileFilter{
+			UserId: 1,
+		},
+	}
+	data := models.Profile{
+		Id:          1,
+		UserId:      1,
+		DisplayName: "Yudha",
+		Birthdate:   time.Date(1995, 5, 12, 0, 0, 0, 0, time.UTC),
+		Gender:      models.GenderMale,
+		Bio:         "hello",
+		HeightCm:    170,
+		Job:         "Engineer",
+	}
+	photos := []models.ProfilePhoto{
+		{Id: 3, Url: "https://cdn.example.com/a.jpg", Position: 0},
+		{Id: 4, Url: "storage://photos/1/abc", Position: 1},
+	}
+	expiresAt := mockTime.Add(2 * models.PhotoUrlTTL)
+	empty := models.UserProfile{
+		UserId:       1,
+		InterestedIn: []string{},
+		Interests:    []string{},
+		Photos:       []models.ProfilePhoto{},
+	}
+
+	tests := []struct {
+		name     string
+		mockfunc func(mock mockfields)
+		want     models.UserProfile
+		wantErr  bool
+	}{
+		{
+			name: "get profile error",
+			mockfunc: func(mock mockfields) {
+				mock.profile.EXPECT().Get(context, paging).Return([]models.Profile{}, 0, assert.AnError)
+			},
+			want:    empty,
+			wantErr: true,
+		},
+		{
+			name: "profile not filled yet",
+			mockfunc: func(mock mockfields) {
+				mock.profile.EXPECT().Get(context, paging).Return([]models.Profile{}, 0, nil)
+			},
+			want: empty,
+		},
+		{
+			name: "get photos error",
+			mockfunc: func(mock mockfields) {
+				mock.profile.EXPECT().Get(context, paging).Return([]models.Profile{data}, 1, nil)
+				mock.profile.EXPECT().GetInterestedGenders(context, 1).Return([]string{models.GenderFemale}, nil)
+				mock.profile.EXPECT().GetInterests(context, 1).Return([]string{"hiking"}, nil)
+				mock.profile.EXPECT().GetPhotos(context, 1).Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "get profile success",
+			mockfunc: func(mock mockfields) {
+				mock.profile.EXPECT().Get(context, paging).Return([]models.Profile{data}, 1, nil)
+				mock.profile.EXPECT().GetInterestedGenders(context, 1).Return([]string{models.GenderFemale}, nil)
+				mock.profile.EXPECT().GetInterests(context, 1).Return([]string{"hiking"}, nil)
+				mock.profile.EXPECT().GetPhotos(context, 1).Return(photos, nil)
+				storageRepo.EXPECT().SignedUrl(gomock.Any(), expiresAt).DoAndReturn(func(key string, expiresAt time.Time) string {
+					return "https://app.example.com/" + key
+				}).Times(len(models.PhotoSizes) + 1)
+			},
+			want: models.UserProfile{
+				UserId:       1,
+				DisplayName:  "Yudha",
+				Birthdate:    "1995-05-12",
+				Age:          26,
+				Gender:       models.GenderMale,
+				InterestedIn: []string{models.GenderFemale},
+				Bio:          "hello",
+				HeightCm:     170,
+				Job:          "Engineer",
+				Interests:    []string{"hiking"},
+				Photos: []models.ProfilePhoto{
+					photos[0],
+					{
+						Id:       4,
+						Url:      "https://app.example.com/photos/1/abc/original.jpg",
+						Position: 1,
+						Thumbnails: map[string]string{
+							models.PhotoSizeSmall:  "https://app.example.com/photos/1/abc/small.jpg",
+							models.PhotoSizeMedium: "https://app.example.com/photos/1/abc/medium.jpg",
+							models.PhotoSizeLarge:  "https://app.example.com/photos/1/abc/large.jpg",
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(mocks)
+
+			result, err := service.Get(context)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("profile.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, result)
+			}
+		})
+	}
+}
+
+func Test_profileService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	profileRepo := mock_profile.NewMockInterface(ctrl)
+	deckRepo := mock_deck.NewMockInterface(ctrl)
+	photoRepo := mock_photo.NewMockInterface(ctrl)
+	userRepo := mock_user.NewMockInterface(ctrl)
+	storageRepo := mock_storage.NewMockInterface(ctrl)
+	type mockfields struct {
+		profile *mock_profile.MockInterface
+		deck    *mock_deck.MockInterface
+		photo   *mock_photo.MockInterface
+		user    *mock_user.MockInterface
+		storage *mock_storage.MockInterface
+	}
+	mocks := mockfields{
+		profile: profileRepo,
+		deck:    deckRepo,
+		photo:   photoRepo,
+		user:    userRepo,
+		storage: storageRepo,
+	}
+	params := profile.Param{
+		ProfileRepository: profileRepo,
+		DeckRepository:    deckRepo,
+		PhotoService: photo.Init(photo.Param{
+			PhotoRepository:   photoRepo,
+			UserRepository:    userRepo,
+			StorageRepository: storageRepo,
+		}),
+	}
+	service := profile.Init(params)
+	type args struct {
+		Input models.ProfileRequest
+	}
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	profile.Now = func() time.Time {
+		return mockTime
+	}
+	photo.Now = func() time.Time {
+		return mockTime
+	}
+
+	restoreAll := func() {
+		profile.Now = time.Now
+		photo.Now = time.Now
+	}
+	defer restoreAll()
+
+	photoPaging := filter.Paging[filter.PhotoFilter]{
+		OrderBy:  "position asc",
+		IsActive: true,
+		Filter: filter.PhotoFilter{
+			UserId: 1,
+		},
+	}
+	uploaded := "storage://photos/1/00112233445566778899aabbccddeeff"
+	deletePhoto := models.Query[models.PhotoInput]{
+		Model: models.PhotoInput{
+			Status:    -1,
+			DeletedAt: mockTime,
+			DeletedBy: 1,
+		},
+	}
+	createPhoto := models.PhotoInput{
+		UserId:    1,
+		Url:       "https://cdn.example.com/a.jpg",
+		CreatedAt: mockTime,
+		CreatedBy: 1,
+	}
+
+	request := models.ProfileRequest{
+		DisplayName:  "Yudha",
+		Birthdate:    "1995-05-12",
+		Gender:       models.GenderMale,
+		InterestedIn: []string{models.GenderFemale},
+		Interests:    []string{"hiking"},
+		Photos:       []string{"https://cdn.example.com/a.jpg"},
+	}
+	input := models.ProfileInput{
+		UserId:      1,
+		DisplayName: "Yudha",
+		Birthdate:   time.Date(1995, 5, 12, 0, 0, 0, 0, time.UTC),
+		Gender:      models.GenderMale,
+		CreatedAt:   mockTime,
+		CreatedBy:   1,
+		UpdatedAt:   mockTime,
+		UpdatedBy:   1,
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		mockfunc func(a args, mock mockfields)
+		wantErr  error
+	}{
+		{
+			name: "underage user",
+			args: args{
+				Input: models.ProfileRequest{
+					DisplayName:  "Yudha",
+					Birthdate:    "2004-05-12",
+					Gender:       models.GenderMale,
+					InterestedIn: []string{models.GenderFemale},
+				},
+			},
+			mockfunc: func(a args, mock mockfields) {},
+			wantErr:  models.ErrUnderage,
+		},
+		{
+			name: "save profile error",
+			args: args{Input: request},
+			mockfunc: func(a args, mock mockfields) {
+				mock.profile.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.profile.EXPECT().SaveProfile(context, input).Return(assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "replace photos error",
+			args: args{Input: request},
+			mockfunc: func(a args, mock mockfields) {
+				mock.profile.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.profile.EXPECT().SaveProfile(context, input).Return(nil)
+				mock.profile.EXPECT().ReplaceInterestedGenders(context, 1, request.InterestedIn).Return(nil)
+				mock.profile.EXPECT().ReplaceInterests(context, 1, request.Interests).Return(nil)
+				mock.photo.EXPECT().Get(context, photoPaging).Return([]models.Photo{}, 0, nil)
+				mock.photo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.photo.EXPECT().CreatePhoto(context, createPhoto).Return(0, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "update profile success",
+			args: args{Input: request},
+			mockfunc: func(a args, mock mockfields) {
+				mock.profile.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.profile.EXPECT().SaveProfile(context, input).Return(nil)
+				mock.profile.EXPECT().ReplaceInterestedGenders(context, 1, request.InterestedIn).Return(nil)
+				mock.profile.EXPECT().ReplaceInterests(context, 1, request.Interests).Return(nil)
+				mock.photo.EXPECT().Get(context, photoPaging).Return([]models.Photo{}, 0, nil)
+				mock.photo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.photo.EXPECT().CreatePhoto(context, createPhoto).Return(3, nil)
+				mock.user.EXPECT().UpdateImage(context, 1, "https://cdn.example.com/a.jpg", mockTime).Return(nil)
+				mock.deck.EXPECT().Clear(context, 1).Return(nil)
+			},
+		},
+		{
+			name: "update profile removes the replaced uploads",
+			args: args{Input: request},
+			mockfunc: func(a args, mock mockfields) {
+				mock.profile.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.profile.EXPECT().SaveProfile(context, input).Return(nil)
+				mock.profile.EXPECT().ReplaceInterestedGenders(context, 1, request.InterestedIn).Return(nil)
+				mock.profile.EXPECT().ReplaceInterests(context, 1, request.Interests).Return(nil)
+				mock.photo.EXPECT().Get(context, photoPaging).Return([]models.Photo{{Id: 2, UserId: 1, Url: uploaded}}, 1, nil)
+				mock.photo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.photo.EXPECT().Update(context, deletePhoto, 2).Return(nil)
+				mock.photo.EXPECT().CreatePhoto(context, createPhoto).Return(3, nil)
+				mock.user.EXPECT().UpdateImage(context, 1, "https://cdn.example.com/a.jpg", mockTime).Return(nil)
+				mock.deck.EXPECT().Clear(context, 1).Return(nil)
+				mock.storage.EXPECT().Delete(context, gomock.Any()).Return(nil).Times(len(models.PhotoSizes) + 1)
+			},
+		},
+		{
+			name: "update profile keeps the uploads when it fails",
+			args: args{Input: request},
+			mockfunc: func(a args, mock mockfields) {
+				mock.profile.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.profile.EXPECT().SaveProfile(context, input).Return(nil)
+				mock.profile.EXPECT().ReplaceInterestedGenders(context, 1, request.InterestedIn).Return(nil)
+				mock.profile.EXPECT().ReplaceInterests(context, 1, request.Interests).Return(nil)
+				mock.photo.EXPECT().Get(context, photoPaging).Return([]models.Photo{{Id: 2, UserId: 1, Url: uploaded}}, 1, nil)
+				mock.photo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.photo.EXPECT().Update(context, deletePhoto, 2).Return(nil)
+				mock.photo.EXPECT().CreatePhoto(context, createPhoto).Return(3, nil)
+				mock.user.EXPECT().UpdateImage(context, 1, "https://cdn.example.com/a.jpg", mockTime).Return(nil)
+				mock.deck.EXPECT().Clear(context, 1).Return(assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "update profile keeps the photos",
+			args: args{
+				Input: models.ProfileRequest{
+					DisplayName:  "Yudha",
+					Birthdate:    "1995-05-12",
+					Gender:       models.GenderMale,
+					InterestedIn: []string{models.GenderFemale},
+					Interests:    []string{"hiking"},
+				},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.profile.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.profile.EXPECT().SaveProfile(context, input).Return(nil)
+				mock.profile.EXPECT().ReplaceInterestedGenders(context, 1, request.InterestedIn).Return(nil)
+				mock.profile.EXPECT().ReplaceInterests(context, 1, request.Interests).Return(nil)
+				mock.deck.EXPECT().Clear(context, 1).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(tt.args, mocks)
+
+			err := service.Update(context, tt.args.Input)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_profileService_GetThenUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	profileRepo := mock_profile.NewMockInterface(ctrl)
+	deckRepo := mock_deck.NewMockInterface(ctrl)
+	photoRepo := mock_photo.NewMockInterface(ctrl)
+	userRepo := mock_user.NewMockInterface(ctrl)
+	storageRepo := mock_storage.NewMockInterface(ctrl)
+	service := profile.Init(profile.Param{
+		ProfileRepository: profileRepo,
+		DeckRepository:    deckRepo,
+		PhotoService: photo.Init(photo.Param{
+			PhotoRepository:   photoRepo,
+			UserRepository:    userRepo,
+			StorageRepository: storageRepo,
+		}),
+	})
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	profile.Now = func() time.Time {
+		return mockTime
+	}
+	photo.Now = func() time.Time {
+		return mockTime
+	}
+	defer func() {
+		profile.Now = time.Now
+		photo.Now = time.Now
+	}()
+
+	uploaded := "storage://photos/1/00112233445566778899aabbccddeeff"
+	storageRepo.EXPECT().SignedUrl(gomock.Any(), gomock.Any()).DoAndReturn(func(key string, expiresAt time.Time) string {
+		return "https://app.example.com/api/v1/photos/" + key + "?expires=" + expiresAt.Format("150405") + "&signature=abc"
+	}).AnyTimes()
+
+	profileRepo.EXPECT().Get(context, gomock.Any()).Return([]models.Profile{{
+		Id:          1,
+		UserId:      1,
+		DisplayName: "Yudha",
+		Birthdate:   time.Date(1995, 5, 12, 0, 0, 0, 0, time.UTC),
+		Gender:      models.GenderMale,
+	}}, 1, nil)
+	profileRepo.EXPECT().GetInterestedGenders(context, 1).Return([]string{models.GenderFemale}, nil)
+	profileRepo.EXPECT().GetInterests(context, 1).Return([]string{}, nil)
+	profileRepo.EXPECT().GetPhotos(context, 1).Return([]models.ProfilePhoto{{Id: 2, Url: uploaded}}, nil)
+
+	got, err := service.Get(context)
+	assert.NoError(t, err)
+
+	// the links are read a while later, signed to expire at another time
+	photo.Now = func() time.Time {
+		return mockTime.Add(3 * models.PhotoUrlTTL)
+	}
+	profile.Now = photo.Now
+
+	request := models.ProfileRequest{
+		DisplayName:  got.DisplayName,
+		Birthdate:    got.Birthdate,
+		Gender:       got.Gender,
+		InterestedIn: got.InterestedIn,
+		Interests:    got.Interests,
+	}
+	for _, photo := range got.Photos {
+		request.Photos = append(request.Photos, photo.Url)
+	}
+
+	now := mockTime.Add(3 * models.PhotoUrlTTL)
+	profileRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+	profileRepo.EXPECT().SaveProfile(context, gomock.Any()).Return(nil)
+	profileRepo.EXPECT().ReplaceInterestedGenders(context, 1, got.InterestedIn).Return(nil)
+	profileRepo.EXPECT().ReplaceInterests(context, 1, got.Interests).Return(nil)
+	photoRepo.EXPECT().Get(context, gomock.Any()).Return([]models.Photo{{Id: 2, UserId: 1, Url: uploaded}}, 1, nil)
+	photoRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+	photoRepo.EXPECT().Update(context, gomock.Any(), 2).Return(nil)
+	photoRepo.EXPECT().CreatePhoto(context, models.PhotoInput{UserId: 1, Url: uploaded, CreatedAt: now, CreatedBy: 1}).Return(3, nil)
+	userRepo.EXPECT().UpdateImage(context, 1, uploaded, now).Return(nil)
+	deckRepo.EXPECT().Clear(context, 1).Return(nil)
+	// no storage Delete is expected, the files of the upload must survive
+
+	assert.NoError(t, service.Update(context, request))
+}
@@ -0,0 +1,172 @@
+package quota
+
+import (
+	"DatingApp/src/models"
+	quotapolicy "DatingApp/src/repositories/quota_policy"
+	useractivity "DatingApp/src/repositories/user_activity"
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	rollingPeriod = time.Hour * 24
+)
+
+type Interface interface {
+	Check(ctx context.Context, user models.User, activity string) error
+	Get(ctx context.Context) ([]models.Quota, error)
+}
+
+type quotaService struct {
+	quotaPolicyRepository  quotapolicy.Interface
+	userActivityRepository useractivity.Interface
+}
+
+type Param struct {
+	QuotaPolicyRepository  quotapolicy.Interface
+	UserActivityRepository useractivity.Interface
+}
+
+func Init(param Param) Interface {
+	return &quotaService{
+		quotaPolicyRepository:  param.QuotaPolicyRepository,
+		userActivityRepository: param.UserActivityRepository,
+	}
+}
+
+var Now = time.Now
+
+// Check returns models.ErrQuotaExceeded when the user can't do the activity anymore.
+func (s *quotaService) Check(ctx context.Context, user models.User, activity string) error {
+	quota, err := s.remaining(ctx, user, activity)
+	if err != nil {
+		return err
+	}
+	if !quota.Unlimited && quota.Remaining <= 0 {
+		return models.ErrQuotaExceeded
+	}
+	return nil
+}
+
+// Get returns the remaining quota of the current user for every activity.
+func (s *quotaService) Get(ctx context.Context) ([]models.Quota, error) {
+	user := ctx.Value(models.UserKey).(models.User)
+
+	result := []models.Quota{}
+	for _, activity := range []string{models.ActivityLike, models.ActivityPass, models.ActivitySuperLike, models.ActivityRewind} {
+		quota, err := s.remaining(ctx, user, activity)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, quota)
+	}
+	return result, nil
+}
+
+// remaining evaluates every policy which applies to the activity, the strictest one is the user's quota.
+func (s *quotaService) remaining(ctx context.Context, user models.User, activity string) (models.Quota, error) {
+	now := Now()
+	policies, err := s.quotaPolicyRepository.GetUserPolicies(ctx, int(user.Id), activity, now)
+	if err != nil {
+		return models.Quota{}, err
+	}
+
+	quota := models.Quota{Activity: activity, Unlimited: true}
+	for _, policy := range effectivePolicies(policies) {
+		if policy.IsUnlimited() {
+			continue
+		}
+		if activity == models.ActivityRewind && policy.Activity == models.ActivityAny {
+			continue
+		}
+
+		since, resetAt, err := periodWindow(policy.Period, now, user.Location())
+		if err != nil {
+			return models.Quota{}, err
+		}
+		count, err := s.userActivityRepository.CountActivity(ctx, int(user.Id), policy.Activity, since)
+		if err != nil {
+			return models.Quota{}, err
+		}
+		if policy.Period == models.QuotaPeriodRolling && count.Oldest.Valid {
+			// a rolling window frees up a swipe once the oldest one in it is older than the period
+			resetAt = count.Oldest.Data.Add(rollingPeriod)
+		}
+
+		remaining := policy.MaxActivity - count.Count
+		if remaining < 0 {
+			remaining = 0
+		}
+		if quota.Unlimited || remaining < quota.Remaining {
+			quota = models.Quota{
+				Activity:  activity,
+				Limit:     policy.MaxActivity,
+				Remaining: remaining,
+				ResetAt:   &resetAt,
+			}
+		}
+	}
+	return quota, nil
+}
+
+// effectivePolicies drops the default policies of an activity when the user has premium ones for it,
+// and keeps only the most generous policy of each activity and period.
+func effectivePolicies(policies []models.QuotaPolicy) []models.QuotaPolicy {
+	hasPremium := map[string]bool{}
+	for _, policy := range policies {
+		if policy.PremiumFeatureId.Valid {
+			hasPremium[policy.Activity] = true
+		}
+	}
+
+	type key struct {
+		activity string
+		period   string
+	}
+	order := []key{}
+	best := map[key]models.QuotaPolicy{}
+	for _, policy := range policies {
+		if hasPremium[policy.Activity] && !policy.PremiumFeatureId.Valid {
+			continue
+		}
+
+		k := key{activity: policy.Activity, period: policy.Period}
+		current, ok := best[k]
+		if !ok {
+			order = append(order, k)
+			best[k] = policy
+			continue
+		}
+		if current.IsUnlimited() {
+			continue
+		}
+		if policy.IsUnlimited() || policy.MaxActivity > current.MaxActivity {
+			best[k] = policy
+		}
+	}
+
+	result := []models.QuotaPolicy{}
+	for _, k := range order {
+		result = append(result, best[k])
+	}
+	return result
+}
+
+// periodWindow returns since when activities count toward the period and when it resets,
+// days and hours start in the user's loc.
+func periodWindow(period string, now time.Time, loc *time.Location) (time.Time, time.Time, error) {
+	switch period {
+	case models.QuotaPeriodDaily:
+		start, end := models.DayWindow(now, loc)
+		return start, end, nil
+	case models.QuotaPeriodHourly:
+		local := now.In(loc)
+		// not Truncate, it works on absolute time and would be off for zones with a half hour offset
+		start := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), 0, 0, 0, loc)
+		return start, start.Add(time.Hour), nil
+	case models.QuotaPeriodRolling:
+		return now.Add(-rollingPeriod), now.Add(rollingPeriod), nil
+	}
+	return time.Time{}, time.Time{}, errors.New("unknown quota period " + period)
+}
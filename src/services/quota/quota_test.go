@@ -0,0 +1,279 @@
+package quota_test
+
+import (
+	"DatingApp/src/formatter"
+	"DatingApp/src/models"
+	mock_quota_policy "DatingApp/src/repositories/mock/quota_policy"
+	mock_user_activity "DatingApp/src/repositories/mock/user_activity"
+	"DatingApp/src/services/quota"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_quotaService_Check(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.Background()
+
+	quotaPolicyRepo := mock_quota_policy.NewMockInterface(ctrl)
+	userActivityRepo := mock_user_activity.NewMockInterface(ctrl)
+	type mockfields struct {
+		quotaPolicy  *mock_quota_policy.MockInterface
+		userActivity *mock_user_activity.MockInterface
+	}
+	mocks := mockfields{
+		quotaPolicy:  quotaPolicyRepo,
+		userActivity: userActivityRepo,
+	}
+	params := quota.Param{
+		QuotaPolicyRepository:  quotaPolicyRepo,
+		UserActivityRepository: userActivityRepo,
+	}
+	service := quota.Init(params)
+
+	mockTime := time.Date(2022, 5, 11, 13, 30, 0, 0, time.UTC)
+	quota.Now = func() time.Time {
+		return mockTime
+	}
+
+	restoreAll := func() {
+		quota.Now = time.Now
+	}
+	defer restoreAll()
+
+	dayStart := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	hourStart := time.Date(2022, 5, 11, 13, 0, 0, 0, time.UTC)
+	defaultDaily := models.QuotaPolicy{Activity: models.ActivityAny, Period: models.QuotaPeriodDaily, MaxActivity: 10}
+	premium := formatter.NullableDataType[int64]{Valid: true, Data: 1}
+	defaultUser := models.User{Id: 1}
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		user     models.User
+		mockfunc func(mock mockfields)
+		wantErr  error
+	}{
+		{
+			name: "get policies error",
+			user: defaultUser,
+			mockfunc: func(mock mockfields) {
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivityLike, mockTime).Return([]models.QuotaPolicy{}, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "no policy is unlimited",
+			user: defaultUser,
+			mockfunc: func(mock mockfields) {
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivityLike, mockTime).Return([]models.QuotaPolicy{}, nil)
+			},
+		},
+		{
+			name: "unknown period",
+			user: defaultUser,
+			mockfunc: func(mock mockfields) {
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivityLike, mockTime).Return([]models.QuotaPolicy{{Activity: models.ActivityAny, Period: "weekly", MaxActivity: 1}}, nil)
+			},
+			wantErr: errors.New("unknown quota period weekly"),
+		},
+		{
+			name: "count activity error",
+			user: defaultUser,
+			mockfunc: func(mock mockfields) {
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivityLike, mockTime).Return([]models.QuotaPolicy{defaultDaily}, nil)
+				mock.userActivity.EXPECT().CountActivity(context, 1, models.ActivityAny, dayStart).Return(models.ActivityCount{}, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "daily quota reached",
+			user: defaultUser,
+			mockfunc: func(mock mockfields) {
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivityLike, mockTime).Return([]models.QuotaPolicy{defaultDaily}, nil)
+				mock.userActivity.EXPECT().CountActivity(context, 1, models.ActivityAny, dayStart).Return(models.ActivityCount{Count: 10}, nil)
+			},
+			wantErr: models.ErrQuotaExceeded,
+		},
+		{
+			name: "daily quota left",
+			user: defaultUser,
+			mockfunc: func(mock mockfields) {
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivityLike, mockTime).Return([]models.QuotaPolicy{defaultDaily}, nil)
+				mock.userActivity.EXPECT().CountActivity(context, 1, models.ActivityAny, dayStart).Return(models.ActivityCount{Count: 9}, nil)
+			},
+		},
+		{
+			name: "premium unlimited replaces default",
+			user: defaultUser,
+			mockfunc: func(mock mockfields) {
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivityLike, mockTime).Return([]models.QuotaPolicy{
+					defaultDaily,
+					{Activity: models.ActivityAny, PremiumFeatureId: premium, Period: models.QuotaPeriodDaily, MaxActivity: models.QuotaUnlimited},
+				}, nil)
+			},
+		},
+		{
+			name: "most generous premium policy wins",
+			user: defaultUser,
+			mockfunc: func(mock mockfields) {
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivityLike, mockTime).Return([]models.QuotaPolicy{
+					defaultDaily,
+					{Activity: models.ActivityAny, PremiumFeatureId: premium, Period: models.QuotaPeriodDaily, MaxActivity: 20},
+					{Activity: models.ActivityAny, PremiumFeatureId: formatter.NullableDataType[int64]{Valid: true, Data: 2}, Period: models.QuotaPeriodDaily, MaxActivity: 50},
+				}, nil)
+				mock.userActivity.EXPECT().CountActivity(context, 1, models.ActivityAny, dayStart).Return(models.ActivityCount{Count: 30}, nil)
+			},
+		},
+		{
+			name: "hourly like quota reached while daily quota left",
+			user: defaultUser,
+			mockfunc: func(mock mockfields) {
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivityLike, mockTime).Return([]models.QuotaPolicy{
+					defaultDaily,
+					{Activity: models.ActivityLike, Period: models.QuotaPeriodHourly, MaxActivity: 3},
+				}, nil)
+				mock.userActivity.EXPECT().CountActivity(context, 1, models.ActivityAny, dayStart).Return(models.ActivityCount{Count: 3}, nil)
+				mock.userActivity.EXPECT().CountActivity(context, 1, models.ActivityLike, hourStart).Return(models.ActivityCount{Count: 3}, nil)
+			},
+			wantErr: models.ErrQuotaExceeded,
+		},
+		{
+			name: "daily quota starts at midnight of user timezone",
+			user: models.User{Id: 1, Timezone: "Asia/Jakarta"},
+			mockfunc: func(mock mockfields) {
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivityLike, mockTime).Return([]models.QuotaPolicy{defaultDaily}, nil)
+				mock.userActivity.EXPECT().CountActivity(context, 1, models.ActivityAny, time.Date(2022, 5, 11, 0, 0, 0, 0, jakarta)).Return(models.ActivityCount{Count: 10}, nil)
+			},
+			wantErr: models.ErrQuotaExceeded,
+		},
+		{
+			name: "hourly quota starts at the hour of user timezone",
+			user: models.User{Id: 1, Timezone: "Asia/Kolkata"},
+			mockfunc: func(mock mockfields) {
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivityLike, mockTime).Return([]models.QuotaPolicy{
+					{Activity: models.ActivityLike, Period: models.QuotaPeriodHourly, MaxActivity: 3},
+				}, nil)
+				mock.userActivity.EXPECT().CountActivity(context, 1, models.ActivityLike, time.Date(2022, 5, 11, 19, 0, 0, 0, kolkata)).Return(models.ActivityCount{Count: 2}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(mocks)
+
+			err := service.Check(context, tt.user, models.ActivityLike)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_quotaService_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	quotaPolicyRepo := mock_quota_policy.NewMockInterface(ctrl)
+	userActivityRepo := mock_user_activity.NewMockInterface(ctrl)
+	type mockfields struct {
+		quotaPolicy  *mock_quota_policy.MockInterface
+		userActivity *mock_user_activity.MockInterface
+	}
+	mocks := mockfields{
+		quotaPolicy:  quotaPolicyRepo,
+		userActivity: userActivityRepo,
+	}
+	params := quota.Param{
+		QuotaPolicyRepository:  quotaPolicyRepo,
+		UserActivityRepository: userActivityRepo,
+	}
+	service := quota.Init(params)
+
+	mockTime := time.Date(2022, 5, 11, 13, 30, 0, 0, time.UTC)
+	quota.Now = func() time.Time {
+		return mockTime
+	}
+
+	restoreAll := func() {
+		quota.Now = time.Now
+	}
+	defer restoreAll()
+
+	dayStart := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	oldestLike := mockTime.Add(-time.Hour * 5)
+	rollingReset := oldestLike.Add(time.Hour * 24)
+
+	tests := []struct {
+		name     string
+		mockfunc func(mock mockfields)
+		want     []models.Quota
+		wantErr  bool
+	}{
+		{
+			name: "get like quota error",
+			mockfunc: func(mock mockfields) {
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivityLike, mockTime).Return([]models.QuotaPolicy{}, assert.AnError)
+			},
+			want:    []models.Quota{},
+			wantErr: true,
+		},
+		{
+			name: "get quota success",
+			mockfunc: func(mock mockfields) {
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivityLike, mockTime).Return([]models.QuotaPolicy{
+					{Activity: models.ActivityLike, Period: models.QuotaPeriodRolling, MaxActivity: 5},
+				}, nil)
+				mock.userActivity.EXPECT().CountActivity(context, 1, models.ActivityLike, mockTime.Add(-time.Hour*24)).Return(models.ActivityCount{
+					Count:  7,
+					Oldest: formatter.NullableDataType[time.Time]{Valid: true, Data: oldestLike},
+				}, nil)
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivityPass, mockTime).Return([]models.QuotaPolicy{
+					{Activity: models.ActivityPass, Period: models.QuotaPeriodDaily, MaxActivity: 10},
+				}, nil)
+				mock.userActivity.EXPECT().CountActivity(context, 1, models.ActivityPass, dayStart).Return(models.ActivityCount{Count: 4}, nil)
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivitySuperLike, mockTime).Return([]models.QuotaPolicy{
+					{Activity: models.ActivitySuperLike, Period: models.QuotaPeriodDaily, MaxActivity: 1},
+					{Activity: models.ActivityAny, Period: models.QuotaPeriodDaily, MaxActivity: 10},
+				}, nil)
+				mock.userActivity.EXPECT().CountActivity(context, 1, models.ActivitySuperLike, dayStart).Return(models.ActivityCount{Count: 0}, nil)
+				mock.userActivity.EXPECT().CountActivity(context, 1, models.ActivityAny, dayStart).Return(models.ActivityCount{Count: 4}, nil)
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivityRewind, mockTime).Return([]models.QuotaPolicy{
+					{Activity: models.ActivityRewind, Period: models.QuotaPeriodDaily, MaxActivity: 5},
+					{Activity: models.ActivityAny, Period: models.QuotaPeriodDaily, MaxActivity: 10},
+				}, nil)
+				mock.userActivity.EXPECT().CountActivity(context, 1, models.ActivityRewind, dayStart).Return(models.ActivityCount{Count: 1}, nil)
+			},
+			want: []models.Quota{
+				{Activity: models.ActivityLike, Limit: 5, Remaining: 0, ResetAt: &rollingReset},
+				{Activity: models.ActivityPass, Limit: 10, Remaining: 6, ResetAt: &dayEnd},
+				{Activity: models.ActivitySuperLike, Limit: 1, Remaining: 1, ResetAt: &dayEnd},
+				{Activity: models.ActivityRewind, Limit: 5, Remaining: 4, ResetAt: &dayEnd},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(mocks)
+
+			quotas, err := service.Get(context)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("quota.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, quotas)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package recomendation
+
+import (
+	"DatingApp/src/models"
+	"math"
+	"time"
+)
+
+// Ranker scores a candidate for the user asking, higher comes first.
+type Ranker interface {
+	Name() string
+	Score(candidate models.RecomendationCandidate, now time.Time) float64
+}
+
+// WeightedRanker adds up the signals of a candidate, each signal is between 0 and 1 and multiplied by its weight.
+type WeightedRanker struct {
+	Label           string
+	Recency         float64
+	Completeness    float64
+	MutualInterests float64
+	InboundLike     float64
+	Premium         float64
+	Proximity       float64
+}
+
+var DefaultRanker = WeightedRanker{
+	Label:           "default",
+	Recency:         3,
+	Completeness:    2,
+	MutualInterests: 2,
+	InboundLike:     1,
+	Premium:         1,
+	Proximity:       2,
+}
+
+const (
+	profileFields         = 3
+	maxMutualInterests    = 5
+	recencyHalfScoreHours = 24
+	proximityHalfScoreKm  = 10
+)
+
+func (r WeightedRanker) Name() string {
+	return r.Label
+}
+
+func (r WeightedRanker) Score(candidate models.RecomendationCandidate, now time.Time) float64 {
+	return r.Recency*recency(candidate, now) +
+		r.Completeness*completeness(candidate) +
+		r.MutualInterests*mutualInterests(candidate) +
+		r.InboundLike*flag(candidate.LikedYou) +
+		r.Premium*flag(candidate.Premium) +
+		r.Proximity*proximity(candidate)
+}
+
+// recency falls off hyperbolically with the time since the candidate last swiped, 1/(1+days),
+// so it is half after a day and a third after two, never swiping scores 0.
+func recency(candidate models.RecomendationCandidate, now time.Time) float64 {
+	if !candidate.LastActiveAt.Valid {
+		return 0
+	}
+	hours := math.Max(0, now.Sub(candidate.LastActiveAt.Data).Hours())
+	return 1 / (1 + hours/recencyHalfScoreHours)
+}
+
+// completeness counts the filled optional fields of the profile plus having photos and interests.
+func completeness(candidate models.RecomendationCandidate) float64 {
+	filled := float64(candidate.ProfileFields) + flag(candidate.PhotoCount > 0) + flag(candidate.InterestCount > 0)
+	return filled / (profileFields + 2)
+}
+
+func mutualInterests(candidate models.RecomendationCandidate) float64 {
+	return math.Min(float64(candidate.MutualInterests), maxMutualInterests) / maxMutualInterests
+}
+
+// proximity falls off hyperbolically with the distance, 1/(1+km/10),
+// so it is half at 10 km and a third at 20 km, an unknown distance scores 0.
+func proximity(candidate models.RecomendationCandidate) float64 {
+	if candidate.Distance == nil {
+		return 0
+	}
+	return 1 / (1 + *candidate.Distance/proximityHalfScoreKm)
+}
+
+func flag(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,173 @@
+package recomendation
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/boost"
+	"DatingApp/src/repositories/deck"
+	"DatingApp/src/repositories/preference"
+	"DatingApp/src/repositories/user"
+	"DatingApp/src/services/photo"
+	"context"
+	"sort"
+	"time"
+)
+
+const (
+	DefaultDeckSize = 20
+	DefaultPoolSize = 100
+)
+
+type Interface interface {
+	GetDeck(ctx context.Context, size int) (models.RecomendationDeck, error)
+}
+
+type recomendationService struct {
+	userRepository       user.Interface
+	preferenceRepository preference.Interface
+	deckRepository       deck.Interface
+	boostRepository      boost.Interface
+	photoService         photo.Interface
+	rankers              []Ranker
+	poolSize             int
+	passCooldown         time.Duration
+}
+
+// Param takes the rankers to A/B test, users are split evenly between them by id.
+// PassCooldown is how long a passed user stays out of the decks.
+type Param struct {
+	UserRepository       user.Interface
+	PreferenceRepository preference.Interface
+	DeckRepository       deck.Interface
+	BoostRepository      boost.Interface
+	PhotoService         photo.Interface
+	Rankers              []Ranker
+	PoolSize             int
+	PassCooldown         time.Duration
+}
+
+func Init(param Param) Interface {
+	rankers := param.Rankers
+	if len(rankers) == 0 {
+		rankers = []Ranker{DefaultRanker}
+	}
+	poolSize := param.PoolSize
+	if poolSize <= 0 {
+		poolSize = DefaultPoolSize
+	}
+	passCooldown := param.PassCooldown
+	if passCooldown <= 0 {
+		passCooldown = models.DefaultPassCooldown
+	}
+
+	return &recomendationService{
+		userRepository:       param.UserRepository,
+		preferenceRepository: param.PreferenceRepository,
+		deckRepository:       param.DeckRepository,
+		boostRepository:      param.BoostRepository,
+		photoService:         param.PhotoService,
+		rankers:              rankers,
+		poolSize:             poolSize,
+		passCooldown:         passCooldown,
+	}
+}
+
+var Now = time.Now
+
+// GetDeck ranks a pool of candidates and hands out the best size of them as a deck,
+// the cards are kept out of the next decks until they expire. Handing out a boosted
+// candidate counts as an impression of their boost.
+func (s *recomendationService) GetDeck(ctx context.Context, size int) (models.RecomendationDeck, error) {
+	user := ctx.Value(models.UserKey).(models.User)
+	if size <= 0 {
+		size = DefaultDeckSize
+	}
+
+	param := models.RecomendationParam{
+		UserId:   int(user.Id),
+		Timezone: user.Location(),
+		Origin:   user.Coordinate(),
+	}
+
+	// the max distance only matters once there is a location to measure from
+	if param.Origin != nil {
+		preferences, _, err := s.preferenceRepository.Get(ctx, filter.Paging[filter.PreferenceFilter]{
+			IsActive: true,
+			Filter: filter.PreferenceFilter{
+				UserId: int(user.Id),
+			},
+		})
+		if err != nil {
+			return models.RecomendationDeck{}, err
+		}
+		if len(preferences) > 0 {
+			param.MaxDistanceKm = preferences[0].MaxDistanceKm
+		}
+	}
+
+	result := models.RecomendationDeck{Cards: []models.RecomendationUser{}}
+	err := s.deckRepository.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.deckRepository.Lock(ctx, int(user.Id)); err != nil {
+			return err
+		}
+		now := Now()
+		if err := s.deckRepository.ClearExpired(ctx, int(user.Id), now); err != nil {
+			return err
+		}
+		param.PassedSince = now.Add(-s.passCooldown)
+
+		candidates, err := s.userRepository.GetCandidates(ctx, param, s.poolSize)
+		if err != nil {
+			return err
+		}
+		s.rank(user.Id, candidates, now)
+
+		candidateIds := []int64{}
+		boostedIds := []int64{}
+		for i := 0; i < len(candidates) && i < size; i++ {
+			card := candidates[i].RecomendationUser()
+			card.Image = s.photoService.ImageUrl(card.Image)
+			result.Cards = append(result.Cards, card)
+			candidateIds = append(candidateIds, candidates[i].Id)
+			if candidates[i].Boosted {
+				boostedIds = append(boostedIds, candidates[i].Id)
+			}
+		}
+		result.ExpiresAt = now.Add(models.DeckTTL)
+
+		if err := s.deckRepository.AddCards(ctx, int(user.Id), candidateIds, result.ExpiresAt); err != nil {
+			return err
+		}
+		return s.boostRepository.AddImpressions(ctx, boostedIds, now)
+	})
+	if err != nil {
+		return models.RecomendationDeck{}, err
+	}
+
+	return result, nil
+}
+
+// rank sorts candidates best first with the ranker of the user, whoever super liked the user
+// is always ahead of the rest and boosted candidates come right after them.
+func (s *recomendationService) rank(userId int64, candidates []models.RecomendationCandidate, now time.Time) {
+	ranker := s.ranker(userId)
+	scores := make(map[int64]float64, len(candidates))
+	for _, candidate := range candidates {
+		scores[candidate.Id] = ranker.Score(candidate, now)
+	}
+	// stable so equal scores keep the closest first order of the pool
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].SuperLikedYou != candidates[j].SuperLikedYou {
+			return candidates[i].SuperLikedYou
+		}
+		if candidates[i].Boosted != candidates[j].Boosted {
+			return candidates[i].Boosted
+		}
+		return scores[candidates[i].Id] > scores[candidates[j].Id]
+	})
+}
+
+// ranker picks the same ranker for a user every time so an A/B bucket stays stable.
+func (s *recomendationService) ranker(userId int64) Ranker {
+	return s.rankers[int(userId%int64(len(s.rankers)))]
+}
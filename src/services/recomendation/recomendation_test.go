@@ -0,0 +1,290 @@
+package recomendation_test
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/formatter"
+	"DatingApp/src/models"
+	mock_boost "DatingApp/src/repositories/mock/boost"
+	mock_deck "DatingApp/src/repositories/mock/deck"
+	mock_preference "DatingApp/src/repositories/mock/preference"
+	mock_storage "DatingApp/src/repositories/mock/storage"
+	mock_user "DatingApp/src/repositories/mock/user"
+	"DatingApp/src/services/photo"
+	"DatingApp/src/services/recomendation"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func runTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// likedRanker only cares about who liked the user, to tell the A/B buckets apart.
+type likedRanker struct{}
+
+func (likedRanker) Name() string {
+	return "liked"
+}
+
+func (likedRanker) Score(candidate models.RecomendationCandidate, now time.Time) float64 {
+	if candidate.LikedYou {
+		return 1
+	}
+	return 0
+}
+
+func Test_recomendationService_GetDeck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	type args struct {
+		Ctx  context.Context
+		Size int
+	}
+	located := models.User{
+		Id:        1,
+		Timezone:  "Asia/Jakarta",
+		Latitude:  formatter.NullableDataType[float64]{Valid: true, Data: -6.2},
+		Longitude: formatter.NullableDataType[float64]{Valid: true, Data: 106.8},
+	}
+	locatedContext := context.WithValue(context.Background(), models.UserKey, located)
+	// user 2 falls in the other bucket than user 1
+	otherBucketContext := context.WithValue(context.Background(), models.UserKey, models.User{Id: 2, Timezone: "Asia/Jakarta"})
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1, Timezone: "Asia/Jakarta"})
+
+	userRepo := mock_user.NewMockInterface(ctrl)
+	preferenceRepo := mock_preference.NewMockInterface(ctrl)
+	deckRepo := mock_deck.NewMockInterface(ctrl)
+	boostRepo := mock_boost.NewMockInterface(ctrl)
+	storageRepo := mock_storage.NewMockInterface(ctrl)
+	type mockfields struct {
+		user       *mock_user.MockInterface
+		preference *mock_preference.MockInterface
+		deck       *mock_deck.MockInterface
+		boost      *mock_boost.MockInterface
+		storage    *mock_storage.MockInterface
+	}
+	mocks := mockfields{
+		user:       userRepo,
+		preference: preferenceRepo,
+		deck:       deckRepo,
+		boost:      boostRepo,
+		storage:    storageRepo,
+	}
+	params := recomendation.Param{
+		UserRepository:       userRepo,
+		PreferenceRepository: preferenceRepo,
+		DeckRepository:       deckRepo,
+		BoostRepository:      boostRepo,
+		PhotoService:         photo.Init(photo.Param{StorageRepository: storageRepo}),
+		Rankers:              []recomendation.Ranker{likedRanker{}, recomendation.DefaultRanker},
+		PoolSize:             50,
+		PassCooldown:         7 * 24 * time.Hour,
+	}
+	service := recomendation.Init(params)
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	recomendation.Now = func() time.Time {
+		return mockTime
+	}
+	photo.Now = func() time.Time {
+		return mockTime
+	}
+
+	restoreAll := func() {
+		recomendation.Now = time.Now
+		photo.Now = time.Now
+	}
+	defer restoreAll()
+
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	preferencePaging := filter.Paging[filter.PreferenceFilter]{
+		IsActive: true,
+		Filter: filter.PreferenceFilter{
+			UserId: 1,
+		},
+	}
+	near, far, approximateNear := 1.2, 40.0, 1.0
+	// closest first the way the repository returns them
+	candidates := func() []models.RecomendationCandidate {
+		return []models.RecomendationCandidate{
+			{Id: 2, UserName: "idle", Distance: &near},
+			{Id: 3, UserName: "active", Distance: &far, LastActiveAt: formatter.NullableDataType[time.Time]{Valid: true, Data: mockTime}, ProfileFields: 3, PhotoCount: 2, InterestCount: 2, MutualInterests: 2},
+			{Id: 4, UserName: "liker", LikedYou: true},
+		}
+	}
+	approximateFar := 40.0
+	stored, signed := "storage://photos/5/abc", "https://app.example.com/photos/5/abc/medium.jpg"
+	expiresAt := mockTime.Add(models.DeckTTL)
+	passedSince := mockTime.AddDate(0, 0, -7)
+	lockDeck := func(a args, userId int, mock mockfields) {
+		mock.deck.EXPECT().Transaction(a.Ctx, gomock.Any()).DoAndReturn(runTransaction)
+		mock.deck.EXPECT().Lock(a.Ctx, userId).Return(nil)
+		mock.deck.EXPECT().ClearExpired(a.Ctx, userId, mockTime).Return(nil)
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		mockfunc func(a args, mock mockfields)
+		want     models.RecomendationDeck
+		wantErr  bool
+	}{
+		{
+			name: "get preference error",
+			args: args{Ctx: locatedContext},
+			mockfunc: func(a args, mock mockfields) {
+				mock.preference.EXPECT().Get(a.Ctx, preferencePaging).Return([]models.Preference{}, 0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "lock deck error",
+			args: args{Ctx: context},
+			mockfunc: func(a args, mock mockfields) {
+				mock.deck.EXPECT().Transaction(a.Ctx, gomock.Any()).DoAndReturn(runTransaction)
+				mock.deck.EXPECT().Lock(a.Ctx, 1).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "get candidates error",
+			args: args{Ctx: context},
+			mockfunc: func(a args, mock mockfields) {
+				lockDeck(a, 1, mock)
+				mock.user.EXPECT().GetCandidates(a.Ctx, models.RecomendationParam{UserId: 1, Timezone: jakarta, PassedSince: passedSince}, 50).Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "no candidates",
+			args: args{Ctx: context},
+			mockfunc: func(a args, mock mockfields) {
+				lockDeck(a, 1, mock)
+				mock.user.EXPECT().GetCandidates(a.Ctx, models.RecomendationParam{UserId: 1, Timezone: jakarta, PassedSince: passedSince}, 50).Return([]models.RecomendationCandidate{}, nil)
+				mock.deck.EXPECT().AddCards(a.Ctx, 1, []int64{}, expiresAt).Return(nil)
+				mock.boost.EXPECT().AddImpressions(a.Ctx, []int64{}, mockTime).Return(nil)
+			},
+			want: models.RecomendationDeck{Cards: []models.RecomendationUser{}, ExpiresAt: expiresAt},
+		},
+		{
+			name: "add cards error",
+			args: args{Ctx: context, Size: 1},
+			mockfunc: func(a args, mock mockfields) {
+				lockDeck(a, 1, mock)
+				mock.user.EXPECT().GetCandidates(a.Ctx, models.RecomendationParam{UserId: 1, Timezone: jakarta, PassedSince: passedSince}, 50).Return(candidates(), nil)
+				mock.deck.EXPECT().AddCards(a.Ctx, 1, []int64{3}, expiresAt).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "default ranker puts the active complete profile first",
+			args: args{Ctx: locatedContext, Size: 2},
+			mockfunc: func(a args, mock mockfields) {
+				mock.preference.EXPECT().Get(a.Ctx, preferencePaging).Return([]models.Preference{{UserId: 1, MaxDistanceKm: 50}}, 1, nil)
+				lockDeck(a, 1, mock)
+				mock.user.EXPECT().GetCandidates(a.Ctx, models.RecomendationParam{
+					UserId:        1,
+					Timezone:      jakarta,
+					Origin:        &models.Coordinate{Latitude: -6.2, Longitude: 106.8},
+					MaxDistanceKm: 50,
+					PassedSince:   passedSince,
+				}, 50).Return(candidates(), nil)
+				mock.deck.EXPECT().AddCards(a.Ctx, 1, []int64{3, 2}, expiresAt).Return(nil)
+				mock.boost.EXPECT().AddImpressions(a.Ctx, []int64{}, mockTime).Return(nil)
+			},
+			want: models.RecomendationDeck{
+				Cards: []models.RecomendationUser{
+					{Id: 3, UserName: "active", Distance: &approximateFar},
+					{Id: 2, UserName: "idle", Distance: &approximateNear},
+				},
+				ExpiresAt: expiresAt,
+			},
+		},
+		{
+			name: "super likers come before better ranked candidates",
+			args: args{Ctx: context, Size: 2},
+			mockfunc: func(a args, mock mockfields) {
+				lockDeck(a, 1, mock)
+				pool := append(candidates(), models.RecomendationCandidate{Id: 5, UserName: "super", SuperLikedYou: true})
+				mock.user.EXPECT().GetCandidates(a.Ctx, models.RecomendationParam{UserId: 1, Timezone: jakarta, PassedSince: passedSince}, 50).Return(pool, nil)
+				mock.deck.EXPECT().AddCards(a.Ctx, 1, []int64{5, 3}, expiresAt).Return(nil)
+				mock.boost.EXPECT().AddImpressions(a.Ctx, []int64{}, mockTime).Return(nil)
+			},
+			want: models.RecomendationDeck{
+				Cards: []models.RecomendationUser{
+					{Id: 5, UserName: "super", SuperLikedYou: true},
+					{Id: 3, UserName: "active", Distance: &approximateFar},
+				},
+				ExpiresAt: expiresAt,
+			},
+		},
+		{
+			name: "add impressions error",
+			args: args{Ctx: context, Size: 1},
+			mockfunc: func(a args, mock mockfields) {
+				lockDeck(a, 1, mock)
+				pool := append(candidates(), models.RecomendationCandidate{Id: 5, UserName: "boosted", Boosted: true})
+				mock.user.EXPECT().GetCandidates(a.Ctx, models.RecomendationParam{UserId: 1, Timezone: jakarta, PassedSince: passedSince}, 50).Return(pool, nil)
+				mock.deck.EXPECT().AddCards(a.Ctx, 1, []int64{5}, expiresAt).Return(nil)
+				mock.boost.EXPECT().AddImpressions(a.Ctx, []int64{5}, mockTime).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "boosted candidates come after super likers and before better ranked ones",
+			args: args{Ctx: context, Size: 3},
+			mockfunc: func(a args, mock mockfields) {
+				lockDeck(a, 1, mock)
+				pool := append(candidates(),
+					models.RecomendationCandidate{Id: 5, UserName: "boosted", Image: &stored, Boosted: true},
+					models.RecomendationCandidate{Id: 6, UserName: "super", SuperLikedYou: true, Verified: true},
+				)
+				mock.user.EXPECT().GetCandidates(a.Ctx, models.RecomendationParam{UserId: 1, Timezone: jakarta, PassedSince: passedSince}, 50).Return(pool, nil)
+				mock.storage.EXPECT().SignedUrl("photos/5/abc/medium.jpg", mockTime.Add(2*models.PhotoUrlTTL)).Return(signed)
+				mock.deck.EXPECT().AddCards(a.Ctx, 1, []int64{6, 5, 3}, expiresAt).Return(nil)
+				mock.boost.EXPECT().AddImpressions(a.Ctx, []int64{5}, mockTime).Return(nil)
+			},
+			want: models.RecomendationDeck{
+				Cards: []models.RecomendationUser{
+					{Id: 6, UserName: "super", SuperLikedYou: true, Verified: true},
+					{Id: 5, UserName: "boosted", Image: &signed},
+					{Id: 3, UserName: "active", Distance: &approximateFar},
+				},
+				ExpiresAt: expiresAt,
+			},
+		},
+		{
+			name: "other bucket uses the other ranker",
+			args: args{Ctx: otherBucketContext, Size: 1},
+			mockfunc: func(a args, mock mockfields) {
+				lockDeck(a, 2, mock)
+				mock.user.EXPECT().GetCandidates(a.Ctx, models.RecomendationParam{UserId: 2, Timezone: jakarta, PassedSince: passedSince}, 50).Return(candidates(), nil)
+				mock.deck.EXPECT().AddCards(a.Ctx, 2, []int64{4}, expiresAt).Return(nil)
+				mock.boost.EXPECT().AddImpressions(a.Ctx, []int64{}, mockTime).Return(nil)
+			},
+			want: models.RecomendationDeck{
+				Cards:     []models.RecomendationUser{{Id: 4, UserName: "liker"}},
+				ExpiresAt: expiresAt,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(tt.args, mocks)
+
+			deck, err := service.GetDeck(tt.args.Ctx, tt.args.Size)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("recomendation.GetDeck() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, deck)
+		})
+	}
+}
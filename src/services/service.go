@@ -1,11 +1,25 @@
 package services
 
 import (
+	"DatingApp/src/models"
 	"DatingApp/src/repositories"
 	"DatingApp/src/services/auth"
+	boost "DatingApp/src/services/boost"
+	match "DatingApp/src/services/match"
+	message "DatingApp/src/services/message"
+	moderation "DatingApp/src/services/moderation"
+	notification "DatingApp/src/services/notification"
+	payment "DatingApp/src/services/payment"
+	photo "DatingApp/src/services/photo"
+	preference "DatingApp/src/services/preference"
 	premiumfeature "DatingApp/src/services/premium_feature"
+	profile "DatingApp/src/services/profile"
+	"DatingApp/src/services/quota"
+	recomendation "DatingApp/src/services/recomendation"
+	subscription "DatingApp/src/services/subscription"
 	user "DatingApp/src/services/user"
 	useractivity "DatingApp/src/services/user_activity"
+	verification "DatingApp/src/services/verification"
 )
 
 type Services struct {
@@ -13,6 +27,19 @@ type Services struct {
 	User           user.Interface
 	UserActivity   useractivity.Interface
 	PremiumFeature premiumfeature.Interface
+	Match          match.Interface
+	Message        message.Interface
+	Notification   notification.Interface
+	Subscription   subscription.Interface
+	Payment        payment.Interface
+	Quota          quota.Interface
+	Profile        profile.Interface
+	Preference     preference.Interface
+	Recomendation  recomendation.Interface
+	Moderation     moderation.Interface
+	Boost          boost.Interface
+	Photo          photo.Interface
+	Verification   verification.Interface
 }
 
 type Param struct {
@@ -20,20 +47,118 @@ type Param struct {
 }
 
 func Init(param Param) *Services {
+	quotaService := quota.Init(quota.Param{
+		QuotaPolicyRepository:  param.Repositories.QuotaPolicy,
+		UserActivityRepository: param.Repositories.UserActivity,
+	})
+	photoService := photo.Init(photo.Param{
+		PhotoRepository:   param.Repositories.Photo,
+		UserRepository:    param.Repositories.User,
+		StorageRepository: param.Repositories.Storage,
+	})
+
 	return &Services{
-		Auth: auth.Init(auth.Param{UserRepository: param.Repositories.User, AuthRepository: param.Repositories.Auth}),
+		Auth: auth.Init(auth.Param{
+			UserRepository:          param.Repositories.User,
+			AuthRepository:          param.Repositories.Auth,
+			SessionRepository:       param.Repositories.Session,
+			PasswordResetRepository: param.Repositories.PasswordReset,
+			MailerRepository:        param.Repositories.Mailer,
+		}),
 		User: user.Init(user.Param{
-			UserRepository: param.Repositories.User,
+			UserRepository:        param.Repositories.User,
+			EntitlementRepository: param.Repositories.Entitlement,
+			DeckRepository:        param.Repositories.Deck,
+			PhotoService:          photoService,
 		},
 		),
 		UserActivity: useractivity.Init(useractivity.Param{
-			UserActivityRepository:   param.Repositories.UserActivity,
+			UserActivityRepository: param.Repositories.UserActivity,
+			UserRepository:         param.Repositories.User,
+			EntitlementRepository:  param.Repositories.Entitlement,
+			MatchRepository:        param.Repositories.Match,
+			BrokerRepository:       param.Repositories.Broker,
+			BlockRepository:        param.Repositories.Block,
+			QuotaService:           quotaService,
+			PhotoService:           photoService,
+		},
+		),
+		PremiumFeature: premiumfeature.Init(premiumfeature.Param{
+			PremiumFeatureRepository: param.Repositories.PremiumFeature,
+		},
+		),
+		Match: match.Init(match.Param{
+			MatchRepository: param.Repositories.Match,
+			PhotoService:    photoService,
+		},
+		),
+		Message: message.Init(message.Param{
+			MessageRepository: param.Repositories.Message,
+			MatchRepository:   param.Repositories.Match,
+			BrokerRepository:  param.Repositories.Broker,
+		},
+		),
+		Notification: notification.Init(notification.Param{
+			BrokerRepository: param.Repositories.Broker,
+		},
+		),
+		Subscription: subscription.Init(subscription.Param{
+			SubscriptionRepository:   param.Repositories.Subscription,
+			PremiumFeatureRepository: param.Repositories.PremiumFeature,
 			UserRepository:           param.Repositories.User,
+		},
+		),
+		Payment: payment.Init(payment.Param{
+			PaymentRepository:        param.Repositories.Payment,
+			OrderRepository:          param.Repositories.Order,
 			PremiumFeatureRepository: param.Repositories.PremiumFeature,
+			SubscriptionRepository:   param.Repositories.Subscription,
 		},
 		),
-		PremiumFeature: premiumfeature.Init(premiumfeature.Param{
+		Quota: quotaService,
+		Profile: profile.Init(profile.Param{
+			ProfileRepository: param.Repositories.Profile,
+			DeckRepository:    param.Repositories.Deck,
+			PhotoService:      photoService,
+		},
+		),
+		Preference: preference.Init(preference.Param{
+			PreferenceRepository: param.Repositories.Preference,
+			ProfileRepository:    param.Repositories.Profile,
+			DeckRepository:       param.Repositories.Deck,
+		},
+		),
+		Recomendation: recomendation.Init(recomendation.Param{
+			UserRepository:       param.Repositories.User,
+			PreferenceRepository: param.Repositories.Preference,
+			DeckRepository:       param.Repositories.Deck,
+			BoostRepository:      param.Repositories.Boost,
+			PhotoService:         photoService,
+			Rankers:              []recomendation.Ranker{recomendation.DefaultRanker},
+			PassCooldown:         models.GetPassCooldown(),
+		},
+		),
+		Moderation: moderation.Init(moderation.Param{
+			BlockRepository:   param.Repositories.Block,
+			ReportRepository:  param.Repositories.Report,
+			UserRepository:    param.Repositories.User,
+			MatchRepository:   param.Repositories.Match,
+			SessionRepository: param.Repositories.Session,
+		},
+		),
+		Boost: boost.Init(boost.Param{
+			BoostRepository:       param.Repositories.Boost,
+			EntitlementRepository: param.Repositories.Entitlement,
+			UserRepository:        param.Repositories.User,
+		},
+		),
+		Photo: photoService,
+		Verification: verification.Init(verification.Param{
+			VerificationRepository:   param.Repositories.Verification,
+			EntitlementRepository:    param.Repositories.Entitlement,
 			PremiumFeatureRepository: param.Repositories.PremiumFeature,
+			SubscriptionRepository:   param.Repositories.Subscription,
+			PhotoService:             photoService,
 		},
 		),
 	}
@@ -0,0 +1,158 @@
+package subscription
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	premiumfeature "DatingApp/src/repositories/premium_feature"
+	"DatingApp/src/repositories/subscription"
+	"DatingApp/src/repositories/user"
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+type Interface interface {
+	Subscribe(ctx context.Context, input models.Subscribe) error
+	Get(ctx context.Context) (models.UserSubscription, error)
+	ExpireLapsed(ctx context.Context) error
+	RunExpirer(ctx context.Context, interval time.Duration)
+}
+
+type subscriptionService struct {
+	subscriptionRepository   subscription.Interface
+	premiumFeatureRepository premiumfeature.Interface
+	userRepository           user.Interface
+}
+
+type Param struct {
+	SubscriptionRepository   subscription.Interface
+	PremiumFeatureRepository premiumfeature.Interface
+	UserRepository           user.Interface
+}
+
+func Init(param Param) Interface {
+	return &subscriptionService{
+		subscriptionRepository:   param.SubscriptionRepository,
+		premiumFeatureRepository: param.PremiumFeatureRepository,
+		userRepository:           param.UserRepository,
+	}
+}
+
+var Now = time.Now
+
+// Subscribe grants the feature to input.UserId without a payment, it's meant for admins.
+func (s *subscriptionService) Subscribe(ctx context.Context, input models.Subscribe) error {
+	adminId := ctx.Value(models.UserKey).(models.User).Id
+
+	users, _, err := s.userRepository.Get(ctx, filter.Paging[filter.UserFilter]{
+		IsActive: true,
+		Filter: filter.UserFilter{
+			Id: input.UserId,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if len(users) == 0 {
+		return models.ErrUserNotFound
+	}
+	userId := users[0].Id
+
+	features, _, err := s.premiumFeatureRepository.Get(ctx, filter.Paging[filter.PremiumFeatureFilter]{
+		IsActive: true,
+		Filter: filter.PremiumFeatureFilter{
+			Id: input.PremiumFeatureId,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if len(features) == 0 {
+		return errors.New("premium feature doesnt exists")
+	}
+	feature := features[0]
+
+	now := Now()
+	return s.subscriptionRepository.Transaction(ctx, func(ctx context.Context) error {
+		// subscribing again to the same feature replaces it instead of stacking on top of it
+		if err := s.subscriptionRepository.ExpireUserSubscriptions(ctx, int(userId), int(feature.Id), now); err != nil {
+			return err
+		}
+
+		return s.subscriptionRepository.Create(ctx, models.Query[models.SubscriptionInput]{
+			Model: models.SubscriptionInput{
+				UserId:           int(userId),
+				PremiumFeatureId: int(feature.Id),
+				StartedAt:        now,
+				ExpiresAt:        now.AddDate(0, 0, feature.DurationDays),
+				Source:           models.SubscriptionSourceManual,
+				CreatedAt:        now,
+				CreatedBy:        adminId,
+			},
+		})
+	})
+}
+
+func (s *subscriptionService) Get(ctx context.Context) (models.UserSubscription, error) {
+	userId := ctx.Value(models.UserKey).(models.User).Id
+
+	subscriptions, _, err := s.subscriptionRepository.Get(ctx, filter.Paging[filter.SubscriptionFilter]{
+		OrderBy: "id desc",
+		Filter: filter.SubscriptionFilter{
+			UserId: int(userId),
+		},
+	})
+	if err != nil {
+		return models.UserSubscription{}, err
+	}
+
+	result := models.UserSubscription{Current: []models.Subscription{}, History: []models.Subscription{}}
+	for _, sub := range subscriptions {
+		if sub.Status == models.SubscriptionActive && sub.ExpiresAt.After(Now()) {
+			result.Current = append(result.Current, sub)
+			continue
+		}
+		result.History = append(result.History, sub)
+	}
+	return result, nil
+}
+
+// ExpireLapsed marks every subscription which already passed its expiry as expired.
+func (s *subscriptionService) ExpireLapsed(ctx context.Context) error {
+	now := Now()
+	subscriptions, err := s.subscriptionRepository.GetExpired(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range subscriptions {
+		err := s.subscriptionRepository.Update(ctx, models.Query[models.SubscriptionInput]{
+			Model: models.SubscriptionInput{
+				Status:    models.SubscriptionExpired,
+				UpdatedAt: now,
+			},
+		}, int(sub.Id))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunExpirer calls ExpireLapsed every interval until ctx is done, it's meant to run in its own goroutine.
+func (s *subscriptionService) RunExpirer(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.ExpireLapsed(ctx); err != nil {
+				log.Println("expire subscriptions:", err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,349 @@
+package subscription_test
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	mock_premium_feature "DatingApp/src/repositories/mock/premium_feature"
+	mock_subscription "DatingApp/src/repositories/mock/subscription"
+	mock_user "DatingApp/src/repositories/mock/user"
+	"DatingApp/src/services/subscription"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func runTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func Test_subscriptionService_Subscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	subscriptionRepo := mock_subscription.NewMockInterface(ctrl)
+	premiumFeatureRepo := mock_premium_feature.NewMockInterface(ctrl)
+	userRepo := mock_user.NewMockInterface(ctrl)
+	type mockfields struct {
+		subscription   *mock_subscription.MockInterface
+		premiumFeature *mock_premium_feature.MockInterface
+		user           *mock_user.MockInterface
+	}
+	mocks := mockfields{
+		subscription:   subscriptionRepo,
+		premiumFeature: premiumFeatureRepo,
+		user:           userRepo,
+	}
+	params := subscription.Param{
+		SubscriptionRepository:   subscriptionRepo,
+		PremiumFeatureRepository: premiumFeatureRepo,
+		UserRepository:           userRepo,
+	}
+	service := subscription.Init(params)
+	type args struct {
+		Input models.Subscribe
+	}
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.Local)
+	subscription.Now = func() time.Time {
+		return mockTime
+	}
+
+	restoreAll := func() {
+		subscription.Now = time.Now
+	}
+	defer restoreAll()
+
+	featurePaging := filter.Paging[filter.PremiumFeatureFilter]{
+		IsActive: true,
+		Filter: filter.PremiumFeatureFilter{
+			Id: 2,
+		},
+	}
+	userPaging := filter.Paging[filter.UserFilter]{
+		IsActive: true,
+		Filter: filter.UserFilter{
+			Id: 3,
+		},
+	}
+	mockUserExists := func(mock mockfields) {
+		mock.user.EXPECT().Get(context, userPaging).Return([]models.User{{Id: 3}}, 1, nil)
+	}
+	feature := models.PremiumFeature{Id: 2, DurationDays: 30}
+	subscriptionInput := models.Query[models.SubscriptionInput]{
+		Model: models.SubscriptionInput{
+			UserId:           3,
+			PremiumFeatureId: 2,
+			StartedAt:        mockTime,
+			ExpiresAt:        mockTime.AddDate(0, 0, 30),
+			Source:           models.SubscriptionSourceManual,
+			CreatedAt:        mockTime,
+			CreatedBy:        1,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		mockfunc func(a args, mock mockfields)
+		wantErr  bool
+	}{
+		{
+			name: "get user error",
+			args: args{
+				Input: models.Subscribe{UserId: 3, PremiumFeatureId: 2},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.user.EXPECT().Get(context, userPaging).Return([]models.User{}, 0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "user doesnt exists",
+			args: args{
+				Input: models.Subscribe{UserId: 3, PremiumFeatureId: 2},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.user.EXPECT().Get(context, userPaging).Return([]models.User{}, 0, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "get premium feature error",
+			args: args{
+				Input: models.Subscribe{UserId: 3, PremiumFeatureId: 2},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mockUserExists(mock)
+				mock.premiumFeature.EXPECT().Get(context, featurePaging).Return([]models.PremiumFeature{}, 0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "premium feature doesnt exists",
+			args: args{
+				Input: models.Subscribe{UserId: 3, PremiumFeatureId: 2},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mockUserExists(mock)
+				mock.premiumFeature.EXPECT().Get(context, featurePaging).Return([]models.PremiumFeature{}, 0, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "expire current subscription error",
+			args: args{
+				Input: models.Subscribe{UserId: 3, PremiumFeatureId: 2},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mockUserExists(mock)
+				mock.premiumFeature.EXPECT().Get(context, featurePaging).Return([]models.PremiumFeature{feature}, 1, nil)
+				mock.subscription.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.subscription.EXPECT().ExpireUserSubscriptions(context, 3, 2, mockTime).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "create subscription error",
+			args: args{
+				Input: models.Subscribe{UserId: 3, PremiumFeatureId: 2},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mockUserExists(mock)
+				mock.premiumFeature.EXPECT().Get(context, featurePaging).Return([]models.PremiumFeature{feature}, 1, nil)
+				mock.subscription.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.subscription.EXPECT().ExpireUserSubscriptions(context, 3, 2, mockTime).Return(nil)
+				mock.subscription.EXPECT().Create(context, subscriptionInput).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "subscribe success",
+			args: args{
+				Input: models.Subscribe{UserId: 3, PremiumFeatureId: 2},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mockUserExists(mock)
+				mock.premiumFeature.EXPECT().Get(context, featurePaging).Return([]models.PremiumFeature{feature}, 1, nil)
+				mock.subscription.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.subscription.EXPECT().ExpireUserSubscriptions(context, 3, 2, mockTime).Return(nil)
+				mock.subscription.EXPECT().Create(context, subscriptionInput).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(tt.args, mocks)
+
+			err := service.Subscribe(context, tt.args.Input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("subscription.Subscribe() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_subscriptionService_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	subscriptionRepo := mock_subscription.NewMockInterface(ctrl)
+	type mockfields struct {
+		subscription *mock_subscription.MockInterface
+	}
+	mocks := mockfields{
+		subscription: subscriptionRepo,
+	}
+	params := subscription.Param{
+		SubscriptionRepository: subscriptionRepo,
+	}
+	service := subscription.Init(params)
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.Local)
+	subscription.Now = func() time.Time {
+		return mockTime
+	}
+
+	restoreAll := func() {
+		subscription.Now = time.Now
+	}
+	defer restoreAll()
+
+	paging := filter.Paging[filter.SubscriptionFilter]{
+		OrderBy: "id desc",
+		Filter: filter.SubscriptionFilter{
+			UserId: 1,
+		},
+	}
+	current := models.Subscription{Id: 4, UserId: 1, Status: models.SubscriptionActive, ExpiresAt: mockTime.Add(time.Hour)}
+	otherCurrent := models.Subscription{Id: 3, UserId: 1, PremiumFeatureId: 2, Status: models.SubscriptionActive, ExpiresAt: mockTime.Add(time.Hour)}
+	lapsed := models.Subscription{Id: 2, UserId: 1, Status: models.SubscriptionActive, ExpiresAt: mockTime.Add(-time.Hour)}
+	expired := models.Subscription{Id: 1, UserId: 1, Status: models.SubscriptionExpired, ExpiresAt: mockTime.Add(-time.Hour * 24)}
+
+	tests := []struct {
+		name     string
+		mockfunc func(mock mockfields)
+		want     models.UserSubscription
+		wantErr  bool
+	}{
+		{
+			name: "get subscription error",
+			mockfunc: func(mock mockfields) {
+				mock.subscription.EXPECT().Get(context, paging).Return([]models.Subscription{}, 0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "no current subscription",
+			mockfunc: func(mock mockfields) {
+				mock.subscription.EXPECT().Get(context, paging).Return([]models.Subscription{lapsed, expired}, 2, nil)
+			},
+			want: models.UserSubscription{
+				Current: []models.Subscription{},
+				History: []models.Subscription{lapsed, expired},
+			},
+		},
+		{
+			name: "get subscription success",
+			mockfunc: func(mock mockfields) {
+				mock.subscription.EXPECT().Get(context, paging).Return([]models.Subscription{current, otherCurrent, expired}, 3, nil)
+			},
+			want: models.UserSubscription{
+				Current: []models.Subscription{current, otherCurrent},
+				History: []models.Subscription{expired},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(mocks)
+
+			result, err := service.Get(context)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("subscription.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func Test_subscriptionService_ExpireLapsed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.Background()
+
+	subscriptionRepo := mock_subscription.NewMockInterface(ctrl)
+	type mockfields struct {
+		subscription *mock_subscription.MockInterface
+	}
+	mocks := mockfields{
+		subscription: subscriptionRepo,
+	}
+	params := subscription.Param{
+		SubscriptionRepository: subscriptionRepo,
+	}
+	service := subscription.Init(params)
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.Local)
+	subscription.Now = func() time.Time {
+		return mockTime
+	}
+
+	restoreAll := func() {
+		subscription.Now = time.Now
+	}
+	defer restoreAll()
+
+	expireInput := models.Query[models.SubscriptionInput]{
+		Model: models.SubscriptionInput{
+			Status:    models.SubscriptionExpired,
+			UpdatedAt: mockTime,
+		},
+	}
+	lapsed := []models.Subscription{{Id: 3, UserId: 1, PremiumFeatureId: 2}, {Id: 4, UserId: 1, PremiumFeatureId: 1}}
+
+	tests := []struct {
+		name     string
+		mockfunc func(mock mockfields)
+		wantErr  bool
+	}{
+		{
+			name: "get expired error",
+			mockfunc: func(mock mockfields) {
+				mock.subscription.EXPECT().GetExpired(context, mockTime).Return([]models.Subscription{}, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "expire subscription error",
+			mockfunc: func(mock mockfields) {
+				mock.subscription.EXPECT().GetExpired(context, mockTime).Return(lapsed, nil)
+				mock.subscription.EXPECT().Update(context, expireInput, 3).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "expire lapsed success",
+			mockfunc: func(mock mockfields) {
+				mock.subscription.EXPECT().GetExpired(context, mockTime).Return(lapsed, nil)
+				mock.subscription.EXPECT().Update(context, expireInput, 3).Return(nil)
+				mock.subscription.EXPECT().Update(context, expireInput, 4).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(mocks)
+
+			err := service.ExpireLapsed(context)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("subscription.ExpireLapsed() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
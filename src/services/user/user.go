@@ -3,40 +3,58 @@ package user
 import (
 	"DatingApp/src/filter"
 	"DatingApp/src/models"
+	"DatingApp/src/repositories/deck"
+	"DatingApp/src/repositories/entitlement"
 	user "DatingApp/src/repositories/user"
+	"DatingApp/src/services/photo"
 	"context"
+	"slices"
 	"time"
 )
 
 type Interface interface {
 	Delete(ctx context.Context, id int) error
 	Get(ctx context.Context, paging filter.Paging[filter.UserFilter]) ([]models.User, int, error)
-	UpdatePremiumFeatureId(ctx context.Context, input models.Subscribe) error
-	GetRecomendedUser(ctx context.Context) (models.RecomendationUser, error)
+	GetWithFeatures(ctx context.Context, paging filter.Paging[filter.UserFilter]) ([]models.UserDetail, int, error)
+	UpdateLocation(ctx context.Context, input models.LocationRequest) error
 }
 
 type userService struct {
-	userRepository user.Interface
+	userRepository        user.Interface
+	entitlementRepository entitlement.Interface
+	deckRepository        deck.Interface
+	photoService          photo.Interface
 }
 
 type Param struct {
-	UserRepository user.Interface
+	UserRepository        user.Interface
+	EntitlementRepository entitlement.Interface
+	DeckRepository        deck.Interface
+	PhotoService          photo.Interface
 }
 
 func Init(param Param) Interface {
 	return &userService{
-		userRepository: param.UserRepository,
+		userRepository:        param.UserRepository,
+		entitlementRepository: param.EntitlementRepository,
+		deckRepository:        param.DeckRepository,
+		photoService:          param.PhotoService,
 	}
 }
 
 var Now = time.Now
 
 func (s *userService) Delete(ctx context.Context, id int) error {
+	user := ctx.Value(models.UserKey).(models.User)
+	if int(user.Id) != id && !user.IsAdmin() {
+		return models.ErrForbidden
+	}
+
 	input := models.Query[models.UserInput]{
 		Model: models.UserInput{
 			Status:    -1,
 			DeletedAt: Now(),
-			DeletedBy: ctx.Value(models.UserKey).(models.User).Id,
+			DeletedBy: user.Id,
 		},
 	}
 
@@ -48,18 +66,53 @@ func (s *userService) Get(ctx context.Context, paging filter.Paging[filter.UserF
 	return s.userRepository.Get(ctx, paging)
 }
 
-func (s *userService) UpdatePremiumFeatureId(ctx context.Context, input models.Subscribe) error {
-	userId := ctx.Value(string(models.UserKey)).(models.User).Id
-	model := models.Query[models.UserInput]{
-		Model: models.UserInput{
-			PremiumFeatureId: input.PremiumFeatureId,
-			UpdatedAt:        Now(),
-			UpdatedBy:        userId,
-		},
+// GetWithFeatures is like Get but also lists the active premium feature flags of every user,
+// tells who is verified and turns their image into an url.
+func (s *userService) GetWithFeatures(ctx context.Context, paging filter.Paging[filter.UserFilter]) ([]models.UserDetail, int, error) {
+	users, count, err := s.Get(ctx, paging)
+	if err != nil {
+		return []models.UserDetail{}, count, err
+	}
+
+	userIds := []int{}
+	for _, user := range users {
+		userIds = append(userIds, int(user.Id))
+	}
+	flags, err := s.entitlementRepository.GetFlags(ctx, userIds, Now())
+	if err != nil {
+		return []models.UserDetail{}, count, err
 	}
-	return s.userRepository.Update(ctx, model, int(userId))
+
+	result := []models.UserDetail{}
+	for _, user := range users {
+		features := flags[int(user.Id)]
+		if features == nil {
+			features = []string{}
+		}
+		if user.Image.Valid {
+			user.Image.Data = s.photoService.Url(user.Image.Data, models.PhotoSizeMedium)
+		}
+		result = append(result, models.UserDetail{
+			User:     user,
+			Features: features,
+			Verified: slices.Contains(features, models.VerifiedFlag),
+		})
+	}
+	return result, count, nil
 }
 
-func (s *userService) GetRecomendedUser(ctx context.Context) (models.RecomendationUser, error) {
-	return s.userRepository.GetRecomendedUser(ctx, int(ctx.Value(string(models.UserKey)).(models.User).Id))
+// UpdateLocation moves the user, the cached deck goes too since its distances are from the old location.
+func (s *userService) UpdateLocation(ctx context.Context, input models.LocationRequest) error {
+	userId := ctx.Value(models.UserKey).(models.User).Id
+
+	return s.userRepository.Transaction(ctx, func(ctx context.Context) error {
+		err := s.userRepository.UpdateLocation(ctx, int(userId), models.Coordinate{
+			Latitude:  *input.Latitude,
+			Longitude: *input.Longitude,
+		}, Now())
+		if err != nil {
+			return err
+		}
+		return s.deckRepository.Clear(ctx, int(userId))
+	})
 }
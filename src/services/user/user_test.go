@@ -2,8 +2,13 @@ package user_test
 
 import (
 	"DatingApp/src/filter"
+	"DatingApp/src/formatter"
 	"DatingApp/src/models"
+	mock_deck "DatingApp/src/repositories/mock/deck"
+	mock_entitlement "DatingApp/src/repositories/mock/entitlement"
+	mock_storage "DatingApp/src/repositories/mock/storage"
 	mock_user "DatingApp/src/repositories/mock/user"
+	"DatingApp/src/services/photo"
 	user "DatingApp/src/services/user"
 	"context"
 	"testing"
@@ -13,9 +18,14 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func runTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 func Test_userService_Delete(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
+	adminContext := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1, Role: models.RoleAdmin})
 	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
 
 	userRepo := mock_user.NewMockInterface(ctrl)
@@ -30,7 +40,8 @@ func Test_userService_Delete(t *testing.T) {
 	}
 	service := user.Init(params)
 	type args struct {
-		Id int
+		Id    int
+		Admin bool
 	}
 
 	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.Local)
@@ -80,12 +91,41 @@ func Test_userService_Delete(t *testing.T) {
 				}, 1).Return(nil)
 			},
 		},
+		{
+			name: "delete other user forbidden",
+			args: args{
+				Id: 2,
+			},
+			mockfunc: func(a args, mock mockfields) {},
+			wantErr:  true,
+		},
+		{
+			name: "admin delete other user success",
+			args: args{
+				Id:    2,
+				Admin: true,
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.user.EXPECT().Update(adminContext, models.Query[models.UserInput]{
+					Model: models.UserInput{
+						DeletedBy: 1,
+						DeletedAt: mockTime,
+						Status:    -1,
+					},
+				}, 2).Return(nil)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockfunc(tt.args, mocks)
 
-			err := service.Delete(context, tt.args.Id)
+			ctx := context
+			if tt.args.Admin {
+				ctx = adminContext
+			}
+
+			err := service.Delete(ctx, tt.args.Id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("user.Delete() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -172,105 +212,123 @@ func Test_userService_Get(t *testing.T) {
 	}
 }
 
-func Test_userService_UpdatePremiumFeatureId(t *testing.T) {
+func Test_userService_GetWithFeatures(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+	context := context.Background()
 
 	userRepo := mock_user.NewMockInterface(ctrl)
+	entitlementRepo := mock_entitlement.NewMockInterface(ctrl)
 	type mockfields struct {
-		user *mock_user.MockInterface
+		user        *mock_user.MockInterface
+		entitlement *mock_entitlement.MockInterface
 	}
 	mocks := mockfields{
-		user: userRepo,
+		user:        userRepo,
+		entitlement: entitlementRepo,
 	}
+	storageRepo := mock_storage.NewMockInterface(ctrl)
 	params := user.Param{
-		UserRepository: userRepo,
+		UserRepository:        userRepo,
+		EntitlementRepository: entitlementRepo,
+		PhotoService:          photo.Init(photo.Param{StorageRepository: storageRepo}),
 	}
 	service := user.Init(params)
-	type args struct {
-		Input models.Subscribe
-	}
 
-	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.Local)
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
 	user.Now = func() time.Time {
 		return mockTime
 	}
+	photo.Now = func() time.Time {
+		return mockTime
+	}
 
 	restoreAll := func() {
 		user.Now = time.Now
+		photo.Now = time.Now
 	}
 	defer restoreAll()
 
+	legacy := formatter.NullableDataType[string]{Valid: true, Data: "https://cdn.example.com/a.jpg"}
+
 	tests := []struct {
-		name     string
-		args     args
-		mockfunc func(a args, mock mockfields)
-		wantErr  bool
+		name      string
+		mockfunc  func(mock mockfields)
+		want      []models.UserDetail
+		wantCount int
+		wantErr   bool
 	}{
 		{
-			name: "update user error",
-			args: args{
-				Input: models.Subscribe{},
-			},
-			mockfunc: func(a args, mock mockfields) {
-				mock.user.EXPECT().Update(context, models.Query[models.UserInput]{
-					Model: models.UserInput{
-						UpdatedBy: context.Value(models.UserKey).(models.User).Id,
-						UpdatedAt: mockTime,
-					},
-				}, 1).Return(assert.AnError)
+			name: "get user error",
+			mockfunc: func(mock mockfields) {
+				mock.user.EXPECT().Get(context, filter.Paging[filter.UserFilter]{IsActive: true}).Return([]models.User{}, 0, assert.AnError)
 			},
+			want:    []models.UserDetail{},
 			wantErr: true,
 		},
 		{
-			name: "update user success",
-			args: args{
-				Input: models.Subscribe{
-					PremiumFeatureId: 1,
-				},
+			name: "get flags error",
+			mockfunc: func(mock mockfields) {
+				mock.user.EXPECT().Get(context, filter.Paging[filter.UserFilter]{IsActive: true}).Return([]models.User{{Id: 1}}, 1, nil)
+				mock.entitlement.EXPECT().GetFlags(context, []int{1}, mockTime).Return(map[int][]string{}, assert.AnError)
 			},
-			mockfunc: func(a args, mock mockfields) {
-				mock.user.EXPECT().Update(context, models.Query[models.UserInput]{
-					Model: models.UserInput{
-						PremiumFeatureId: 1,
-						UpdatedBy:        context.Value(models.UserKey).(models.User).Id,
-						UpdatedAt:        mockTime,
-					},
-				}, 1).Return(nil)
+			want:      []models.UserDetail{},
+			wantCount: 1,
+			wantErr:   true,
+		},
+		{
+			name: "get user with features success",
+			mockfunc: func(mock mockfields) {
+				mock.user.EXPECT().Get(context, filter.Paging[filter.UserFilter]{IsActive: true}).Return([]models.User{
+					{Id: 1, Image: formatter.NullableDataType[string]{Valid: true, Data: "storage://photos/1/abc"}},
+					{Id: 2, Image: legacy},
+					{Id: 3},
+				}, 3, nil)
+				mock.entitlement.EXPECT().GetFlags(context, []int{1, 2, 3}, mockTime).Return(map[int][]string{1: {"no-swipe-quota-limit", "verified"}}, nil)
+				storageRepo.EXPECT().SignedUrl("photos/1/abc/medium.jpg", mockTime.Add(2*models.PhotoUrlTTL)).Return("https://app.example.com/photos/1/abc/medium.jpg")
+			},
+			want: []models.UserDetail{
+				{
+					User:     models.User{Id: 1, Image: formatter.NullableDataType[string]{Valid: true, Data: "https://app.example.com/photos/1/abc/medium.jpg"}},
+					Features: []string{"no-swipe-quota-limit", "verified"},
+					Verified: true,
+				},
+				{User: models.User{Id: 2, Image: legacy}, Features: []string{}},
+				{User: models.User{Id: 3}, Features: []string{}},
 			},
+			wantCount: 3,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.mockfunc(tt.args, mocks)
+			tt.mockfunc(mocks)
 
-			err := service.UpdatePremiumFeatureId(context, tt.args.Input)
+			users, count, err := service.GetWithFeatures(context, filter.Paging[filter.UserFilter]{})
 			if (err != nil) != tt.wantErr {
-				t.Errorf("user.UpdatePremiumFeatureId() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("user.GetWithFeatures() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			assert.Equal(t, tt.want, users)
+			assert.Equal(t, tt.wantCount, count)
 		})
 	}
 }
 
-func Test_userService_GetRecomendedUser(t *testing.T) {
+func Test_userService_UpdateLocation(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
 
 	userRepo := mock_user.NewMockInterface(ctrl)
-	type mockfields struct {
-		user *mock_user.MockInterface
-	}
-	mocks := mockfields{
-		user: userRepo,
-	}
-	params := user.Param{
+	deckRepo := mock_deck.NewMockInterface(ctrl)
+	service := user.Init(user.Param{
 		UserRepository: userRepo,
-	}
-	service := user.Init(params)
-	type args struct {
+		DeckRepository: deckRepo,
+	})
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	user.Now = func() time.Time {
+		return mockTime
 	}
 
 	restoreAll := func() {
@@ -278,42 +336,47 @@ func Test_userService_GetRecomendedUser(t *testing.T) {
 	}
 	defer restoreAll()
 
+	latitude, longitude := 0.0, 106.8
+
 	tests := []struct {
 		name     string
-		args     args
-		mockfunc func(a args, mock mockfields)
-		want     models.RecomendationUser
+		mockfunc func()
 		wantErr  bool
 	}{
 		{
-			name: "get user recomendation error",
-			args: args{},
-			mockfunc: func(a args, mock mockfields) {
-				mock.user.EXPECT().GetRecomendedUser(context, int(context.Value(models.UserKey).(models.User).Id)).Return(models.RecomendationUser{}, assert.AnError)
+			name: "update location error",
+			mockfunc: func() {
+				userRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				userRepo.EXPECT().UpdateLocation(context, 1, models.Coordinate{Latitude: 0, Longitude: 106.8}, mockTime).Return(assert.AnError)
 			},
-			want:    models.RecomendationUser{},
 			wantErr: true,
 		},
 		{
-			name: "get user success",
-			args: args{},
-			mockfunc: func(a args, mock mockfields) {
-				mock.user.EXPECT().GetRecomendedUser(context, int(context.Value(models.UserKey).(models.User).Id)).Return(models.RecomendationUser{}, nil)
+			name: "clear deck error",
+			mockfunc: func() {
+				userRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				userRepo.EXPECT().UpdateLocation(context, 1, models.Coordinate{Latitude: 0, Longitude: 106.8}, mockTime).Return(nil)
+				deckRepo.EXPECT().Clear(context, 1).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "update location success",
+			mockfunc: func() {
+				userRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				userRepo.EXPECT().UpdateLocation(context, 1, models.Coordinate{Latitude: 0, Longitude: 106.8}, mockTime).Return(nil)
+				deckRepo.EXPECT().Clear(context, 1).Return(nil)
 			},
-			want:    models.RecomendationUser{},
-			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.mockfunc(tt.args, mocks)
+			tt.mockfunc()
 
-			users, err := service.GetRecomendedUser(context)
+			err := service.UpdateLocation(context, models.LocationRequest{Latitude: &latitude, Longitude: &longitude})
 			if (err != nil) != tt.wantErr {
-				t.Errorf("user.GetRecomendedUser() error = %v, wantErr %v", err, tt.wantErr)
-				return
+				t.Errorf("user.UpdateLocation() error = %v, wantErr %v", err, tt.wantErr)
 			}
-			assert.Equal(t, tt.want, users)
 		})
 	}
 }
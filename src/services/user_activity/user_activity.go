@@ -3,48 +3,75 @@ package useractivity
 import (
 	"DatingApp/src/filter"
 	"DatingApp/src/models"
-	premiumfeature "DatingApp/src/repositories/premium_feature"
+	"DatingApp/src/repositories/block"
+	"DatingApp/src/repositories/broker"
+	"DatingApp/src/repositories/entitlement"
+	"DatingApp/src/repositories/match"
 	"DatingApp/src/repositories/user"
 	useractivity "DatingApp/src/repositories/user_activity"
+	"DatingApp/src/services/photo"
+	"DatingApp/src/services/quota"
 	"context"
 	"errors"
 	"time"
 )
 
 const (
-	noLimitSwipeQuotaFlag = "no-swipe-quota-limit"
+	rewindFlag        = "rewind"
+	likesReceivedFlag = "likes-received"
 )
 
 type Interface interface {
 	Delete(ctx context.Context, id int) error
 	Update(ctx context.Context, input models.Query[models.UserActivityInput], id int) error
 	Create(ctx context.Context, input models.Query[models.UserActivityInput]) error
+	Rewind(ctx context.Context) (models.UserActivity, error)
 	Get(ctx context.Context, paging filter.Paging[filter.UserActivityFilter]) ([]models.UserActivity, int, error)
+	GetLikesReceived(ctx context.Context, paging filter.Paging[filter.UserActivityFilter]) ([]models.LikeReceivedUser, int, error)
 }
 
 type userActivityService struct {
-	userActivityRepository   useractivity.Interface
-	userRepository           user.Interface
-	premiumFeatureRepository premiumfeature.Interface
+	userActivityRepository useractivity.Interface
+	userRepository         user.Interface
+	entitlementRepository  entitlement.Interface
+	matchRepository        match.Interface
+	brokerRepository       broker.Interface
+	blockRepository        block.Interface
+	quotaService           quota.Interface
+	photoService           photo.Interface
 }
 
 type Param struct {
-	UserActivityRepository   useractivity.Interface
-	UserRepository           user.Interface
-	PremiumFeatureRepository premiumfeature.Interface
+	UserActivityRepository useractivity.Interface
+	UserRepository         user.Interface
+	EntitlementRepository  entitlement.Interface
+	MatchRepository        match.Interface
+	BrokerRepository       broker.Interface
+	BlockRepository        block.Interface
+	QuotaService           quota.Interface
+	PhotoService           photo.Interface
 }
 
 func Init(param Param) Interface {
 	return &userActivityService{
-		userActivityRepository:   param.UserActivityRepository,
-		userRepository:           param.UserRepository,
-		premiumFeatureRepository: param.PremiumFeatureRepository,
+		userActivityRepository: param.UserActivityRepository,
+		userRepository:         param.UserRepository,
+		entitlementRepository:  param.EntitlementRepository,
+		matchRepository:        param.MatchRepository,
+		brokerRepository:       param.BrokerRepository,
+		blockRepository:        param.BlockRepository,
+		quotaService:           param.QuotaService,
+		photoService:           param.PhotoService,
 	}
 }
 
 var Now = time.Now
 
 func (s *userActivityService) Delete(ctx context.Context, id int) error {
+	if err := s.checkOwnership(ctx, id); err != nil {
+		return err
+	}
+
 	input := models.Query[models.UserActivityInput]{
 		Model: models.UserActivityInput{
 			Status:    -1,
@@ -57,12 +84,42 @@ func (s *userActivityService) Delete(ctx context.Context, id int) error {
 }
 
 func (s *userActivityService) Update(ctx context.Context, input models.Query[models.UserActivityInput], id int) error {
+	user := ctx.Value(models.UserKey).(models.User)
+	if err := s.checkOwnership(ctx, id); err != nil {
+		return err
+	}
+	if !user.IsAdmin() && input.Model.UserId != 0 && input.Model.UserId != int(user.Id) {
+		return models.ErrForbidden
+	}
+
 	input.Model.UpdatedAt = Now()
-	input.Model.UpdatedBy = ctx.Value(string(models.UserKey)).(models.User).Id
+	input.Model.UpdatedBy = user.Id
 
 	return s.userActivityRepository.Update(ctx, input, id)
 }
 
+// checkOwnership only lets admins touch other users' activities.
+func (s *userActivityService) checkOwnership(ctx context.Context, id int) error {
+	user := ctx.Value(models.UserKey).(models.User)
+
+	activities, _, err := s.userActivityRepository.Get(ctx, filter.Paging[filter.UserActivityFilter]{
+		IsActive: true,
+		Filter: filter.UserActivityFilter{
+			Id: id,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if len(activities) == 0 {
+		return errors.New("user activity doesnt exists")
+	}
+	if activities[0].UserId != int(user.Id) && !user.IsAdmin() {
+		return models.ErrForbidden
+	}
+	return nil
+}
+
 func (s *userActivityService) Create(ctx context.Context, input models.Query[models.UserActivityInput]) error {
 	userId := ctx.Value(models.UserKey).(models.User).Id
 	users, _, err := s.userRepository.Get(ctx, filter.Paging[filter.UserFilter]{
@@ -78,36 +135,287 @@ func (s *userActivityService) Create(ctx context.Context, input models.Query[mod
 	}
 	user := users[0]
 
-	features, _, err := s.premiumFeatureRepository.Get(ctx, filter.Paging[filter.PremiumFeatureFilter]{
-		Filter: filter.PremiumFeatureFilter{
-			Flag: noLimitSwipeQuotaFlag,
-		},
+	targetId := input.Model.LikedUserId
+	if targetId == 0 {
+		targetId = input.Model.PassedUserId
+	}
+	if targetId == int(userId) {
+		return models.ErrSelfTarget
+	}
+	if targetId != 0 {
+		targets, _, err := s.userRepository.Get(ctx, filter.Paging[filter.UserFilter]{
+			IsActive: true,
+			Filter: filter.UserFilter{
+				Id: targetId,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if len(targets) == 0 {
+			return models.ErrUserNotFound
+		}
+
+		isBlocked, err := s.blockRepository.IsBlocked(ctx, int(userId), targetId)
+		if err != nil {
+			return err
+		}
+		if isBlocked {
+			return models.ErrBlocked
+		}
+	}
+
+	activity := input.Model.Activity
+	if activity == "" {
+		activity = models.ActivityPass
+		if input.Model.LikedUserId != 0 {
+			activity = models.ActivityLike
+		}
+	}
+	if activity == models.ActivitySuperLike && input.Model.LikedUserId == 0 {
+		return errors.New("super like needs a liked user")
+	}
+	input.Model.Activity = activity
+
+	input.Model.CreatedAt = Now()
+	input.Model.CreatedBy = userId
+
+	isMatched := false
+	err = s.userActivityRepository.Transaction(ctx, func(ctx context.Context) error {
+		// the user stays locked until the swipe is in, so swipes at once can't all pass the same quota
+		if err := s.userRepository.Lock(ctx, int(userId)); err != nil {
+			return err
+		}
+		if err := s.quotaService.Check(ctx, user, activity); err != nil {
+			return err
+		}
+		if err := s.userActivityRepository.Create(ctx, input); err != nil {
+			return err
+		}
+		if input.Model.LikedUserId == 0 {
+			return nil
+		}
+		isMatched, err = s.createMatch(ctx, int(userId), input.Model.LikedUserId)
+		return err
 	})
 	if err != nil {
 		return err
 	}
-	if len(features) == 0 {
-		return errors.New("feature doesnt exists")
+
+	if input.Model.LikedUserId != 0 {
+		s.notifyLike(ctx, user, input.Model.LikedUserId, activity, isMatched)
+	}
+	return nil
+}
+
+// Rewind undoes the last like or pass of the user along with the match the like created,
+// it needs the rewind premium feature and has its own quota.
+func (s *userActivityService) Rewind(ctx context.Context) (models.UserActivity, error) {
+	user := ctx.Value(models.UserKey).(models.User)
+
+	canRewind, err := s.entitlementRepository.HasFeature(ctx, int(user.Id), rewindFlag, Now())
+	if err != nil {
+		return models.UserActivity{}, err
+	}
+	if !canRewind {
+		return models.UserActivity{}, models.ErrPremiumRequired
 	}
-	feeature := features[0]
 
-	if !user.PremiumFeatureId.Valid || user.PremiumFeatureId.Data != int(feeature.Id) {
-		totalActivity, err := s.userActivityRepository.GetTotalTodayActivity(ctx, int(userId))
+	var activity models.UserActivity
+	err = s.userActivityRepository.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.Lock(ctx, int(user.Id)); err != nil {
+			return err
+		}
+		if err := s.quotaService.Check(ctx, user, models.ActivityRewind); err != nil {
+			return err
+		}
+
+		activities, _, err := s.userActivityRepository.Get(ctx, filter.Paging[filter.UserActivityFilter]{
+			Page:     1,
+			Take:     1,
+			OrderBy:  "id desc",
+			IsActive: true,
+			Filter: filter.UserActivityFilter{
+				UserId: int(user.Id),
+			},
+		})
 		if err != nil {
 			return err
 		}
-		if totalActivity >= 10 {
-			return errors.New("reached total of max activity today")
+		if len(activities) == 0 {
+			return errors.New("no activity to rewind")
 		}
+		activity = activities[0]
+
+		now := Now()
+		err = s.userActivityRepository.Update(ctx, models.Query[models.UserActivityInput]{
+			Model: models.UserActivityInput{
+				RewoundAt: now,
+				Status:    -1,
+				DeletedAt: now,
+				DeletedBy: user.Id,
+			},
+		}, int(activity.Id))
+		if err != nil {
+			return err
+		}
+		if !activity.LikedUserId.Valid {
+			return nil
+		}
+		return s.deleteMatch(ctx, int(user.Id), activity.LikedUserId.Data)
+	})
+	if err != nil {
+		return models.UserActivity{}, err
 	}
+	return activity, nil
+}
 
-	input.Model.CreatedAt = Now()
-	input.Model.CreatedBy = userId
+// deleteMatch unmatches both users only when the match was created by the like of the user.
+func (s *userActivityService) deleteMatch(ctx context.Context, userId, likedUserId int) error {
+	firstId, secondId := userId, likedUserId
+	if firstId > secondId {
+		firstId, secondId = secondId, firstId
+	}
 
-	return s.userActivityRepository.Create(ctx, input)
+	matches, _, err := s.matchRepository.Get(ctx, filter.Paging[filter.MatchFilter]{
+		IsActive: true,
+		Filter: filter.MatchFilter{
+			UserId:        firstId,
+			MatchedUserId: secondId,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if len(matches) == 0 || matches[0].CreatedBy.Data != int64(userId) {
+		return nil
+	}
+
+	return s.matchRepository.Update(ctx, models.Query[models.MatchInput]{
+		Model: models.MatchInput{
+			Status:    -1,
+			DeletedAt: Now(),
+			DeletedBy: int64(userId),
+		},
+	}, int(matches[0].Id))
+}
+
+// createMatch creates a match between both users when the liked user already likes the user back,
+// it returns true only when a new match is created.
+func (s *userActivityService) createMatch(ctx context.Context, userId, likedUserId int) (bool, error) {
+	isLikedBack, err := s.userActivityRepository.HasLiked(ctx, likedUserId, userId)
+	if err != nil {
+		return false, err
+	}
+	if !isLikedBack {
+		return false, nil
+	}
+
+	// lower user id is always stored first so a pair only has one lookup
+	firstId, secondId := userId, likedUserId
+	if firstId > secondId {
+		firstId, secondId = secondId, firstId
+	}
+
+	return s.matchRepository.CreateMatch(ctx, models.MatchInput{
+		UserId:        firstId,
+		MatchedUserId: secondId,
+		CreatedAt:     Now(),
+		CreatedBy:     int64(userId),
+	})
+}
+
+// notifyLike tells both users about a new match, or tells the liked user who super liked them,
+// or who liked them when they are premium. Notification is best effort so it never fails the like itself.
+func (s *userActivityService) notifyLike(ctx context.Context, user models.User, likedUserId int, activity string, isMatched bool) {
+	likedUsers, _, err := s.userRepository.Get(ctx, filter.Paging[filter.UserFilter]{
+		Filter: filter.UserFilter{
+			Id: likedUserId,
+		},
+	})
+	if err != nil || len(likedUsers) == 0 {
+		return
+	}
+	likedUser := likedUsers[0]
+
+	if isMatched {
+		s.brokerRepository.Publish(ctx, models.Event{
+			UserId:    int(user.Id),
+			Type:      models.EventNewMatch,
+			Data:      s.toEventUser(likedUser),
+			CreatedAt: Now(),
+		})
+		s.brokerRepository.Publish(ctx, models.Event{
+			UserId:    likedUserId,
+			Type:      models.EventNewMatch,
+			Data:      s.toEventUser(user),
+			CreatedAt: Now(),
+		})
+		return
+	}
+
+	if activity == models.ActivitySuperLike {
+		s.brokerRepository.Publish(ctx, models.Event{
+			UserId:    likedUserId,
+			Type:      models.EventSuperLike,
+			Data:      s.toEventUser(user),
+			CreatedAt: Now(),
+		})
+		return
+	}
+
+	seesLikes, err := s.entitlementRepository.HasFeature(ctx, likedUserId, likesReceivedFlag, Now())
+	if err != nil {
+		return
+	}
+	if seesLikes {
+		s.brokerRepository.Publish(ctx, models.Event{
+			UserId:    likedUserId,
+			Type:      models.EventNewLike,
+			Data:      s.toEventUser(user),
+			CreatedAt: Now(),
+		})
+	}
+}
+
+func (s *userActivityService) toEventUser(user models.User) models.EventUser {
+	eventUser := models.EventUser{
+		Id:       user.Id,
+		UserName: user.UserName,
+	}
+	if user.Image.Valid {
+		eventUser.Image = s.photoService.ImageUrl(&user.Image.Data)
+	}
+	return eventUser
 }
 
 func (s *userActivityService) Get(ctx context.Context, paging filter.Paging[filter.UserActivityFilter]) ([]models.UserActivity, int, error) {
 	paging.IsActive = true
 	return s.userActivityRepository.Get(ctx, paging)
 }
+
+// GetLikesReceived lists who liked the user and wasn't swiped back yet,
+// only users with the likes-received feature get to see who they are, the others get them blurred.
+func (s *userActivityService) GetLikesReceived(ctx context.Context, paging filter.Paging[filter.UserActivityFilter]) ([]models.LikeReceivedUser, int, error) {
+	var (
+		result = []models.LikeReceivedUser{}
+		userId = int(ctx.Value(models.UserKey).(models.User).Id)
+	)
+
+	seesLikes, err := s.entitlementRepository.HasFeature(ctx, userId, likesReceivedFlag, Now())
+	if err != nil {
+		return result, 0, err
+	}
+	blurred := !seesLikes
+
+	likes, count, err := s.userActivityRepository.GetLikesReceived(ctx, userId, paging)
+	if err != nil {
+		return result, count, err
+	}
+	for _, like := range likes {
+		liker := like.LikeReceivedUser(blurred)
+		liker.Image = s.photoService.ImageUrl(liker.Image)
+		result = append(result, liker)
+	}
+	return result, count, nil
+}
@@ -4,9 +4,16 @@ import (
 	"DatingApp/src/filter"
 	"DatingApp/src/formatter"
 	"DatingApp/src/models"
-	mock_premium_feature "DatingApp/src/repositories/mock/premium_feature"
+	mock_block "DatingApp/src/repositories/mock/block"
+	mock_broker "DatingApp/src/repositories/mock/broker"
+	mock_entitlement "DatingApp/src/repositories/mock/entitlement"
+	mock_match "DatingApp/src/repositories/mock/match"
+	mock_quota_policy "DatingApp/src/repositories/mock/quota_policy"
+	mock_storage "DatingApp/src/repositories/mock/storage"
 	mock_user "DatingApp/src/repositories/mock/user"
 	mock_user_activity "DatingApp/src/repositories/mock/user_activity"
+	"DatingApp/src/services/photo"
+	"DatingApp/src/services/quota"
 	useractivity "DatingApp/src/services/user_activity"
 	"context"
 	"testing"
@@ -16,6 +23,10 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func runTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 func Test_userActivityService_Create(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -23,21 +34,40 @@ func Test_userActivityService_Create(t *testing.T) {
 
 	userActivityRepo := mock_user_activity.NewMockInterface(ctrl)
 	user := mock_user.NewMockInterface(ctrl)
-	premiumFeature := mock_premium_feature.NewMockInterface(ctrl)
+	entitlement := mock_entitlement.NewMockInterface(ctrl)
+	match := mock_match.NewMockInterface(ctrl)
+	broker := mock_broker.NewMockInterface(ctrl)
+	quotaPolicy := mock_quota_policy.NewMockInterface(ctrl)
+	block := mock_block.NewMockInterface(ctrl)
 	type mockfields struct {
-		userActivity   *mock_user_activity.MockInterface
-		user           *mock_user.MockInterface
-		premiumFeature *mock_premium_feature.MockInterface
+		userActivity *mock_user_activity.MockInterface
+		user         *mock_user.MockInterface
+		entitlement  *mock_entitlement.MockInterface
+		match        *mock_match.MockInterface
+		broker       *mock_broker.MockInterface
+		quotaPolicy  *mock_quota_policy.MockInterface
+		block        *mock_block.MockInterface
 	}
 	mocks := mockfields{
-		userActivity:   userActivityRepo,
-		user:           user,
-		premiumFeature: premiumFeature,
+		userActivity: userActivityRepo,
+		user:         user,
+		entitlement:  entitlement,
+		match:        match,
+		broker:       broker,
+		quotaPolicy:  quotaPolicy,
+		block:        block,
 	}
 	params := useractivity.Param{
-		UserActivityRepository:   userActivityRepo,
-		UserRepository:           mocks.user,
-		PremiumFeatureRepository: premiumFeature,
+		UserActivityRepository: userActivityRepo,
+		UserRepository:         mocks.user,
+		EntitlementRepository:  entitlement,
+		MatchRepository:        match,
+		BrokerRepository:       broker,
+		BlockRepository:        block,
+		QuotaService: quota.Init(quota.Param{
+			QuotaPolicyRepository:  quotaPolicy,
+			UserActivityRepository: userActivityRepo,
+		}),
 	}
 	service := useractivity.Init(params)
 	type args struct {
@@ -48,12 +78,73 @@ func Test_userActivityService_Create(t *testing.T) {
 	useractivity.Now = func() time.Time {
 		return mockTime
 	}
+	quota.Now = func() time.Time {
+		return mockTime
+	}
 
 	restoreAll := func() {
 		useractivity.Now = time.Now
+		quota.Now = time.Now
 	}
 	defer restoreAll()
 
+	utcTime := mockTime.UTC()
+	dayStart := time.Date(utcTime.Year(), utcTime.Month(), utcTime.Day(), 0, 0, 0, 0, time.UTC)
+	defaultPolicies := []models.QuotaPolicy{{Activity: models.ActivityAny, Period: models.QuotaPeriodDaily, MaxActivity: 10}}
+	premiumPolicies := append([]models.QuotaPolicy{{
+		Activity:         models.ActivityAny,
+		PremiumFeatureId: formatter.NullableDataType[int64]{Valid: true, Data: 1},
+		Period:           models.QuotaPeriodDaily,
+		MaxActivity:      models.QuotaUnlimited,
+	}}, defaultPolicies...)
+
+	mockPremiumUser := func(mock mockfields) {
+		mock.user.EXPECT().Get(context, filter.Paging[filter.UserFilter]{
+			Filter: filter.UserFilter{
+				Id: int(context.Value(models.UserKey).(models.User).Id),
+			},
+		}).Return([]models.User{{Id: 1, UserName: "me"}}, 1, nil)
+		mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivityLike, mockTime).Return(premiumPolicies, nil)
+	}
+	mockTargetExists := func(mock mockfields) {
+		mock.user.EXPECT().Get(context, filter.Paging[filter.UserFilter]{
+			IsActive: true,
+			Filter: filter.UserFilter{
+				Id: 2,
+			},
+		}).Return([]models.User{{Id: 2}}, 1, nil)
+	}
+	mockNotBlocked := func(mock mockfields) {
+		mockTargetExists(mock)
+		mock.block.EXPECT().IsBlocked(context, 1, 2).Return(false, nil)
+	}
+	mockLikedUser := func(mock mockfields, likedUser models.User) {
+		mock.user.EXPECT().Get(context, filter.Paging[filter.UserFilter]{
+			Filter: filter.UserFilter{
+				Id: 2,
+			},
+		}).Return([]models.User{likedUser}, 1, nil)
+	}
+	mockLikedUserSeesLikes := func(mock mockfields, seesLikes bool) {
+		mock.entitlement.EXPECT().HasFeature(context, 2, "likes-received", mockTime).Return(seesLikes, nil)
+	}
+	likeInput := models.Query[models.UserActivityInput]{
+		Model: models.UserActivityInput{
+			LikedUserId: 2,
+			Activity:    models.ActivityLike,
+			CreatedBy:   context.Value(models.UserKey).(models.User).Id,
+			CreatedAt:   mockTime,
+		},
+	}
+	superLikeInput := models.Query[models.UserActivityInput]{
+		Model: models.UserActivityInput{
+			LikedUserId: 2,
+			Activity:    models.ActivitySuperLike,
+			CreatedBy:   context.Value(models.UserKey).(models.User).Id,
+			CreatedAt:   mockTime,
+		},
+	}
+
 	tests := []struct {
 		name     string
 		args     args
@@ -89,7 +180,7 @@ func Test_userActivityService_Create(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "get premium feature error",
+			name: "lock user error",
 			args: args{
 				Input: models.Query[models.UserActivityInput]{},
 			},
@@ -98,17 +189,14 @@ func Test_userActivityService_Create(t *testing.T) {
 					Filter: filter.UserFilter{
 						Id: int(context.Value(models.UserKey).(models.User).Id),
 					},
-				}).Return([]models.User{{PremiumFeatureId: formatter.NullableDataType[int]{Data: 1, Valid: true}}}, 1, nil)
-				mock.premiumFeature.EXPECT().Get(context, filter.Paging[filter.PremiumFeatureFilter]{
-					Filter: filter.PremiumFeatureFilter{
-						Flag: "no-swipe-quota-limit",
-					},
-				}).Return([]models.PremiumFeature{}, 0, assert.AnError)
+				}).Return([]models.User{{Id: 1}}, 1, nil)
+				mock.userActivity.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.user.EXPECT().Lock(context, 1).Return(assert.AnError)
 			},
 			wantErr: true,
 		},
 		{
-			name: "get no premium feature",
+			name: "get quota policies error",
 			args: args{
 				Input: models.Query[models.UserActivityInput]{},
 			},
@@ -117,12 +205,28 @@ func Test_userActivityService_Create(t *testing.T) {
 					Filter: filter.UserFilter{
 						Id: int(context.Value(models.UserKey).(models.User).Id),
 					},
-				}).Return([]models.User{{PremiumFeatureId: formatter.NullableDataType[int]{Data: 1, Valid: true}}}, 1, nil)
-				mock.premiumFeature.EXPECT().Get(context, filter.Paging[filter.PremiumFeatureFilter]{
-					Filter: filter.PremiumFeatureFilter{
-						Flag: "no-swipe-quota-limit",
+				}).Return([]models.User{{Id: 1}}, 1, nil)
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivityPass, mockTime).Return([]models.QuotaPolicy{}, assert.AnError)
+				mock.userActivity.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.user.EXPECT().Lock(context, 1).Return(nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "reached max activity of default policy",
+			args: args{
+				Input: models.Query[models.UserActivityInput]{},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.user.EXPECT().Get(context, filter.Paging[filter.UserFilter]{
+					Filter: filter.UserFilter{
+						Id: int(context.Value(models.UserKey).(models.User).Id),
 					},
-				}).Return([]models.PremiumFeature{}, 0, nil)
+				}).Return([]models.User{{Id: 1}}, 1, nil)
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivityPass, mockTime).Return(defaultPolicies, nil)
+				mock.userActivity.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.user.EXPECT().Lock(context, 1).Return(nil)
+				mock.userActivity.EXPECT().CountActivity(context, 1, models.ActivityAny, dayStart).Return(models.ActivityCount{Count: 10}, nil)
 			},
 			wantErr: true,
 		},
@@ -136,14 +240,13 @@ func Test_userActivityService_Create(t *testing.T) {
 					Filter: filter.UserFilter{
 						Id: int(context.Value(models.UserKey).(models.User).Id),
 					},
-				}).Return([]models.User{{PremiumFeatureId: formatter.NullableDataType[int]{Data: 1, Valid: true}}}, 1, nil)
-				mock.premiumFeature.EXPECT().Get(context, filter.Paging[filter.PremiumFeatureFilter]{
-					Filter: filter.PremiumFeatureFilter{
-						Flag: "no-swipe-quota-limit",
-					},
-				}).Return([]models.PremiumFeature{{Id: 1}}, 1, nil)
+				}).Return([]models.User{{Id: 1}}, 1, nil)
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivityPass, mockTime).Return(premiumPolicies, nil)
+				mock.userActivity.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.user.EXPECT().Lock(context, 1).Return(nil)
 				mock.userActivity.EXPECT().Create(context, models.Query[models.UserActivityInput]{
 					Model: models.UserActivityInput{
+						Activity:  models.ActivityPass,
 						CreatedBy: context.Value(models.UserKey).(models.User).Id,
 						CreatedAt: mockTime,
 					},
@@ -163,20 +266,329 @@ func Test_userActivityService_Create(t *testing.T) {
 					Filter: filter.UserFilter{
 						Id: int(context.Value(models.UserKey).(models.User).Id),
 					},
-				}).Return([]models.User{{PremiumFeatureId: formatter.NullableDataType[int]{Data: 1, Valid: true}}}, 1, nil)
-				mock.premiumFeature.EXPECT().Get(context, filter.Paging[filter.PremiumFeatureFilter]{
-					Filter: filter.PremiumFeatureFilter{
-						Flag: "no-swipe-quota-limit",
-					},
-				}).Return([]models.PremiumFeature{{Id: 1}}, 1, nil)
+				}).Return([]models.User{{Id: 1}}, 1, nil)
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivityPass, mockTime).Return(premiumPolicies, nil)
+				mock.userActivity.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.user.EXPECT().Lock(context, 1).Return(nil)
 				mock.userActivity.EXPECT().Create(context, models.Query[models.UserActivityInput]{
 					Model: models.UserActivityInput{
+						Activity:  models.ActivityPass,
 						CreatedBy: context.Value(models.UserKey).(models.User).Id,
 						CreatedAt: mockTime,
 					},
 				}).Return(nil)
 			},
 		},
+		{
+			name: "like yourself",
+			args: args{
+				Input: models.Query[models.UserActivityInput]{
+					Model: models.UserActivityInput{LikedUserId: 1},
+				},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.user.EXPECT().Get(context, filter.Paging[filter.UserFilter]{
+					Filter: filter.UserFilter{
+						Id: int(context.Value(models.UserKey).(models.User).Id),
+					},
+				}).Return([]models.User{{Id: 1}}, 1, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "get target error",
+			args: args{
+				Input: models.Query[models.UserActivityInput]{
+					Model: models.UserActivityInput{LikedUserId: 2},
+				},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.user.EXPECT().Get(context, filter.Paging[filter.UserFilter]{
+					Filter: filter.UserFilter{
+						Id: int(context.Value(models.UserKey).(models.User).Id),
+					},
+				}).Return([]models.User{{Id: 1}}, 1, nil)
+				mock.user.EXPECT().Get(context, filter.Paging[filter.UserFilter]{
+					IsActive: true,
+					Filter: filter.UserFilter{
+						Id: 2,
+					},
+				}).Return([]models.User{}, 0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "like missing or deleted user",
+			args: args{
+				Input: models.Query[models.UserActivityInput]{
+					Model: models.UserActivityInput{LikedUserId: 2},
+				},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.user.EXPECT().Get(context, filter.Paging[filter.UserFilter]{
+					Filter: filter.UserFilter{
+						Id: int(context.Value(models.UserKey).(models.User).Id),
+					},
+				}).Return([]models.User{{Id: 1}}, 1, nil)
+				mock.user.EXPECT().Get(context, filter.Paging[filter.UserFilter]{
+					IsActive: true,
+					Filter: filter.UserFilter{
+						Id: 2,
+					},
+				}).Return([]models.User{}, 0, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "block check error",
+			args: args{
+				Input: models.Query[models.UserActivityInput]{
+					Model: models.UserActivityInput{LikedUserId: 2},
+				},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.user.EXPECT().Get(context, filter.Paging[filter.UserFilter]{
+					Filter: filter.UserFilter{
+						Id: int(context.Value(models.UserKey).(models.User).Id),
+					},
+				}).Return([]models.User{{Id: 1}}, 1, nil)
+				mockTargetExists(mock)
+				mock.block.EXPECT().IsBlocked(context, 1, 2).Return(false, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "like blocked user",
+			args: args{
+				Input: models.Query[models.UserActivityInput]{
+					Model: models.UserActivityInput{LikedUserId: 2},
+				},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.user.EXPECT().Get(context, filter.Paging[filter.UserFilter]{
+					Filter: filter.UserFilter{
+						Id: int(context.Value(models.UserKey).(models.User).Id),
+					},
+				}).Return([]models.User{{Id: 1}}, 1, nil)
+				mockTargetExists(mock)
+				mock.block.EXPECT().IsBlocked(context, 1, 2).Return(true, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "pass blocked user",
+			args: args{
+				Input: models.Query[models.UserActivityInput]{
+					Model: models.UserActivityInput{PassedUserId: 2},
+				},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.user.EXPECT().Get(context, filter.Paging[filter.UserFilter]{
+					Filter: filter.UserFilter{
+						Id: int(context.Value(models.UserKey).(models.User).Id),
+					},
+				}).Return([]models.User{{Id: 1}}, 1, nil)
+				mockTargetExists(mock)
+				mock.block.EXPECT().IsBlocked(context, 1, 2).Return(true, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "like without like back",
+			args: args{
+				Input: models.Query[models.UserActivityInput]{
+					Model: models.UserActivityInput{LikedUserId: 2},
+				},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mockPremiumUser(mock)
+				mockNotBlocked(mock)
+				mock.userActivity.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.user.EXPECT().Lock(context, 1).Return(nil)
+				mock.userActivity.EXPECT().Create(context, likeInput).Return(nil)
+				mock.userActivity.EXPECT().HasLiked(context, 2, 1).Return(false, nil)
+				mockLikedUser(mock, models.User{Id: 2, UserName: "other"})
+				mockLikedUserSeesLikes(mock, false)
+			},
+		},
+		{
+			name: "like user seeing likes notifies like",
+			args: args{
+				Input: models.Query[models.UserActivityInput]{
+					Model: models.UserActivityInput{LikedUserId: 2},
+				},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mockPremiumUser(mock)
+				mockNotBlocked(mock)
+				mock.userActivity.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.user.EXPECT().Lock(context, 1).Return(nil)
+				mock.userActivity.EXPECT().Create(context, likeInput).Return(nil)
+				mock.userActivity.EXPECT().HasLiked(context, 2, 1).Return(false, nil)
+				mockLikedUser(mock, models.User{Id: 2, UserName: "other"})
+				mockLikedUserSeesLikes(mock, true)
+				mock.broker.EXPECT().Publish(context, models.Event{
+					UserId:    2,
+					Type:      models.EventNewLike,
+					Data:      models.EventUser{Id: 1, UserName: "me"},
+					CreatedAt: mockTime,
+				}).Return(nil)
+			},
+		},
+		{
+			name: "super like without liked user",
+			args: args{
+				Input: models.Query[models.UserActivityInput]{
+					Model: models.UserActivityInput{Activity: models.ActivitySuperLike},
+				},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.user.EXPECT().Get(context, filter.Paging[filter.UserFilter]{
+					Filter: filter.UserFilter{
+						Id: int(context.Value(models.UserKey).(models.User).Id),
+					},
+				}).Return([]models.User{{Id: 1}}, 1, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "reached max super like",
+			args: args{
+				Input: models.Query[models.UserActivityInput]{
+					Model: models.UserActivityInput{LikedUserId: 2, Activity: models.ActivitySuperLike},
+				},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.user.EXPECT().Get(context, filter.Paging[filter.UserFilter]{
+					Filter: filter.UserFilter{
+						Id: int(context.Value(models.UserKey).(models.User).Id),
+					},
+				}).Return([]models.User{{Id: 1}}, 1, nil)
+				mockNotBlocked(mock)
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivitySuperLike, mockTime).Return([]models.QuotaPolicy{
+					{Activity: models.ActivitySuperLike, Period: models.QuotaPeriodDaily, MaxActivity: 1},
+				}, nil)
+				mock.userActivity.EXPECT().CountActivity(context, 1, models.ActivitySuperLike, dayStart).Return(models.ActivityCount{Count: 1}, nil)
+				mock.userActivity.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.user.EXPECT().Lock(context, 1).Return(nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "super like always notifies",
+			args: args{
+				Input: models.Query[models.UserActivityInput]{
+					Model: models.UserActivityInput{LikedUserId: 2, Activity: models.ActivitySuperLike},
+				},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.user.EXPECT().Get(context, filter.Paging[filter.UserFilter]{
+					Filter: filter.UserFilter{
+						Id: int(context.Value(models.UserKey).(models.User).Id),
+					},
+				}).Return([]models.User{{Id: 1, UserName: "me"}}, 1, nil)
+				mockNotBlocked(mock)
+				mock.quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivitySuperLike, mockTime).Return([]models.QuotaPolicy{
+					{Activity: models.ActivitySuperLike, Period: models.QuotaPeriodDaily, MaxActivity: 1},
+				}, nil)
+				mock.userActivity.EXPECT().CountActivity(context, 1, models.ActivitySuperLike, dayStart).Return(models.ActivityCount{}, nil)
+				mock.userActivity.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.user.EXPECT().Lock(context, 1).Return(nil)
+				mock.userActivity.EXPECT().Create(context, superLikeInput).Return(nil)
+				mock.userActivity.EXPECT().HasLiked(context, 2, 1).Return(false, nil)
+				mockLikedUser(mock, models.User{Id: 2, UserName: "other"})
+				mock.broker.EXPECT().Publish(context, models.Event{
+					UserId:    2,
+					Type:      models.EventSuperLike,
+					Data:      models.EventUser{Id: 1, UserName: "me"},
+					CreatedAt: mockTime,
+				}).Return(nil)
+			},
+		},
+		{
+			name: "like back check error",
+			args: args{
+				Input: models.Query[models.UserActivityInput]{
+					Model: models.UserActivityInput{LikedUserId: 2},
+				},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mockPremiumUser(mock)
+				mockNotBlocked(mock)
+				mock.userActivity.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.user.EXPECT().Lock(context, 1).Return(nil)
+				mock.userActivity.EXPECT().Create(context, likeInput).Return(nil)
+				mock.userActivity.EXPECT().HasLiked(context, 2, 1).Return(false, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "like back with existing match",
+			args: args{
+				Input: models.Query[models.UserActivityInput]{
+					Model: models.UserActivityInput{LikedUserId: 2},
+				},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mockPremiumUser(mock)
+				mockNotBlocked(mock)
+				mock.userActivity.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.user.EXPECT().Lock(context, 1).Return(nil)
+				mock.userActivity.EXPECT().Create(context, likeInput).Return(nil)
+				mock.userActivity.EXPECT().HasLiked(context, 2, 1).Return(true, nil)
+				mock.match.EXPECT().CreateMatch(context, models.MatchInput{UserId: 1, MatchedUserId: 2, CreatedAt: mockTime, CreatedBy: 1}).Return(false, nil)
+				mockLikedUser(mock, models.User{Id: 2, UserName: "other"})
+				mockLikedUserSeesLikes(mock, false)
+			},
+		},
+		{
+			name: "like back create match error",
+			args: args{
+				Input: models.Query[models.UserActivityInput]{
+					Model: models.UserActivityInput{LikedUserId: 2},
+				},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mockPremiumUser(mock)
+				mockNotBlocked(mock)
+				mock.userActivity.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.user.EXPECT().Lock(context, 1).Return(nil)
+				mock.userActivity.EXPECT().Create(context, likeInput).Return(nil)
+				mock.userActivity.EXPECT().HasLiked(context, 2, 1).Return(true, nil)
+				mock.match.EXPECT().CreateMatch(context, models.MatchInput{UserId: 1, MatchedUserId: 2, CreatedAt: mockTime, CreatedBy: 1}).Return(false, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "like back create match success",
+			args: args{
+				Input: models.Query[models.UserActivityInput]{
+					Model: models.UserActivityInput{LikedUserId: 2},
+				},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mockPremiumUser(mock)
+				mockNotBlocked(mock)
+				mock.userActivity.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				mock.user.EXPECT().Lock(context, 1).Return(nil)
+				mock.userActivity.EXPECT().Create(context, likeInput).Return(nil)
+				mock.userActivity.EXPECT().HasLiked(context, 2, 1).Return(true, nil)
+				mock.match.EXPECT().CreateMatch(context, models.MatchInput{UserId: 1, MatchedUserId: 2, CreatedAt: mockTime, CreatedBy: 1}).Return(true, nil)
+				mockLikedUser(mock, models.User{Id: 2, UserName: "other"})
+				mock.broker.EXPECT().Publish(context, models.Event{
+					UserId:    1,
+					Type:      models.EventNewMatch,
+					Data:      models.EventUser{Id: 2, UserName: "other"},
+					CreatedAt: mockTime,
+				}).Return(nil)
+				mock.broker.EXPECT().Publish(context, models.Event{
+					UserId:    2,
+					Type:      models.EventNewMatch,
+					Data:      models.EventUser{Id: 1, UserName: "me"},
+					CreatedAt: mockTime,
+				}).Return(nil)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -191,9 +603,187 @@ func Test_userActivityService_Create(t *testing.T) {
 	}
 }
 
+func Test_userActivityService_Rewind(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	userActivityRepo := mock_user_activity.NewMockInterface(ctrl)
+	entitlement := mock_entitlement.NewMockInterface(ctrl)
+	match := mock_match.NewMockInterface(ctrl)
+	quotaPolicy := mock_quota_policy.NewMockInterface(ctrl)
+	user := mock_user.NewMockInterface(ctrl)
+	service := useractivity.Init(useractivity.Param{
+		UserRepository:         user,
+		UserActivityRepository: userActivityRepo,
+		EntitlementRepository:  entitlement,
+		MatchRepository:        match,
+		QuotaService: quota.Init(quota.Param{
+			QuotaPolicyRepository:  quotaPolicy,
+			UserActivityRepository: userActivityRepo,
+		}),
+	})
+
+	mockTime := time.Date(2022, 5, 11, 10, 0, 0, 0, time.UTC)
+	useractivity.Now = func() time.Time {
+		return mockTime
+	}
+	quota.Now = func() time.Time {
+		return mockTime
+	}
+
+	restoreAll := func() {
+		useractivity.Now = time.Now
+		quota.Now = time.Now
+	}
+	defer restoreAll()
+
+	dayStart := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	rewindPolicies := []models.QuotaPolicy{{
+		Activity:         models.ActivityRewind,
+		PremiumFeatureId: formatter.NullableDataType[int64]{Valid: true, Data: 3},
+		Period:           models.QuotaPeriodDaily,
+		MaxActivity:      5,
+	}}
+	mockCanRewind := func(rewinds int) {
+		entitlement.EXPECT().HasFeature(context, 1, "rewind", mockTime).Return(true, nil)
+		userActivityRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+		user.EXPECT().Lock(context, 1).Return(nil)
+		quotaPolicy.EXPECT().GetUserPolicies(context, 1, models.ActivityRewind, mockTime).Return(rewindPolicies, nil)
+		userActivityRepo.EXPECT().CountActivity(context, 1, models.ActivityRewind, dayStart).Return(models.ActivityCount{Count: rewinds}, nil)
+	}
+	lastPaging := filter.Paging[filter.UserActivityFilter]{
+		Page:     1,
+		Take:     1,
+		OrderBy:  "id desc",
+		IsActive: true,
+		Filter: filter.UserActivityFilter{
+			UserId: 1,
+		},
+	}
+	rewound := models.Query[models.UserActivityInput]{
+		Model: models.UserActivityInput{
+			RewoundAt: mockTime,
+			Status:    -1,
+			DeletedAt: mockTime,
+			DeletedBy: 1,
+		},
+	}
+	pass := models.UserActivity{Id: 7, UserId: 1, PassedUserId: formatter.NullableDataType[int]{Valid: true, Data: 2}}
+	like := models.UserActivity{Id: 8, UserId: 1, LikedUserId: formatter.NullableDataType[int]{Valid: true, Data: 2}}
+	matchPaging := filter.Paging[filter.MatchFilter]{
+		IsActive: true,
+		Filter:   filter.MatchFilter{UserId: 1, MatchedUserId: 2},
+	}
+
+	tests := []struct {
+		name     string
+		mockfunc func()
+		want     models.UserActivity
+		wantErr  bool
+	}{
+		{
+			name: "entitlement error",
+			mockfunc: func() {
+				entitlement.EXPECT().HasFeature(context, 1, "rewind", mockTime).Return(false, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "not premium",
+			mockfunc: func() {
+				entitlement.EXPECT().HasFeature(context, 1, "rewind", mockTime).Return(false, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "lock user error",
+			mockfunc: func() {
+				entitlement.EXPECT().HasFeature(context, 1, "rewind", mockTime).Return(true, nil)
+				userActivityRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				user.EXPECT().Lock(context, 1).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "reached max rewind",
+			mockfunc: func() {
+				mockCanRewind(5)
+			},
+			wantErr: true,
+		},
+		{
+			name: "nothing to rewind",
+			mockfunc: func() {
+				mockCanRewind(0)
+				userActivityRepo.EXPECT().Get(context, lastPaging).Return([]models.UserActivity{}, 0, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "rewind update error",
+			mockfunc: func() {
+				mockCanRewind(0)
+				userActivityRepo.EXPECT().Get(context, lastPaging).Return([]models.UserActivity{pass}, 1, nil)
+				userActivityRepo.EXPECT().Update(context, rewound, 7).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "rewind pass",
+			mockfunc: func() {
+				mockCanRewind(1)
+				userActivityRepo.EXPECT().Get(context, lastPaging).Return([]models.UserActivity{pass}, 1, nil)
+				userActivityRepo.EXPECT().Update(context, rewound, 7).Return(nil)
+			},
+			want: pass,
+		},
+		{
+			name: "rewind like keeps match created by the other user",
+			mockfunc: func() {
+				mockCanRewind(1)
+				userActivityRepo.EXPECT().Get(context, lastPaging).Return([]models.UserActivity{like}, 1, nil)
+				userActivityRepo.EXPECT().Update(context, rewound, 8).Return(nil)
+				match.EXPECT().Get(context, matchPaging).Return([]models.Match{
+					{Id: 3, UserId: 1, MatchedUserId: 2, CreatedBy: formatter.NullableDataType[int64]{Valid: true, Data: 2}},
+				}, 1, nil)
+			},
+			want: like,
+		},
+		{
+			name: "rewind like undoes its match",
+			mockfunc: func() {
+				mockCanRewind(1)
+				userActivityRepo.EXPECT().Get(context, lastPaging).Return([]models.UserActivity{like}, 1, nil)
+				userActivityRepo.EXPECT().Update(context, rewound, 8).Return(nil)
+				match.EXPECT().Get(context, matchPaging).Return([]models.Match{
+					{Id: 3, UserId: 1, MatchedUserId: 2, CreatedBy: formatter.NullableDataType[int64]{Valid: true, Data: 1}},
+				}, 1, nil)
+				match.EXPECT().Update(context, models.Query[models.MatchInput]{
+					Model: models.MatchInput{Status: -1, DeletedAt: mockTime, DeletedBy: 1},
+				}, 3).Return(nil)
+			},
+			want: like,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc()
+
+			activity, err := service.Rewind(context)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("userActivity.Rewind() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, activity)
+		})
+	}
+}
+
 func Test_userActivityService_Update(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
+	adminContext := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1, Role: models.RoleAdmin})
 	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
 
 	userActivityRepo := mock_user_activity.NewMockInterface(ctrl)
@@ -210,6 +800,7 @@ func Test_userActivityService_Update(t *testing.T) {
 	type args struct {
 		Input models.Query[models.UserActivityInput]
 		Id    int
+		Admin bool
 	}
 
 	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.Local)
@@ -222,12 +813,67 @@ func Test_userActivityService_Update(t *testing.T) {
 	}
 	defer restoreAll()
 
+	paging := filter.Paging[filter.UserActivityFilter]{
+		IsActive: true,
+		Filter: filter.UserActivityFilter{
+			Id: 1,
+		},
+	}
+
 	tests := []struct {
 		name     string
 		args     args
 		mockfunc func(a args, mock mockfields)
 		wantErr  bool
 	}{
+		{
+			name: "get userActivity error",
+			args: args{
+				Input: models.Query[models.UserActivityInput]{},
+				Id:    1,
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.userActivity.EXPECT().Get(context, paging).Return([]models.UserActivity{}, 0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "userActivity doesnt exists",
+			args: args{
+				Input: models.Query[models.UserActivityInput]{},
+				Id:    1,
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.userActivity.EXPECT().Get(context, paging).Return([]models.UserActivity{}, 0, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "update other user activity forbidden",
+			args: args{
+				Input: models.Query[models.UserActivityInput]{},
+				Id:    1,
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.userActivity.EXPECT().Get(context, paging).Return([]models.UserActivity{{Id: 1, UserId: 2}}, 1, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "reassign user activity forbidden",
+			args: args{
+				Input: models.Query[models.UserActivityInput]{
+					Model: models.UserActivityInput{
+						UserId: 2,
+					},
+				},
+				Id: 1,
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.userActivity.EXPECT().Get(context, paging).Return([]models.UserActivity{{Id: 1, UserId: 1}}, 1, nil)
+			},
+			wantErr: true,
+		},
 		{
 			name: "update userActivity error",
 			args: args{
@@ -235,6 +881,7 @@ func Test_userActivityService_Update(t *testing.T) {
 				Id:    1,
 			},
 			mockfunc: func(a args, mock mockfields) {
+				mock.userActivity.EXPECT().Get(context, paging).Return([]models.UserActivity{{Id: 1, UserId: 1}}, 1, nil)
 				mock.userActivity.EXPECT().Update(context, models.Query[models.UserActivityInput]{
 					Model: models.UserActivityInput{
 						UpdatedBy: context.Value(models.UserKey).(models.User).Id,
@@ -253,6 +900,7 @@ func Test_userActivityService_Update(t *testing.T) {
 				Id: 1,
 			},
 			mockfunc: func(a args, mock mockfields) {
+				mock.userActivity.EXPECT().Get(context, paging).Return([]models.UserActivity{{Id: 1, UserId: 1}}, 1, nil)
 				mock.userActivity.EXPECT().Update(context, models.Query[models.UserActivityInput]{
 					Model: models.UserActivityInput{
 						UpdatedBy: context.Value(models.UserKey).(models.User).Id,
@@ -261,12 +909,39 @@ func Test_userActivityService_Update(t *testing.T) {
 				}, 1).Return(nil)
 			},
 		},
+		{
+			name: "admin update other user activity success",
+			args: args{
+				Input: models.Query[models.UserActivityInput]{
+					Model: models.UserActivityInput{
+						UserId: 3,
+					},
+				},
+				Id:    1,
+				Admin: true,
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.userActivity.EXPECT().Get(adminContext, paging).Return([]models.UserActivity{{Id: 1, UserId: 2}}, 1, nil)
+				mock.userActivity.EXPECT().Update(adminContext, models.Query[models.UserActivityInput]{
+					Model: models.UserActivityInput{
+						UserId:    3,
+						UpdatedBy: 1,
+						UpdatedAt: mockTime,
+					},
+				}, 1).Return(nil)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockfunc(tt.args, mocks)
 
-			err := service.Update(context, tt.args.Input, tt.args.Id)
+			ctx := context
+			if tt.args.Admin {
+				ctx = adminContext
+			}
+
+			err := service.Update(ctx, tt.args.Input, tt.args.Id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("userActivity.Update() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -305,18 +980,36 @@ func Test_userActivityService_Delete(t *testing.T) {
 	}
 	defer restoreAll()
 
+	paging := filter.Paging[filter.UserActivityFilter]{
+		IsActive: true,
+		Filter: filter.UserActivityFilter{
+			Id: 1,
+		},
+	}
+
 	tests := []struct {
 		name     string
 		args     args
 		mockfunc func(a args, mock mockfields)
 		wantErr  bool
 	}{
+		{
+			name: "delete other user activity forbidden",
+			args: args{
+				Id: 1,
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.userActivity.EXPECT().Get(context, paging).Return([]models.UserActivity{{Id: 1, UserId: 2}}, 1, nil)
+			},
+			wantErr: true,
+		},
 		{
 			name: "delete userActivity error",
 			args: args{
 				Id: 1,
 			},
 			mockfunc: func(a args, mock mockfields) {
+				mock.userActivity.EXPECT().Get(context, paging).Return([]models.UserActivity{{Id: 1, UserId: 1}}, 1, nil)
 				mock.userActivity.EXPECT().Update(context, models.Query[models.UserActivityInput]{
 					Model: models.UserActivityInput{
 						DeletedBy: context.Value(models.UserKey).(models.User).Id,
@@ -333,6 +1026,7 @@ func Test_userActivityService_Delete(t *testing.T) {
 				Id: 1,
 			},
 			mockfunc: func(a args, mock mockfields) {
+				mock.userActivity.EXPECT().Get(context, paging).Return([]models.UserActivity{{Id: 1, UserId: 1}}, 1, nil)
 				mock.userActivity.EXPECT().Update(context, models.Query[models.UserActivityInput]{
 					Model: models.UserActivityInput{
 						DeletedBy: context.Value(models.UserKey).(models.User).Id,
@@ -433,3 +1127,142 @@ func Test_userActivityService_Get(t *testing.T) {
 		})
 	}
 }
+
+func Test_userActivityService_GetLikesReceived(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	mockImage, signedImage := "storage://photos/2/abc", "https://app.example.com/photos/2/abc/medium.jpg"
+
+	userActivityRepo := mock_user_activity.NewMockInterface(ctrl)
+	entitlementRepo := mock_entitlement.NewMockInterface(ctrl)
+	storageRepo := mock_storage.NewMockInterface(ctrl)
+	type mockfields struct {
+		userActivity *mock_user_activity.MockInterface
+		entitlement  *mock_entitlement.MockInterface
+		storage      *mock_storage.MockInterface
+	}
+	mocks := mockfields{
+		userActivity: userActivityRepo,
+		entitlement:  entitlementRepo,
+		storage:      storageRepo,
+	}
+	params := useractivity.Param{
+		UserActivityRepository: userActivityRepo,
+		EntitlementRepository:  entitlementRepo,
+		PhotoService:           photo.Init(photo.Param{StorageRepository: storageRepo}),
+	}
+	service := useractivity.Init(params)
+
+	useractivity.Now = func() time.Time {
+		return mockTime
+	}
+	photo.Now = func() time.Time {
+		return mockTime
+	}
+
+	restoreAll := func() {
+		useractivity.Now = time.Now
+		photo.Now = time.Now
+	}
+	defer restoreAll()
+	type args struct {
+		Paging filter.Paging[filter.UserActivityFilter]
+	}
+
+	likes := []models.LikeReceived{
+		{
+			Id:        2,
+			UserName:  "test",
+			Image:     &mockImage,
+			SuperLike: true,
+			LikedAt:   mockTime,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		mockfunc  func(a args, mock mockfields)
+		want      []models.LikeReceivedUser
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name: "has feature error",
+			args: args{
+				filter.Paging[filter.UserActivityFilter]{Page: 1, Take: 10},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.entitlement.EXPECT().HasFeature(context, 1, "likes-received", mockTime).Return(false, assert.AnError)
+			},
+			want:    []models.LikeReceivedUser{},
+			wantErr: true,
+		},
+		{
+			name: "get likes received error",
+			args: args{
+				filter.Paging[filter.UserActivityFilter]{Page: 1, Take: 10},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.entitlement.EXPECT().HasFeature(context, 1, "likes-received", mockTime).Return(false, nil)
+				mock.userActivity.EXPECT().GetLikesReceived(context, 1, a.Paging).Return([]models.LikeReceived{}, 0, assert.AnError)
+			},
+			want:    []models.LikeReceivedUser{},
+			wantErr: true,
+		},
+		{
+			name: "without likes received feature gets blurred likes",
+			args: args{
+				filter.Paging[filter.UserActivityFilter]{Page: 1, Take: 10},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.entitlement.EXPECT().HasFeature(context, 1, "likes-received", mockTime).Return(false, nil)
+				mock.userActivity.EXPECT().GetLikesReceived(context, 1, a.Paging).Return(likes, 1, nil)
+			},
+			want: []models.LikeReceivedUser{
+				{
+					SuperLike: true,
+					LikedAt:   mockTime,
+					Blurred:   true,
+				},
+			},
+			wantCount: 1,
+		},
+		{
+			name: "likes received feature gets full likes",
+			args: args{
+				filter.Paging[filter.UserActivityFilter]{Page: 1, Take: 10},
+			},
+			mockfunc: func(a args, mock mockfields) {
+				mock.entitlement.EXPECT().HasFeature(context, 1, "likes-received", mockTime).Return(true, nil)
+				mock.userActivity.EXPECT().GetLikesReceived(context, 1, a.Paging).Return(likes, 1, nil)
+				mock.storage.EXPECT().SignedUrl("photos/2/abc/medium.jpg", mockTime.Add(2*models.PhotoUrlTTL)).Return(signedImage)
+			},
+			want: []models.LikeReceivedUser{
+				{
+					Id:        2,
+					UserName:  "test",
+					Image:     &signedImage,
+					SuperLike: true,
+					LikedAt:   mockTime,
+				},
+			},
+			wantCount: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc(tt.args, mocks)
+
+			likes, count, err := service.GetLikesReceived(context, tt.args.Paging)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("userActivity.GetLikesReceived() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, likes)
+			assert.Equal(t, tt.wantCount, count)
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package verification
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/models"
+	"DatingApp/src/repositories/entitlement"
+	premiumfeature "DatingApp/src/repositories/premium_feature"
+	"DatingApp/src/repositories/subscription"
+	"DatingApp/src/repositories/verification"
+	"DatingApp/src/services/photo"
+	"context"
+	"errors"
+	"time"
+)
+
+type Interface interface {
+	Request(ctx context.Context, selfie []byte) error
+	Get(ctx context.Context) (models.Verification, error)
+	GetVerifications(ctx context.Context, paging filter.Paging[filter.VerificationFilter]) ([]models.Verification, int, error)
+	Review(ctx context.Context, id int, input models.VerificationReview) error
+}
+
+type verificationService struct {
+	verificationRepository   verification.Interface
+	entitlementRepository    entitlement.Interface
+	premiumFeatureRepository premiumfeature.Interface
+	subscriptionRepository   subscription.Interface
+	photoService             photo.Interface
+}
+
+type Param struct {
+	VerificationRepository   verification.Interface
+	EntitlementRepository    entitlement.Interface
+	PremiumFeatureRepository premiumfeature.Interface
+	SubscriptionRepository   subscription.Interface
+	PhotoService             photo.Interface
+}
+
+func Init(param Param) Interface {
+	return &verificationService{
+		verificationRepository:   param.VerificationRepository,
+		entitlementRepository:    param.EntitlementRepository,
+		premiumFeatureRepository: param.PremiumFeatureRepository,
+		subscriptionRepository:   param.SubscriptionRepository,
+		photoService:             param.PhotoService,
+	}
+}
+
+var Now = time.Now
+
+// Request puts a selfie of the user in the review queue, a user has at most one request waiting at a time.
+func (s *verificationService) Request(ctx context.Context, selfie []byte) error {
+	userId := ctx.Value(models.UserKey).(models.User).Id
+
+	verified, err := s.entitlementRepository.HasFeature(ctx, int(userId), models.VerifiedFlag, Now())
+	if err != nil {
+		return err
+	}
+	if verified {
+		return models.ErrAlreadyVerified
+	}
+
+	_, count, err := s.verificationRepository.Get(ctx, filter.Paging[filter.VerificationFilter]{
+		Filter: filter.VerificationFilter{
+			UserId: int(userId),
+			Status: models.VerificationPending,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return models.ErrVerificationPending
+	}
+
+	image, err := s.photoService.Store(ctx, selfie)
+	if err != nil {
+		return err
+	}
+
+	err = s.verificationRepository.Create(ctx, models.Query[models.VerificationInput]{
+		Model: models.VerificationInput{
+			UserId:    int(userId),
+			Selfie:    image,
+			Status:    models.VerificationPending,
+			CreatedAt: Now(),
+			CreatedBy: userId,
+		},
+	})
+	if err != nil {
+		// nothing points at the selfie anymore
+		s.photoService.Remove(ctx, int(userId), image)
+		return err
+	}
+	return nil
+}
+
+// Get returns the latest verification request of the user, with the reason when it was rejected.
+func (s *verificationService) Get(ctx context.Context) (models.Verification, error) {
+	userId := ctx.Value(models.UserKey).(models.User).Id
+
+	verifications, _, err := s.verificationRepository.Get(ctx, filter.Paging[filter.VerificationFilter]{
+		Page:    1,
+		Take:    1,
+		OrderBy: "id desc",
+		Filter: filter.VerificationFilter{
+			UserId: int(userId),
+		},
+	})
+	if err != nil {
+		return models.Verification{}, err
+	}
+	if len(verifications) == 0 {
+		return models.Verification{}, models.ErrVerificationNotFound
+	}
+	return s.resolve(verifications[0]), nil
+}
+
+// GetVerifications lists the review queue, pending requests oldest first unless asked otherwise.
+func (s *verificationService) GetVerifications(ctx context.Context, paging filter.Paging[filter.VerificationFilter]) ([]models.Verification, int, error) {
+	if paging.Filter.Status == 0 {
+		paging.Filter.Status = models.VerificationPending
+	}
+	if paging.OrderBy == "" {
+		paging.OrderBy = "id asc"
+	}
+
+	verifications, count, err := s.verificationRepository.Get(ctx, paging)
+	if err != nil {
+		return []models.Verification{}, count, err
+	}
+	result := []models.Verification{}
+	for _, verification := range verifications {
+		result = append(result, s.resolve(verification))
+	}
+	return result, count, nil
+}
+
+// Review closes a pending request, approving it grants the user the verified premium feature
+// and rejecting it removes the selfie since it was only kept to be reviewed.
+func (s *verificationService) Review(ctx context.Context, id int, input models.VerificationReview) error {
+	adminId := ctx.Value(models.UserKey).(models.User).Id
+
+	verifications, _, err := s.verificationRepository.Get(ctx, filter.Paging[filter.VerificationFilter]{
+		Filter: filter.VerificationFilter{
+			Id:     id,
+			Status: models.VerificationPending,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if len(verifications) == 0 {
+		return models.ErrVerificationNotFound
+	}
+	verification := verifications[0]
+	now := Now()
+
+	if input.Action == models.VerificationActionReject {
+		closed, err := s.verificationRepository.Close(ctx, id, models.VerificationInput{
+			Status:    models.VerificationRejected,
+			Reason:    input.Reason,
+			UpdatedAt: now,
+			UpdatedBy: adminId,
+		})
+		if err != nil {
+			return err
+		}
+		if !closed {
+			return models.ErrVerificationNotFound
+		}
+		// removing the files is best effort, the request doesnt point at them anymore
+		s.photoService.Remove(ctx, verification.UserId, verification.Selfie)
+		return nil
+	}
+
+	features, _, err := s.premiumFeatureRepository.Get(ctx, filter.Paging[filter.PremiumFeatureFilter]{
+		IsActive: true,
+		Filter: filter.PremiumFeatureFilter{
+			Flag: models.VerifiedFlag,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if len(features) == 0 {
+		return errors.New("premium feature doesnt exists")
+	}
+	feature := features[0]
+
+	return s.verificationRepository.Transaction(ctx, func(ctx context.Context) error {
+		// another admin may have closed the request since it was read
+		closed, err := s.verificationRepository.Close(ctx, id, models.VerificationInput{
+			Selfie:    verification.Selfie,
+			Status:    models.VerificationApproved,
+			UpdatedAt: now,
+			UpdatedBy: adminId,
+		})
+		if err != nil {
+			return err
+		}
+		if !closed {
+			return models.ErrVerificationNotFound
+		}
+
+		if err := s.subscriptionRepository.ExpireUserSubscriptions(ctx, verification.UserId, int(feature.Id), now); err != nil {
+			return err
+		}
+		return s.subscriptionRepository.Create(ctx, models.Query[models.SubscriptionInput]{
+			Model: models.SubscriptionInput{
+				UserId:           verification.UserId,
+				PremiumFeatureId: int(feature.Id),
+				StartedAt:        now,
+				ExpiresAt:        now.AddDate(0, 0, feature.DurationDays),
+				Source:           models.SubscriptionSourceVerification,
+				CreatedAt:        now,
+				CreatedBy:        adminId,
+			},
+		})
+	})
+}
+
+// resolve turns the selfie into an url that can be shown.
+func (s *verificationService) resolve(verification models.Verification) models.Verification {
+	verification.Selfie = s.photoService.Url(verification.Selfie, models.PhotoSizeOriginal)
+	return verification
+}
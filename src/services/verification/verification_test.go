@@ -0,0 +1,478 @@
+package verification_test
+
+import (
+	"DatingApp/src/filter"
+	"DatingApp/src/formatter"
+	"DatingApp/src/models"
+	mock_entitlement "DatingApp/src/repositories/mock/entitlement"
+	mock_premium_feature "DatingApp/src/repositories/mock/premium_feature"
+	mock_storage "DatingApp/src/repositories/mock/storage"
+	mock_subscription "DatingApp/src/repositories/mock/subscription"
+	mock_verification "DatingApp/src/repositories/mock/verification"
+	"DatingApp/src/services/photo"
+	"DatingApp/src/services/verification"
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func runTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func pngOf(width, height int) []byte {
+	var buf bytes.Buffer
+	png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)))
+	return buf.Bytes()
+}
+
+func Test_verificationService_Request(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	verificationRepo := mock_verification.NewMockInterface(ctrl)
+	entitlementRepo := mock_entitlement.NewMockInterface(ctrl)
+	storageRepo := mock_storage.NewMockInterface(ctrl)
+	service := verification.Init(verification.Param{
+		VerificationRepository: verificationRepo,
+		EntitlementRepository:  entitlementRepo,
+		PhotoService:           photo.Init(photo.Param{StorageRepository: storageRepo}),
+	})
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	verification.Now = func() time.Time {
+		return mockTime
+	}
+	defer func() {
+		verification.Now = time.Now
+	}()
+
+	pending := filter.Paging[filter.VerificationFilter]{
+		Filter: filter.VerificationFilter{
+			UserId: 1,
+			Status: models.VerificationPending,
+		},
+	}
+	storeSelfie := func() {
+		storageRepo.EXPECT().Put(context, gomock.Any(), gomock.Any()).Return(nil).Times(len(models.PhotoSizes) + 1)
+	}
+	assertRequest := func(input models.Query[models.VerificationInput]) {
+		assert.Regexp(t, "^storage://photos/1/[0-9a-f]{32}$", input.Model.Selfie)
+		input.Model.Selfie = ""
+		assert.Equal(t, models.VerificationInput{
+			UserId:    1,
+			Status:    models.VerificationPending,
+			CreatedAt: mockTime,
+			CreatedBy: 1,
+		}, input.Model)
+	}
+
+	tests := []struct {
+		name     string
+		selfie   []byte
+		mockfunc func()
+		wantErr  error
+	}{
+		{
+			name:   "has feature error",
+			selfie: pngOf(10, 10),
+			mockfunc: func() {
+				entitlementRepo.EXPECT().HasFeature(context, 1, models.VerifiedFlag, mockTime).Return(false, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name:   "already verified",
+			selfie: pngOf(10, 10),
+			mockfunc: func() {
+				entitlementRepo.EXPECT().HasFeature(context, 1, models.VerifiedFlag, mockTime).Return(true, nil)
+			},
+			wantErr: models.ErrAlreadyVerified,
+		},
+		{
+			name:   "get verification error",
+			selfie: pngOf(10, 10),
+			mockfunc: func() {
+				entitlementRepo.EXPECT().HasFeature(context, 1, models.VerifiedFlag, mockTime).Return(false, nil)
+				verificationRepo.EXPECT().Get(context, pending).Return([]models.Verification{}, 0, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name:   "already waiting for review",
+			selfie: pngOf(10, 10),
+			mockfunc: func() {
+				entitlementRepo.EXPECT().HasFeature(context, 1, models.VerifiedFlag, mockTime).Return(false, nil)
+				verificationRepo.EXPECT().Get(context, pending).Return([]models.Verification{{Id: 3}}, 1, nil)
+			},
+			wantErr: models.ErrVerificationPending,
+		},
+		{
+			name:   "selfie is not an image",
+			selfie: []byte("hello, this is not an image"),
+			mockfunc: func() {
+				entitlementRepo.EXPECT().HasFeature(context, 1, models.VerifiedFlag, mockTime).Return(false, nil)
+				verificationRepo.EXPECT().Get(context, pending).Return([]models.Verification{}, 0, nil)
+			},
+			wantErr: models.ErrUnsupportedPhoto,
+		},
+		{
+			name:   "create verification error removes the selfie",
+			selfie: pngOf(10, 10),
+			mockfunc: func() {
+				entitlementRepo.EXPECT().HasFeature(context, 1, models.VerifiedFlag, mockTime).Return(false, nil)
+				verificationRepo.EXPECT().Get(context, pending).Return([]models.Verification{}, 0, nil)
+				storeSelfie()
+				verificationRepo.EXPECT().Create(context, gomock.Any()).DoAndReturn(func(ctx interface{}, input models.Query[models.VerificationInput]) error {
+					assertRequest(input)
+					return assert.AnError
+				})
+				storageRepo.EXPECT().Delete(context, gomock.Any()).Return(nil).Times(len(models.PhotoSizes) + 1)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name:   "request success",
+			selfie: pngOf(10, 10),
+			mockfunc: func() {
+				entitlementRepo.EXPECT().HasFeature(context, 1, models.VerifiedFlag, mockTime).Return(false, nil)
+				verificationRepo.EXPECT().Get(context, pending).Return([]models.Verification{}, 0, nil)
+				storeSelfie()
+				verificationRepo.EXPECT().Create(context, gomock.Any()).DoAndReturn(func(ctx interface{}, input models.Query[models.VerificationInput]) error {
+					assertRequest(input)
+					return nil
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc()
+
+			err := service.Request(context, tt.selfie)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_verificationService_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 1})
+
+	verificationRepo := mock_verification.NewMockInterface(ctrl)
+	storageRepo := mock_storage.NewMockInterface(ctrl)
+	service := verification.Init(verification.Param{
+		VerificationRepository: verificationRepo,
+		PhotoService:           photo.Init(photo.Param{StorageRepository: storageRepo}),
+	})
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	photo.Now = func() time.Time {
+		return mockTime
+	}
+	defer func() {
+		photo.Now = time.Now
+	}()
+
+	latest := filter.Paging[filter.VerificationFilter]{
+		Page:    1,
+		Take:    1,
+		OrderBy: "id desc",
+		Filter: filter.VerificationFilter{
+			UserId: 1,
+		},
+	}
+	reason := formatter.NullableDataType[string]{Valid: true, Data: "face is not visible"}
+
+	tests := []struct {
+		name     string
+		mockfunc func()
+		want     models.Verification
+		wantErr  bool
+	}{
+		{
+			name: "get verification error",
+			mockfunc: func() {
+				verificationRepo.EXPECT().Get(context, latest).Return([]models.Verification{}, 0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "never asked for verification",
+			mockfunc: func() {
+				verificationRepo.EXPECT().Get(context, latest).Return([]models.Verification{}, 0, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejected verification",
+			mockfunc: func() {
+				verificationRepo.EXPECT().Get(context, latest).Return([]models.Verification{
+					{Id: 3, UserId: 1, Selfie: "storage://photos/1/abc", Reason: reason, Status: models.VerificationRejected},
+				}, 1, nil)
+				storageRepo.EXPECT().SignedUrl("photos/1/abc/original.jpg", mockTime.Add(2*models.PhotoUrlTTL)).Return("https://app.example.com/photos/1/abc/original.jpg")
+			},
+			want: models.Verification{
+				Id:     3,
+				UserId: 1,
+				Selfie: "https://app.example.com/photos/1/abc/original.jpg",
+				Reason: reason,
+				Status: models.VerificationRejected,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc()
+
+			result, err := service.Get(context)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verification.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func Test_verificationService_GetVerifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 9, Role: models.RoleAdmin})
+
+	verificationRepo := mock_verification.NewMockInterface(ctrl)
+	storageRepo := mock_storage.NewMockInterface(ctrl)
+	service := verification.Init(verification.Param{
+		VerificationRepository: verificationRepo,
+		PhotoService:           photo.Init(photo.Param{StorageRepository: storageRepo}),
+	})
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	photo.Now = func() time.Time {
+		return mockTime
+	}
+	defer func() {
+		photo.Now = time.Now
+	}()
+
+	tests := []struct {
+		name      string
+		paging    filter.Paging[filter.VerificationFilter]
+		mockfunc  func()
+		want      []models.Verification
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name:   "get verifications error",
+			paging: filter.Paging[filter.VerificationFilter]{Page: 1, Take: 10},
+			mockfunc: func() {
+				verificationRepo.EXPECT().Get(context, filter.Paging[filter.VerificationFilter]{
+					Page: 1, Take: 10, OrderBy: "id asc", Filter: filter.VerificationFilter{Status: models.VerificationPending},
+				}).Return([]models.Verification{}, 0, assert.AnError)
+			},
+			want:    []models.Verification{},
+			wantErr: true,
+		},
+		{
+			name:   "pending verifications oldest first",
+			paging: filter.Paging[filter.VerificationFilter]{Page: 1, Take: 10},
+			mockfunc: func() {
+				verificationRepo.EXPECT().Get(context, filter.Paging[filter.VerificationFilter]{
+					Page: 1, Take: 10, OrderBy: "id asc", Filter: filter.VerificationFilter{Status: models.VerificationPending},
+				}).Return([]models.Verification{
+					{Id: 3, UserId: 1, Selfie: "storage://photos/1/abc", Status: models.VerificationPending},
+				}, 1, nil)
+				storageRepo.EXPECT().SignedUrl("photos/1/abc/original.jpg", mockTime.Add(2*models.PhotoUrlTTL)).Return("https://app.example.com/photos/1/abc/original.jpg")
+			},
+			want: []models.Verification{
+				{Id: 3, UserId: 1, Selfie: "https://app.example.com/photos/1/abc/original.jpg", Status: models.VerificationPending},
+			},
+			wantCount: 1,
+		},
+		{
+			name: "rejected verifications newest first",
+			paging: filter.Paging[filter.VerificationFilter]{
+				Page: 1, Take: 10, OrderBy: "id desc", Filter: filter.VerificationFilter{Status: models.VerificationRejected},
+			},
+			mockfunc: func() {
+				verificationRepo.EXPECT().Get(context, filter.Paging[filter.VerificationFilter]{
+					Page: 1, Take: 10, OrderBy: "id desc", Filter: filter.VerificationFilter{Status: models.VerificationRejected},
+				}).Return([]models.Verification{}, 0, nil)
+			},
+			want: []models.Verification{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc()
+
+			verifications, count, err := service.GetVerifications(context, tt.paging)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verification.GetVerifications() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, verifications)
+			assert.Equal(t, tt.wantCount, count)
+		})
+	}
+}
+
+func Test_verificationService_Review(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	context := context.WithValue(context.Background(), models.UserKey, models.User{Id: 9, Role: models.RoleAdmin})
+
+	verificationRepo := mock_verification.NewMockInterface(ctrl)
+	premiumFeatureRepo := mock_premium_feature.NewMockInterface(ctrl)
+	subscriptionRepo := mock_subscription.NewMockInterface(ctrl)
+	storageRepo := mock_storage.NewMockInterface(ctrl)
+	service := verification.Init(verification.Param{
+		VerificationRepository:   verificationRepo,
+		PremiumFeatureRepository: premiumFeatureRepo,
+		SubscriptionRepository:   subscriptionRepo,
+		PhotoService:             photo.Init(photo.Param{StorageRepository: storageRepo}),
+	})
+
+	mockTime := time.Date(2022, 5, 11, 0, 0, 0, 0, time.UTC)
+	verification.Now = func() time.Time {
+		return mockTime
+	}
+	defer func() {
+		verification.Now = time.Now
+	}()
+
+	paging := filter.Paging[filter.VerificationFilter]{
+		Filter: filter.VerificationFilter{
+			Id:     3,
+			Status: models.VerificationPending,
+		},
+	}
+	featurePaging := filter.Paging[filter.PremiumFeatureFilter]{
+		IsActive: true,
+		Filter: filter.PremiumFeatureFilter{
+			Flag: models.VerifiedFlag,
+		},
+	}
+	selfie := "storage://photos/1/00112233445566778899aabbccddeeff"
+	pending := []models.Verification{{Id: 3, UserId: 1, Selfie: selfie, Status: models.VerificationPending}}
+	verified := []models.PremiumFeature{{Id: 2, Name: "Verified", Flag: models.VerifiedFlag, DurationDays: 365}}
+	approve := models.VerificationInput{Selfie: selfie, Status: models.VerificationApproved, UpdatedAt: mockTime, UpdatedBy: 9}
+	reject := models.VerificationInput{Status: models.VerificationRejected, Reason: "face is not visible", UpdatedAt: mockTime, UpdatedBy: 9}
+	grant := models.Query[models.SubscriptionInput]{
+		Model: models.SubscriptionInput{
+			UserId:           1,
+			PremiumFeatureId: 2,
+			StartedAt:        mockTime,
+			ExpiresAt:        mockTime.AddDate(0, 0, 365),
+			Source:           models.SubscriptionSourceVerification,
+			CreatedAt:        mockTime,
+			CreatedBy:        9,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		input    models.VerificationReview
+		mockfunc func()
+		wantErr  bool
+	}{
+		{
+			name:  "get verification error",
+			input: models.VerificationReview{Action: models.VerificationActionApprove},
+			mockfunc: func() {
+				verificationRepo.EXPECT().Get(context, paging).Return([]models.Verification{}, 0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "verification already reviewed",
+			input: models.VerificationReview{Action: models.VerificationActionApprove},
+			mockfunc: func() {
+				verificationRepo.EXPECT().Get(context, paging).Return([]models.Verification{}, 0, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "reject reviewed by another admin",
+			input: models.VerificationReview{Action: models.VerificationActionReject, Reason: "face is not visible"},
+			mockfunc: func() {
+				verificationRepo.EXPECT().Get(context, paging).Return(pending, 1, nil)
+				verificationRepo.EXPECT().Close(context, 3, reject).Return(false, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "reject with a reason removes the selfie",
+			input: models.VerificationReview{Action: models.VerificationActionReject, Reason: "face is not visible"},
+			mockfunc: func() {
+				verificationRepo.EXPECT().Get(context, paging).Return(pending, 1, nil)
+				verificationRepo.EXPECT().Close(context, 3, reject).Return(true, nil)
+				storageRepo.EXPECT().Delete(context, gomock.Any()).Return(nil).Times(len(models.PhotoSizes) + 1)
+			},
+		},
+		{
+			name:  "verified feature missing",
+			input: models.VerificationReview{Action: models.VerificationActionApprove},
+			mockfunc: func() {
+				verificationRepo.EXPECT().Get(context, paging).Return(pending, 1, nil)
+				premiumFeatureRepo.EXPECT().Get(context, featurePaging).Return([]models.PremiumFeature{}, 0, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "grant error",
+			input: models.VerificationReview{Action: models.VerificationActionApprove},
+			mockfunc: func() {
+				verificationRepo.EXPECT().Get(context, paging).Return(pending, 1, nil)
+				premiumFeatureRepo.EXPECT().Get(context, featurePaging).Return(verified, 1, nil)
+				verificationRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				verificationRepo.EXPECT().Close(context, 3, approve).Return(true, nil)
+				subscriptionRepo.EXPECT().ExpireUserSubscriptions(context, 1, 2, mockTime).Return(nil)
+				subscriptionRepo.EXPECT().Create(context, grant).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "approve reviewed by another admin",
+			input: models.VerificationReview{Action: models.VerificationActionApprove},
+			mockfunc: func() {
+				verificationRepo.EXPECT().Get(context, paging).Return(pending, 1, nil)
+				premiumFeatureRepo.EXPECT().Get(context, featurePaging).Return(verified, 1, nil)
+				verificationRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				verificationRepo.EXPECT().Close(context, 3, approve).Return(false, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "approve grants the verified feature",
+			input: models.VerificationReview{Action: models.VerificationActionApprove},
+			mockfunc: func() {
+				verificationRepo.EXPECT().Get(context, paging).Return(pending, 1, nil)
+				premiumFeatureRepo.EXPECT().Get(context, featurePaging).Return(verified, 1, nil)
+				verificationRepo.EXPECT().Transaction(context, gomock.Any()).DoAndReturn(runTransaction)
+				verificationRepo.EXPECT().Close(context, 3, approve).Return(true, nil)
+				subscriptionRepo.EXPECT().ExpireUserSubscriptions(context, 1, 2, mockTime).Return(nil)
+				subscriptionRepo.EXPECT().Create(context, grant).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockfunc()
+
+			err := service.Review(context, 3, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verification.Review() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}